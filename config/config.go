@@ -36,6 +36,9 @@ type config struct {
 	Producer               *Producer
 	Consumer               *Consumer
 	Notification           *Notification
+	RateLimiter            *RateLimiter `yaml:"rateLimiter"`
+	Authentication         *Authentication
+	OAuth                  *OAuth
 }
 
 // Logger is settings of logger
@@ -186,7 +189,7 @@ type Consumer struct {
 type Notification struct {
 	Provider            string
 	RoomTopicNamePrefix string `yaml:"roomTopicNamePrefix"`
-	DefaultBadgeCount   string `yaml:"defaultBadgeCount"`
+	DefaultLang         string `yaml:"defaultLang"`
 
 	// Amazon SNS
 	AmazonSNS struct {
@@ -198,6 +201,61 @@ type Notification struct {
 	}
 }
 
+// RateLimiter is settings of message rate limiter
+type RateLimiter struct {
+	// Provider is where rate limit counters are kept. "local" keeps them in process, "datastore" shares them among API instances.
+	Provider string
+}
+
+const (
+	// AuthenticationModeHeader trusts the user and the workspace in the headers set by a gateway in front of the API
+	AuthenticationModeHeader = "header"
+	// AuthenticationModeJWT verifies the bearer token of each request
+	AuthenticationModeJWT = "jwt"
+)
+
+// Authentication is settings of how requests are authenticated
+type Authentication struct {
+	// Mode is "header" or "jwt". The default is "header".
+	Mode string
+	JWT  *JWT
+}
+
+// JWT is settings of the verification of bearer tokens
+type JWT struct {
+	// HMACSecret is a shared secret of HS256 tokens.
+	HMACSecret string `yaml:"hmacSecret"`
+	// PublicKeyFile is a PEM file of RSA or ECDSA public keys or certificates of RS256 and ES256 tokens.
+	PublicKeyFile string `yaml:"publicKeyFile"`
+	// JWKSFile is a JSON Web Key Set file. Symmetric (oct) keys are accepted only from the file.
+	JWKSFile string `yaml:"jwksFile"`
+	// JWKSURL is a JSON Web Key Set URL. The keys are cached and refreshed when a token has an unknown kid. Symmetric (oct) keys are ignored.
+	JWKSURL string `yaml:"jwksUrl"`
+	// Audience is required to be in the aud claim if it is set.
+	Audience string
+	// Issuer is required to be the iss claim if it is set.
+	Issuer string
+	// UserIDClaim is the claim of userId. The default is "sub".
+	UserIDClaim string `yaml:"userIdClaim"`
+	// WorkspaceClaim is the claim of workspace. If it is not set, the default database is used.
+	WorkspaceClaim string `yaml:"workspaceClaim"`
+	// ClientIDClaim is the claim of the app client the token is issued to. The default is "client_id".
+	ClientIDClaim string `yaml:"clientIdClaim"`
+	// RolesClaim is the claim of the role IDs of the user. The default is "roles".
+	RolesClaim string `yaml:"rolesClaim"`
+}
+
+// OAuth is settings of the client credentials grant of app clients
+type OAuth struct {
+	// SigningSecret is the HS256 secret with which access tokens are signed. The token endpoint is enabled if it is set.
+	// Then app clients are authenticated only by access tokens, so issue a secret of the first client before setting it.
+	SigningSecret string `yaml:"signingSecret"`
+	// AccessTokenExpiresIn is seconds until an access token expires. The default is 900.
+	AccessTokenExpiresIn int64 `yaml:"accessTokenExpiresIn"`
+	// SecretRotationOverlap is seconds the previous secrets of an app client stay valid after a rotation. The default is 86400.
+	SecretRotationOverlap int64 `yaml:"secretRotationOverlap"`
+}
+
 func NewConfig() *config {
 	log.SetFlags(log.Llongfile)
 
@@ -279,6 +337,21 @@ func defaultSetting() *config {
 		Producer:     &Producer{},
 		Consumer:     &Consumer{},
 		Notification: &Notification{},
+		RateLimiter: &RateLimiter{
+			Provider: "local",
+		},
+		Authentication: &Authentication{
+			Mode: AuthenticationModeHeader,
+			JWT: &JWT{
+				UserIDClaim:   "sub",
+				ClientIDClaim: "client_id",
+				RolesClaim:    "roles",
+			},
+		},
+		OAuth: &OAuth{
+			AccessTokenExpiresIn:  900,
+			SecretRotationOverlap: 86400,
+		},
 	}
 }
 
@@ -659,8 +732,8 @@ func (c *config) loadEnv() {
 	if v = os.Getenv("SWAG_NOTIFICATION_ROOM_TOPIC_NAME_PREFIX"); v != "" {
 		c.Notification.RoomTopicNamePrefix = v
 	}
-	if v = os.Getenv("SWAG_NOTIFICATION_DEFAULT_BADGE_COUNT"); v != "" {
-		c.Notification.DefaultBadgeCount = v
+	if v = os.Getenv("SWAG_NOTIFICATION_DEFAULT_LANG"); v != "" {
+		c.Notification.DefaultLang = v
 	}
 
 	// Notification - Amazon SNS
@@ -679,6 +752,63 @@ func (c *config) loadEnv() {
 	if v = os.Getenv("SWAG_NOTIFICATION_AMAZONSNS_APPLICATION_ARN_ANDROID"); v != "" {
 		c.Notification.AmazonSNS.ApplicationArnAndroid = v
 	}
+
+	// RateLimiter
+	if v = os.Getenv("SWAG_RATELIMITER_PROVIDER"); v != "" {
+		c.RateLimiter.Provider = v
+	}
+
+	// Authentication
+	if v = os.Getenv("SWAG_AUTHENTICATION_MODE"); v != "" {
+		c.Authentication.Mode = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_HMAC_SECRET"); v != "" {
+		c.Authentication.JWT.HMACSecret = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_PUBLIC_KEY_FILE"); v != "" {
+		c.Authentication.JWT.PublicKeyFile = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_JWKS_FILE"); v != "" {
+		c.Authentication.JWT.JWKSFile = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_JWKS_URL"); v != "" {
+		c.Authentication.JWT.JWKSURL = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_AUDIENCE"); v != "" {
+		c.Authentication.JWT.Audience = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_ISSUER"); v != "" {
+		c.Authentication.JWT.Issuer = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_USER_ID_CLAIM"); v != "" {
+		c.Authentication.JWT.UserIDClaim = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_WORKSPACE_CLAIM"); v != "" {
+		c.Authentication.JWT.WorkspaceClaim = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_CLIENT_ID_CLAIM"); v != "" {
+		c.Authentication.JWT.ClientIDClaim = v
+	}
+	if v = os.Getenv("SWAG_AUTHENTICATION_JWT_ROLES_CLAIM"); v != "" {
+		c.Authentication.JWT.RolesClaim = v
+	}
+
+	// OAuth
+	if v = os.Getenv("SWAG_OAUTH_SIGNING_SECRET"); v != "" {
+		c.OAuth.SigningSecret = v
+	}
+	if v = os.Getenv("SWAG_OAUTH_ACCESS_TOKEN_EXPIRES_IN"); v != "" {
+		expiresIn, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			c.OAuth.AccessTokenExpiresIn = expiresIn
+		}
+	}
+	if v = os.Getenv("SWAG_OAUTH_SECRET_ROTATION_OVERLAP"); v != "" {
+		overlap, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			c.OAuth.SecretRotationOverlap = overlap
+		}
+	}
 }
 
 func (c *config) parseFlag(args []string) error {
@@ -847,6 +977,7 @@ func (c *config) parseFlag(args []string) error {
 	// Notification
 	flags.StringVar(&c.Notification.Provider, "notification.provider", c.Notification.Provider, "")
 	flags.StringVar(&c.Notification.RoomTopicNamePrefix, "notification.roomTopicNamePrefix", c.Notification.RoomTopicNamePrefix, "")
+	flags.StringVar(&c.Notification.DefaultLang, "notification.defaultLang", c.Notification.DefaultLang, "Language of push notifications for users without lang")
 
 	// Notification - Amazon SNS
 	flags.StringVar(&c.Notification.AmazonSNS.Region, "notification.amazonsns.region", c.Notification.AmazonSNS.Region, "")
@@ -855,6 +986,27 @@ func (c *config) parseFlag(args []string) error {
 	flags.StringVar(&c.Notification.AmazonSNS.ApplicationArnIos, "notification.amazonsns.applicationArnIos", c.Notification.AmazonSNS.ApplicationArnIos, "")
 	flags.StringVar(&c.Notification.AmazonSNS.ApplicationArnAndroid, "notification.amazonsns.applicationArnAndroid", c.Notification.AmazonSNS.ApplicationArnAndroid, "")
 
+	// RateLimiter
+	flags.StringVar(&c.RateLimiter.Provider, "rateLimiter.provider", c.RateLimiter.Provider, "local or datastore")
+
+	// Authentication
+	flags.StringVar(&c.Authentication.Mode, "authentication.mode", c.Authentication.Mode, "header or jwt")
+	flags.StringVar(&c.Authentication.JWT.HMACSecret, "authentication.jwt.hmacSecret", c.Authentication.JWT.HMACSecret, "Shared secret of HS256 tokens")
+	flags.StringVar(&c.Authentication.JWT.PublicKeyFile, "authentication.jwt.publicKeyFile", c.Authentication.JWT.PublicKeyFile, "PEM file of public keys of RS256 and ES256 tokens")
+	flags.StringVar(&c.Authentication.JWT.JWKSFile, "authentication.jwt.jwksFile", c.Authentication.JWT.JWKSFile, "JSON Web Key Set file")
+	flags.StringVar(&c.Authentication.JWT.JWKSURL, "authentication.jwt.jwksUrl", c.Authentication.JWT.JWKSURL, "JSON Web Key Set URL")
+	flags.StringVar(&c.Authentication.JWT.Audience, "authentication.jwt.audience", c.Authentication.JWT.Audience, "Required aud claim")
+	flags.StringVar(&c.Authentication.JWT.Issuer, "authentication.jwt.issuer", c.Authentication.JWT.Issuer, "Required iss claim")
+	flags.StringVar(&c.Authentication.JWT.UserIDClaim, "authentication.jwt.userIdClaim", c.Authentication.JWT.UserIDClaim, "Claim of userId")
+	flags.StringVar(&c.Authentication.JWT.WorkspaceClaim, "authentication.jwt.workspaceClaim", c.Authentication.JWT.WorkspaceClaim, "Claim of workspace")
+	flags.StringVar(&c.Authentication.JWT.ClientIDClaim, "authentication.jwt.clientIdClaim", c.Authentication.JWT.ClientIDClaim, "Claim of clientId")
+	flags.StringVar(&c.Authentication.JWT.RolesClaim, "authentication.jwt.rolesClaim", c.Authentication.JWT.RolesClaim, "Claim of role IDs")
+
+	// OAuth
+	flags.StringVar(&c.OAuth.SigningSecret, "oauth.signingSecret", c.OAuth.SigningSecret, "HS256 secret of access tokens")
+	flags.Int64Var(&c.OAuth.AccessTokenExpiresIn, "oauth.accessTokenExpiresIn", c.OAuth.AccessTokenExpiresIn, "Seconds until an access token expires")
+	flags.Int64Var(&c.OAuth.SecretRotationOverlap, "oauth.secretRotationOverlap", c.OAuth.SecretRotationOverlap, "Seconds the previous secrets stay valid after a rotation")
+
 	configPath := ""
 	flags.StringVar(&configPath, "config", "", "config file(yaml format)")
 
@@ -1000,6 +1152,41 @@ func (c *config) validate() error {
 		}
 	}
 
+	// RateLimiter
+	if c.RateLimiter.Provider == "" {
+		c.RateLimiter.Provider = "local"
+	}
+	p := c.RateLimiter.Provider
+	if !(p == "local" || p == "datastore") {
+		return errors.New("Please set rateLimiter.provider to \"local\" or \"datastore\"")
+	}
+
+	// Authentication
+	if c.Authentication.Mode == "" {
+		c.Authentication.Mode = AuthenticationModeHeader
+	}
+	m := c.Authentication.Mode
+	if !(m == AuthenticationModeHeader || m == AuthenticationModeJWT) {
+		return errors.New("Please set authentication.mode to \"header\" or \"jwt\"")
+	}
+	if m == AuthenticationModeJWT {
+		j := c.Authentication.JWT
+		if j.HMACSecret == "" && j.PublicKeyFile == "" && j.JWKSFile == "" && j.JWKSURL == "" && c.OAuth.SigningSecret == "" {
+			return errors.New("Please set authentication.jwt.hmacSecret, authentication.jwt.publicKeyFile, authentication.jwt.jwksFile, authentication.jwt.jwksUrl or oauth.signingSecret")
+		}
+		if j.UserIDClaim == "" {
+			j.UserIDClaim = "sub"
+		}
+	}
+
+	// OAuth
+	if c.OAuth.AccessTokenExpiresIn <= 0 {
+		return errors.New("Please set oauth.accessTokenExpiresIn to a positive number of seconds")
+	}
+	if c.OAuth.SecretRotationOverlap < 0 {
+		return errors.New("Please set oauth.secretRotationOverlap to zero or a positive number of seconds")
+	}
+
 	return nil
 }
 
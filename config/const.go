@@ -34,6 +34,7 @@ const (
 	CtxWorkspace
 	CtxRoomUser
 	CtxSubscription
+	CtxRoleIDs
 
 	RoleGeneral int32 = 1
 
@@ -0,0 +1,11 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type appClientSecretStore interface {
+	createAppClientSecretStore()
+
+	InsertAppClientSecret(appClientSecret *model.AppClientSecret) error
+	SelectAppClientSecrets(clientID string) ([]*model.AppClientSecret, error)
+	UpdateAppClientSecret(appClientSecret *model.AppClientSecret) error
+}
@@ -26,4 +26,6 @@ type appClientStore interface {
 
 	InsertAppClient(appClient *model.AppClient) error
 	SelectLatestAppClient(opts ...SelectAppClientOption) (*model.AppClient, error)
+	SelectAppClients() ([]*model.AppClient, error)
+	UpdateAppClient(appClient *model.AppClient) error
 }
@@ -2,9 +2,22 @@ package datastore
 
 import "github.com/swagchat/chat-api/model"
 
+type selectAssetsOptions struct {
+	userID string
+}
+
+type SelectAssetsOption func(*selectAssetsOptions)
+
+func SelectAssetsOptionFilterByUserID(userID string) SelectAssetsOption {
+	return func(ops *selectAssetsOptions) {
+		ops.userID = userID
+	}
+}
+
 type assetStore interface {
 	createAssetStore()
 
 	InsertAsset(asset *model.Asset) error
+	SelectAssets(opts ...SelectAssetsOption) ([]*model.Asset, error)
 	SelectAsset(assetID string) (*model.Asset, error)
 }
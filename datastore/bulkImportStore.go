@@ -0,0 +1,25 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type selectBulkImportRowResultsOptions struct {
+	status model.BulkImportRowStatus
+}
+
+type SelectBulkImportRowResultsOption func(*selectBulkImportRowResultsOptions)
+
+func SelectBulkImportRowResultsOptionFilterByStatus(status model.BulkImportRowStatus) SelectBulkImportRowResultsOption {
+	return func(ops *selectBulkImportRowResultsOptions) {
+		ops.status = status
+	}
+}
+
+type bulkImportStore interface {
+	createBulkImportStore()
+
+	InsertBulkImport(bulkImport *model.BulkImport) error
+	SelectBulkImport(importID string) (*model.BulkImport, error)
+	UpdateBulkImport(bulkImport *model.BulkImport) error
+	InsertBulkImportBatch(bulkImport *model.BulkImport, batch *model.BulkImportBatch) error
+	SelectBulkImportRowResults(importID string, limit, offset int32, opts ...SelectBulkImportRowResultsOption) ([]*model.BulkImportRowResult, error)
+}
@@ -1,10 +1,69 @@
 package datastore
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
 )
 
+// addColumnsIfNotExist adds columns to a table created by an older version, because CreateTablesIfNotExists does not alter existing tables.
+// Each definition is "name TYPE ...", and the columns that already exist are skipped
+func addColumnsIfNotExist(dbMap *gorp.DbMap, tableName string, columnDefinitions []string) error {
+	for _, columnDefinition := range columnDefinitions {
+		exist, err := columnExists(dbMap, tableName, strings.Fields(columnDefinition)[0])
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", tableName, columnDefinition)
+		_, err = dbMap.Exec(query)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnExists reports whether the table has the column, inspecting the schema of the database
+func columnExists(dbMap *gorp.DbMap, tableName, columnName string) (bool, error) {
+	if config.Config().Datastore.Provider != "sqlite" {
+		query := "SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME=? AND COLUMN_NAME=?;"
+		count, err := dbMap.SelectInt(query, tableName, columnName)
+		if err != nil {
+			return false, err
+		}
+		return count > 0, nil
+	}
+
+	rows, err := dbMap.Db.Query(fmt.Sprintf("PRAGMA table_info(%s);", tableName))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	// Each row is cid, name, type, notnull, dflt_value and pk
+	for rows.Next() {
+		var cid, notNull, pk int64
+		var name, columnType string
+		var defaultValue interface{}
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+			return false, err
+		}
+		if name == columnName {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
 // makePrepareExpressionParamsForInOperand makes prepare expression for in operand
 func makePrepareExpressionParamsForInOperand(target interface{}) (string, map[string]interface{}) {
 	bindParams := make(map[string]interface{})
@@ -51,3 +110,66 @@ func makePrepareExpressionForInOperand(target interface{}) (string, []interface{
 	}
 	return query, bindParams
 }
+
+// makeMetaDataFilterCondition makes AND conditions on the JSON fields of a metaData column.
+// Values are compared as text, so that numbers and booleans match their query parameter representation
+func makeMetaDataFilterCondition(column string, filters []*model.MetaDataFilter) (string, map[string]interface{}) {
+	query := ""
+	params := make(map[string]interface{})
+	sqlite := config.Config().Datastore.Provider == "sqlite"
+
+	for i, mdf := range filters {
+		pathParam := fmt.Sprintf("mdPath%d", i)
+		params[pathParam] = mdf.Path()
+
+		var value, exists string
+		if sqlite {
+			value = fmt.Sprintf("(CASE json_type(%s, :%s) WHEN 'true' THEN 'true' WHEN 'false' THEN 'false' ELSE CAST(json_extract(%s, :%s) AS TEXT) END)", column, pathParam, column, pathParam)
+			exists = fmt.Sprintf("json_type(%s, :%s) IS NOT NULL", column, pathParam)
+		} else {
+			value = fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, :%s))", column, pathParam)
+			exists = fmt.Sprintf("JSON_CONTAINS_PATH(%s, 'one', :%s)=1", column, pathParam)
+		}
+
+		switch mdf.Operator {
+		case model.MetaDataFilterOperatorEq:
+			valueParam := fmt.Sprintf("mdValue%d", i)
+			query = fmt.Sprintf("%s AND %s=:%s", query, value, valueParam)
+			params[valueParam] = mdf.Values[0]
+		case model.MetaDataFilterOperatorIn:
+			valueParams := make([]string, len(mdf.Values))
+			for j, v := range mdf.Values {
+				valueParam := fmt.Sprintf("mdValue%d_%d", i, j)
+				valueParams[j] = ":" + valueParam
+				params[valueParam] = v
+			}
+			query = fmt.Sprintf("%s AND %s IN (%s)", query, value, strings.Join(valueParams, ", "))
+		case model.MetaDataFilterOperatorExists:
+			if mdf.Exists {
+				query = fmt.Sprintf("%s AND %s", query, exists)
+			} else {
+				query = fmt.Sprintf("%s AND NOT (%s)", query, exists)
+			}
+		}
+	}
+
+	return query, params
+}
+
+// likeEscape is the escape character of LIKE patterns. Backslash is avoided because
+// MySQL and SQLite disagree about escaping it in string literals
+const likeEscape = "!"
+
+// escapeLikePattern escapes the wildcards of LIKE in s
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_").Replace(s)
+}
+
+// makeFuzzyLikePattern makes a LIKE pattern that matches strings containing the characters of s in order
+func makeFuzzyLikePattern(s string) string {
+	pattern := "%"
+	for _, r := range s {
+		pattern += escapeLikePattern(string(r)) + "%"
+	}
+	return pattern
+}
@@ -0,0 +1,11 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type contactStore interface {
+	createContactStore()
+
+	SelectContact(userID, contactUserID string) (*model.Contact, error)
+	SelectContactUserIDs(userID string, contactUserIDs []string) ([]string, error)
+	DeleteContacts(userID, contactUserID string) error
+}
@@ -20,6 +20,7 @@ func InsertDeviceOptionBeforeClean(beforeClean bool) InsertDeviceOption {
 type selectDevicesOptions struct {
 	deleted  bool
 	userID   string
+	userIDs  []string
 	platform scpb.Platform
 	token    string
 }
@@ -38,6 +39,12 @@ func SelectDevicesOptionFilterByUserID(userID string) SelectDevicesOption {
 	}
 }
 
+func SelectDevicesOptionFilterByUserIDs(userIDs []string) SelectDevicesOption {
+	return func(ops *selectDevicesOptions) {
+		ops.userIDs = userIDs
+	}
+}
+
 func SelectDevicesOptionFilterByPlatform(platform scpb.Platform) SelectDevicesOption {
 	return func(ops *selectDevicesOptions) {
 		ops.platform = platform
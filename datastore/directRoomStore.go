@@ -0,0 +1,10 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type directRoomStore interface {
+	createDirectRoomStore()
+
+	InsertDirectRoom(directRoom *model.DirectRoom) error
+	SelectDirectRoom(userID, otherUserID string) (*model.DirectRoom, error)
+}
@@ -0,0 +1,54 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestStoreInsertDirectRoom = "[store] insert direct room test"
+	TestStoreSelectDirectRoom = "[store] select direct room test"
+)
+
+func TestDirectRoomStore(t *testing.T) {
+	t.Run(TestStoreInsertDirectRoom, func(t *testing.T) {
+		newDirectRoom := model.NewDirectRoom("direct-room-store-room-id-0001", "direct-room-store-user-id-0002", "direct-room-store-user-id-0001")
+		err := Provider(ctx).InsertDirectRoom(newDirectRoom)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertDirectRoom, err.Error())
+		}
+
+		duplicateDirectRoom := model.NewDirectRoom("direct-room-store-room-id-0002", "direct-room-store-user-id-0001", "direct-room-store-user-id-0002")
+		err = Provider(ctx).InsertDirectRoom(duplicateDirectRoom)
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil, but it was nil", TestStoreInsertDirectRoom)
+		}
+	})
+
+	t.Run(TestStoreSelectDirectRoom, func(t *testing.T) {
+		directRoom, err := Provider(ctx).SelectDirectRoom("direct-room-store-user-id-0001", "direct-room-store-user-id-0002")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectDirectRoom, err.Error())
+		}
+		if directRoom == nil || directRoom.RoomID != "direct-room-store-room-id-0001" {
+			t.Fatalf("Failed to %s. Expected directRoom.RoomID to be \"direct-room-store-room-id-0001\", but it was not", TestStoreSelectDirectRoom)
+		}
+
+		directRoom, err = Provider(ctx).SelectDirectRoom("direct-room-store-user-id-0002", "direct-room-store-user-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectDirectRoom, err.Error())
+		}
+		if directRoom == nil {
+			t.Fatalf("Failed to %s. Expected directRoom to be not nil, but it was nil", TestStoreSelectDirectRoom)
+		}
+
+		directRoom, err = Provider(ctx).SelectDirectRoom("direct-room-store-user-id-0001", "direct-room-store-user-id-0003")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectDirectRoom, err.Error())
+		}
+		if directRoom != nil {
+			t.Fatalf("Failed to %s. Expected directRoom to be nil, but it was not nil", TestStoreSelectDirectRoom)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type selectFriendRequestsOptions struct {
+	requesterUserID string
+	addresseeUserID string
+	status          model.FriendRequestStatus
+}
+
+type SelectFriendRequestsOption func(*selectFriendRequestsOptions)
+
+func SelectFriendRequestsOptionFilterByRequesterUserID(requesterUserID string) SelectFriendRequestsOption {
+	return func(ops *selectFriendRequestsOptions) {
+		ops.requesterUserID = requesterUserID
+	}
+}
+
+func SelectFriendRequestsOptionFilterByAddresseeUserID(addresseeUserID string) SelectFriendRequestsOption {
+	return func(ops *selectFriendRequestsOptions) {
+		ops.addresseeUserID = addresseeUserID
+	}
+}
+
+func SelectFriendRequestsOptionFilterByStatus(status model.FriendRequestStatus) SelectFriendRequestsOption {
+	return func(ops *selectFriendRequestsOptions) {
+		ops.status = status
+	}
+}
+
+type updateFriendRequestOptions struct {
+	contacts []*model.Contact
+}
+
+type UpdateFriendRequestOption func(*updateFriendRequestOptions)
+
+func UpdateFriendRequestOptionWithContacts(contacts []*model.Contact) UpdateFriendRequestOption {
+	return func(ops *updateFriendRequestOptions) {
+		ops.contacts = contacts
+	}
+}
+
+type friendRequestStore interface {
+	createFriendRequestStore()
+
+	InsertFriendRequest(friendRequest *model.FriendRequest) error
+	SelectFriendRequests(opts ...SelectFriendRequestsOption) ([]*model.FriendRequest, error)
+	SelectFriendRequest(friendRequestID string) (*model.FriendRequest, error)
+	UpdateFriendRequest(friendRequest *model.FriendRequest, opts ...UpdateFriendRequestOption) error
+}
@@ -0,0 +1,23 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *gcpSQLProvider) createAppClientSecretStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateAppClientSecretStore(p.ctx, master)
+}
+
+func (p *gcpSQLProvider) InsertAppClientSecret(appClientSecret *model.AppClientSecret) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertAppClientSecret(p.ctx, master, appClientSecret)
+}
+
+func (p *gcpSQLProvider) SelectAppClientSecrets(clientID string) ([]*model.AppClientSecret, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectAppClientSecrets(p.ctx, replica, clientID)
+}
+
+func (p *gcpSQLProvider) UpdateAppClientSecret(appClientSecret *model.AppClientSecret) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateAppClientSecret(p.ctx, master, appClientSecret)
+}
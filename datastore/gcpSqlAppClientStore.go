@@ -16,3 +16,13 @@ func (p *gcpSQLProvider) SelectLatestAppClient(opts ...SelectAppClientOption) (*
 	replica := RdbStore(p.database).replica()
 	return rdbSelectLatestAppClient(p.ctx, replica, opts...)
 }
+
+func (p *gcpSQLProvider) SelectAppClients() ([]*model.AppClient, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectAppClients(p.ctx, replica)
+}
+
+func (p *gcpSQLProvider) UpdateAppClient(appClient *model.AppClient) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateAppClient(p.ctx, master, appClient)
+}
@@ -12,6 +12,11 @@ func (p *gcpSQLProvider) InsertAsset(asset *model.Asset) error {
 	return rdbInsertAsset(p.ctx, master, asset)
 }
 
+func (p *gcpSQLProvider) SelectAssets(opts ...SelectAssetsOption) ([]*model.Asset, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectAssets(p.ctx, replica, opts...)
+}
+
 func (p *gcpSQLProvider) SelectAsset(assetID string) (*model.Asset, error) {
 	replica := RdbStore(p.database).replica()
 	return rdbSelectAsset(p.ctx, replica, assetID)
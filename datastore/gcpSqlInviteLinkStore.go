@@ -0,0 +1,63 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *gcpSQLProvider) createInviteLinkStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateInviteLinkStore(p.ctx, master)
+}
+
+func (p *gcpSQLProvider) InsertInviteLink(inviteLink *model.InviteLink) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertInviteLink(p.ctx, master, inviteLink)
+}
+
+func (p *gcpSQLProvider) SelectInviteLinks(opts ...SelectInviteLinksOption) ([]*model.InviteLink, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectInviteLinks(p.ctx, replica, opts...)
+}
+
+func (p *gcpSQLProvider) SelectInviteLink(token string) (*model.InviteLink, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectInviteLink(p.ctx, replica, token)
+}
+
+func (p *gcpSQLProvider) UpdateInviteLink(inviteLink *model.InviteLink) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateInviteLink(p.ctx, master, inviteLink)
+}
+
+func (p *gcpSQLProvider) InsertInviteLinkUse(inviteLinkUse *model.InviteLinkUse) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting invite link use")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbInsertInviteLinkUse(p.ctx, master, tx, inviteLinkUse)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while inserting invite link use")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (p *gcpSQLProvider) SelectInviteLinkUses(token string) ([]*model.InviteLinkUse, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectInviteLinkUses(p.ctx, replica, token)
+}
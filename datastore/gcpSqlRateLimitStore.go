@@ -0,0 +1,24 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *gcpSQLProvider) createRateLimitStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateRateLimitStore(p.ctx, master)
+}
+
+func (p *gcpSQLProvider) InsertRateLimit(rateLimit *model.RateLimit) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertRateLimit(p.ctx, master, rateLimit)
+}
+
+func (p *gcpSQLProvider) SelectRateLimit(limitKey string) (*model.RateLimit, error) {
+	// Read from master so that the tat passed to UpdateRateLimit is not stale
+	master := RdbStore(p.database).master()
+	return rdbSelectRateLimit(p.ctx, master, limitKey)
+}
+
+func (p *gcpSQLProvider) UpdateRateLimit(rateLimit *model.RateLimit, previousTat int64) (bool, error) {
+	master := RdbStore(p.database).master()
+	return rdbUpdateRateLimit(p.ctx, master, rateLimit, previousTat)
+}
@@ -0,0 +1,58 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *gcpSQLProvider) createRoomFolderStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateRoomFolderStore(p.ctx, master)
+}
+
+func (p *gcpSQLProvider) InsertRoomFolder(roomFolder *model.RoomFolder) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertRoomFolder(p.ctx, master, roomFolder)
+}
+
+func (p *gcpSQLProvider) SelectRoomFolders(userID string) ([]*model.RoomFolder, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomFolders(p.ctx, replica, userID)
+}
+
+func (p *gcpSQLProvider) SelectRoomFolder(folderID string) (*model.RoomFolder, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomFolder(p.ctx, replica, folderID)
+}
+
+func (p *gcpSQLProvider) UpdateRoomFolder(roomFolder *model.RoomFolder) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateRoomFolder(p.ctx, master, roomFolder)
+}
+
+func (p *gcpSQLProvider) DeleteRoomFolder(roomFolder *model.RoomFolder) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting room folder")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbDeleteRoomFolder(p.ctx, master, tx, roomFolder)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while deleting room folder")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -57,6 +57,11 @@ func (p *gcpSQLProvider) SelectUserIDsOfRoomUser(opts ...SelectUserIDsOfRoomUser
 	return rdbSelectUserIDsOfRoomUser(p.ctx, replica, opts...)
 }
 
+func (p *gcpSQLProvider) SelectRoomMateUserIDs(userID string, userIDs []string) ([]string, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomMateUserIDs(p.ctx, replica, userID, userIDs)
+}
+
 func (p *gcpSQLProvider) SelectMiniRoom(roomID, userID string) (*model.MiniRoom, error) {
 	replica := RdbStore(p.database).replica()
 	return rdbSelectMiniRoom(p.ctx, replica, roomID, userID)
@@ -72,6 +77,16 @@ func (p *gcpSQLProvider) SelectCountMiniRooms(userID string, opts ...SelectMiniR
 	return rdbSelectCountMiniRooms(p.ctx, replica, userID, opts...)
 }
 
+func (p *gcpSQLProvider) SelectRoomUnreadCounts(userID string) ([]*model.RoomUnreadCount, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomUnreadCounts(p.ctx, replica, userID)
+}
+
+func (p *gcpSQLProvider) SelectUnreadCountsOfRoomMembers(roomID string) ([]*model.UserUnreadCount, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUnreadCountsOfRoomMembers(p.ctx, replica, roomID)
+}
+
 func (p *gcpSQLProvider) UpdateRoomUser(roomUser *model.RoomUser) error {
 	master := RdbStore(p.database).master()
 	tx, err := master.Begin()
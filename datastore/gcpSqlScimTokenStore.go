@@ -0,0 +1,23 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *gcpSQLProvider) createScimTokenStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateScimTokenStore(p.ctx, master)
+}
+
+func (p *gcpSQLProvider) InsertScimToken(scimToken *model.ScimToken) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertScimToken(p.ctx, master, scimToken)
+}
+
+func (p *gcpSQLProvider) SelectScimToken(opts ...SelectScimTokenOption) (*model.ScimToken, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectScimToken(p.ctx, replica, opts...)
+}
+
+func (p *gcpSQLProvider) UpdateScimToken(scimToken *model.ScimToken) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateScimToken(p.ctx, master, scimToken)
+}
@@ -0,0 +1,43 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *gcpSQLProvider) createUserStatusStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateUserStatusStore(p.ctx, master)
+}
+
+func (p *gcpSQLProvider) UpdateUserStatus(user *model.User, userStatusLog *model.UserStatusLog) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating user status")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbUpdateUserStatus(p.ctx, master, tx, user, userStatusLog)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while updating user status")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (p *gcpSQLProvider) SelectUserStatusLogs(userID string, limit, offset int32) ([]*model.UserStatusLog, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUserStatusLogs(p.ctx, replica, userID, limit, offset)
+}
@@ -47,9 +47,9 @@ func (p *gcpSQLProvider) SelectUser(userID string, opts ...SelectUserOption) (*m
 	return rdbSelectUser(p.ctx, replica, userID, opts...)
 }
 
-func (p *gcpSQLProvider) SelectCountUsers() (int64, error) {
+func (p *gcpSQLProvider) SelectCountUsers(opts ...SelectUsersOption) (int64, error) {
 	replica := RdbStore(p.database).replica()
-	return rdbSelectCountUsers(p.ctx, replica)
+	return rdbSelectCountUsers(p.ctx, replica, opts...)
 }
 
 func (p *gcpSQLProvider) SelectUserIDsOfUser(userIDs []string) ([]string, error) {
@@ -57,6 +57,11 @@ func (p *gcpSQLProvider) SelectUserIDsOfUser(userIDs []string) ([]string, error)
 	return rdbSelectUserIDsOfUser(p.ctx, replica, userIDs)
 }
 
+func (p *gcpSQLProvider) SelectUsersByUserIDs(userIDs []string) ([]*model.User, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUsersByUserIDs(p.ctx, replica, userIDs)
+}
+
 func (p *gcpSQLProvider) UpdateUser(user *model.User, opts ...UpdateUserOption) error {
 	master := RdbStore(p.database).master()
 	tx, err := master.Begin()
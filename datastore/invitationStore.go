@@ -0,0 +1,45 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type selectInvitationsOptions struct {
+	roomID         string
+	inviteeUserID  string
+	status         model.InvitationStatus
+	excludeExpired bool
+}
+
+type SelectInvitationsOption func(*selectInvitationsOptions)
+
+func SelectInvitationsOptionFilterByRoomID(roomID string) SelectInvitationsOption {
+	return func(ops *selectInvitationsOptions) {
+		ops.roomID = roomID
+	}
+}
+
+func SelectInvitationsOptionFilterByInviteeUserID(inviteeUserID string) SelectInvitationsOption {
+	return func(ops *selectInvitationsOptions) {
+		ops.inviteeUserID = inviteeUserID
+	}
+}
+
+func SelectInvitationsOptionFilterByStatus(status model.InvitationStatus) SelectInvitationsOption {
+	return func(ops *selectInvitationsOptions) {
+		ops.status = status
+	}
+}
+
+func SelectInvitationsOptionExcludeExpired(excludeExpired bool) SelectInvitationsOption {
+	return func(ops *selectInvitationsOptions) {
+		ops.excludeExpired = excludeExpired
+	}
+}
+
+type invitationStore interface {
+	createInvitationStore()
+
+	InsertInvitations(invitations []*model.Invitation) error
+	SelectInvitations(opts ...SelectInvitationsOption) ([]*model.Invitation, error)
+	SelectInvitation(invitationID string) (*model.Invitation, error)
+	UpdateInvitation(invitation *model.Invitation) error
+}
@@ -0,0 +1,41 @@
+package datastore
+
+import (
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+var (
+	// ErrInviteLinkExhausted is returned when an invite link is revoked or has reached its maximum number of uses while it is redeemed
+	ErrInviteLinkExhausted = errors.New("The invite link has no uses left")
+)
+
+type selectInviteLinksOptions struct {
+	roomID         string
+	excludeRevoked bool
+}
+
+type SelectInviteLinksOption func(*selectInviteLinksOptions)
+
+func SelectInviteLinksOptionFilterByRoomID(roomID string) SelectInviteLinksOption {
+	return func(ops *selectInviteLinksOptions) {
+		ops.roomID = roomID
+	}
+}
+
+func SelectInviteLinksOptionExcludeRevoked(excludeRevoked bool) SelectInviteLinksOption {
+	return func(ops *selectInviteLinksOptions) {
+		ops.excludeRevoked = excludeRevoked
+	}
+}
+
+type inviteLinkStore interface {
+	createInviteLinkStore()
+
+	InsertInviteLink(inviteLink *model.InviteLink) error
+	SelectInviteLinks(opts ...SelectInviteLinksOption) ([]*model.InviteLink, error)
+	SelectInviteLink(token string) (*model.InviteLink, error)
+	UpdateInviteLink(inviteLink *model.InviteLink) error
+	InsertInviteLinkUse(inviteLinkUse *model.InviteLinkUse) error
+	SelectInviteLinkUses(token string) ([]*model.InviteLinkUse, error)
+}
@@ -0,0 +1,112 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestStoreInsertInviteLink    = "[store] insert invite link test"
+	TestStoreSelectInviteLinks   = "[store] select invite links test"
+	TestStoreInsertInviteLinkUse = "[store] insert invite link use test"
+	TestStoreUpdateInviteLink    = "[store] update invite link test"
+)
+
+func TestInviteLinkStore(t *testing.T) {
+	t.Run(TestStoreInsertInviteLink, func(t *testing.T) {
+		nowTimestamp := time.Now().Unix()
+		newInviteLink := &model.InviteLink{}
+		newInviteLink.Token = "invitelinkstoretoken0001"
+		newInviteLink.RoomID = "invite-link-store-room-id-0001"
+		newInviteLink.CreatorUserID = "invite-link-store-user-id-0001"
+		newInviteLink.MaxUses = 1
+		newInviteLink.Created = nowTimestamp
+		newInviteLink.Modified = nowTimestamp
+		err := Provider(ctx).InsertInviteLink(newInviteLink)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertInviteLink, err.Error())
+		}
+	})
+
+	t.Run(TestStoreSelectInviteLinks, func(t *testing.T) {
+		inviteLinks, err := Provider(ctx).SelectInviteLinks(
+			SelectInviteLinksOptionFilterByRoomID("invite-link-store-room-id-0001"),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectInviteLinks, err.Error())
+		}
+		if len(inviteLinks) != 1 {
+			t.Fatalf("Failed to %s. Expected inviteLinks count to be 1, but it was %d", TestStoreSelectInviteLinks, len(inviteLinks))
+		}
+
+		_, err = Provider(ctx).SelectInviteLinks()
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil, but it was nil", TestStoreSelectInviteLinks)
+		}
+	})
+
+	t.Run(TestStoreInsertInviteLinkUse, func(t *testing.T) {
+		newInviteLinkUse := &model.InviteLinkUse{}
+		newInviteLinkUse.Token = "invitelinkstoretoken0001"
+		newInviteLinkUse.RoomID = "invite-link-store-room-id-0001"
+		newInviteLinkUse.UserID = "invite-link-store-user-id-0002"
+		newInviteLinkUse.Created = time.Now().Unix()
+		err := Provider(ctx).InsertInviteLinkUse(newInviteLinkUse)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertInviteLinkUse, err.Error())
+		}
+
+		inviteLink, err := Provider(ctx).SelectInviteLink("invitelinkstoretoken0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertInviteLinkUse, err.Error())
+		}
+		if inviteLink.Uses != 1 {
+			t.Fatalf("Failed to %s. Expected inviteLink.Uses to be 1, but it was %d", TestStoreInsertInviteLinkUse, inviteLink.Uses)
+		}
+
+		inviteLinkUses, err := Provider(ctx).SelectInviteLinkUses("invitelinkstoretoken0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertInviteLinkUse, err.Error())
+		}
+		if len(inviteLinkUses) != 1 {
+			t.Fatalf("Failed to %s. Expected inviteLinkUses count to be 1, but it was %d", TestStoreInsertInviteLinkUse, len(inviteLinkUses))
+		}
+
+		newInviteLinkUse.ID = 0
+		newInviteLinkUse.UserID = "invite-link-store-user-id-0003"
+		err = Provider(ctx).InsertInviteLinkUse(newInviteLinkUse)
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil, but it was nil", TestStoreInsertInviteLinkUse)
+		}
+		if errors.Cause(err) != ErrInviteLinkExhausted {
+			t.Fatalf("Failed to %s. Expected err to be ErrInviteLinkExhausted, but it was [%s]", TestStoreInsertInviteLinkUse, err.Error())
+		}
+	})
+
+	t.Run(TestStoreUpdateInviteLink, func(t *testing.T) {
+		inviteLink, err := Provider(ctx).SelectInviteLink("invitelinkstoretoken0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateInviteLink, err.Error())
+		}
+
+		inviteLink.Revoke()
+		err = Provider(ctx).UpdateInviteLink(inviteLink)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateInviteLink, err.Error())
+		}
+
+		inviteLinks, err := Provider(ctx).SelectInviteLinks(
+			SelectInviteLinksOptionFilterByRoomID("invite-link-store-room-id-0001"),
+			SelectInviteLinksOptionExcludeRevoked(true),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateInviteLink, err.Error())
+		}
+		if len(inviteLinks) != 0 {
+			t.Fatalf("Failed to %s. Expected inviteLinks count to be 0, but it was %d", TestStoreUpdateInviteLink, len(inviteLinks))
+		}
+	})
+}
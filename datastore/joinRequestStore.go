@@ -0,0 +1,38 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type selectJoinRequestsOptions struct {
+	roomID string
+	userID string
+	status model.JoinRequestStatus
+}
+
+type SelectJoinRequestsOption func(*selectJoinRequestsOptions)
+
+func SelectJoinRequestsOptionFilterByRoomID(roomID string) SelectJoinRequestsOption {
+	return func(ops *selectJoinRequestsOptions) {
+		ops.roomID = roomID
+	}
+}
+
+func SelectJoinRequestsOptionFilterByUserID(userID string) SelectJoinRequestsOption {
+	return func(ops *selectJoinRequestsOptions) {
+		ops.userID = userID
+	}
+}
+
+func SelectJoinRequestsOptionFilterByStatus(status model.JoinRequestStatus) SelectJoinRequestsOption {
+	return func(ops *selectJoinRequestsOptions) {
+		ops.status = status
+	}
+}
+
+type joinRequestStore interface {
+	createJoinRequestStore()
+
+	InsertJoinRequest(joinRequest *model.JoinRequest) error
+	SelectJoinRequests(opts ...SelectJoinRequestsOption) ([]*model.JoinRequest, error)
+	SelectJoinRequest(joinRequestID string) (*model.JoinRequest, error)
+	UpdateJoinRequest(joinRequest *model.JoinRequest) error
+}
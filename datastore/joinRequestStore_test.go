@@ -0,0 +1,73 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestStoreInsertJoinRequest  = "[store] insert join request test"
+	TestStoreSelectJoinRequests = "[store] select join requests test"
+	TestStoreUpdateJoinRequest  = "[store] update join request test"
+)
+
+func TestJoinRequestStore(t *testing.T) {
+	t.Run(TestStoreInsertJoinRequest, func(t *testing.T) {
+		nowTimestamp := time.Now().Unix()
+		newJoinRequest := &model.JoinRequest{}
+		newJoinRequest.JoinRequestID = "join-request-store-join-request-id-0001"
+		newJoinRequest.RoomID = "join-request-store-room-id-0001"
+		newJoinRequest.UserID = "join-request-store-user-id-0001"
+		newJoinRequest.Status = model.JoinRequestStatusPending
+		newJoinRequest.Created = nowTimestamp
+		newJoinRequest.Modified = nowTimestamp
+		err := Provider(ctx).InsertJoinRequest(newJoinRequest)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertJoinRequest, err.Error())
+		}
+	})
+
+	t.Run(TestStoreSelectJoinRequests, func(t *testing.T) {
+		joinRequests, err := Provider(ctx).SelectJoinRequests(
+			SelectJoinRequestsOptionFilterByRoomID("join-request-store-room-id-0001"),
+			SelectJoinRequestsOptionFilterByStatus(model.JoinRequestStatusPending),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectJoinRequests, err.Error())
+		}
+		if len(joinRequests) != 1 {
+			t.Fatalf("Failed to %s. Expected joinRequests count to be 1, but it was %d", TestStoreSelectJoinRequests, len(joinRequests))
+		}
+
+		_, err = Provider(ctx).SelectJoinRequests()
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil, but it was nil", TestStoreSelectJoinRequests)
+		}
+	})
+
+	t.Run(TestStoreUpdateJoinRequest, func(t *testing.T) {
+		joinRequest, err := Provider(ctx).SelectJoinRequest("join-request-store-join-request-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateJoinRequest, err.Error())
+		}
+
+		joinRequest.Reply(model.JoinRequestStatusApproved)
+		err = Provider(ctx).UpdateJoinRequest(joinRequest)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateJoinRequest, err.Error())
+		}
+
+		joinRequests, err := Provider(ctx).SelectJoinRequests(
+			SelectJoinRequestsOptionFilterByRoomID("join-request-store-room-id-0001"),
+			SelectJoinRequestsOptionFilterByStatus(model.JoinRequestStatusPending),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateJoinRequest, err.Error())
+		}
+		if len(joinRequests) != 0 {
+			t.Fatalf("Failed to %s. Expected joinRequests count to be 0, but it was %d", TestStoreUpdateJoinRequest, len(joinRequests))
+		}
+	})
+}
@@ -7,10 +7,13 @@ import (
 
 type selectMessagesOptions struct {
 	roomID          string
+	userID          string
 	roleIDs         []int32
 	limitTimestamp  int64
 	offsetTimestamp int64
 	orders          []*scpb.OrderInfo
+	excludeHidden   bool
+	blockerUserID   string
 }
 
 type SelectMessagesOption func(*selectMessagesOptions)
@@ -21,6 +24,12 @@ func SelectMessagesOptionFilterByRoomID(roomID string) SelectMessagesOption {
 	}
 }
 
+func SelectMessagesOptionFilterByUserID(userID string) SelectMessagesOption {
+	return func(ops *selectMessagesOptions) {
+		ops.userID = userID
+	}
+}
+
 func SelectMessagesOptionFilterByRoleIDs(roleIDs []int32) SelectMessagesOption {
 	return func(ops *selectMessagesOptions) {
 		ops.roleIDs = roleIDs
@@ -45,6 +54,21 @@ func SelectMessagesOptionOffsetTimestamp(offsetTimestamp int64) SelectMessagesOp
 	}
 }
 
+// SelectMessagesOptionExcludeHidden excludes the messages of the users whose messages are hidden
+// by a suspension or a deactivation in effect
+func SelectMessagesOptionExcludeHidden(excludeHidden bool) SelectMessagesOption {
+	return func(ops *selectMessagesOptions) {
+		ops.excludeHidden = excludeHidden
+	}
+}
+
+// SelectMessagesOptionExcludeBlockedBy excludes the messages of the users who are blocked by the user
+func SelectMessagesOptionExcludeBlockedBy(blockerUserID string) SelectMessagesOption {
+	return func(ops *selectMessagesOptions) {
+		ops.blockerUserID = blockerUserID
+	}
+}
+
 type messageStore interface {
 	createMessageStore()
 
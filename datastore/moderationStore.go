@@ -0,0 +1,65 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type selectRoomSanctionsOptions struct {
+	roomID         string
+	userIDs        []string
+	sanctionType   model.RoomSanctionType
+	excludeExpired bool
+}
+
+type SelectRoomSanctionsOption func(*selectRoomSanctionsOptions)
+
+func SelectRoomSanctionsOptionFilterByRoomID(roomID string) SelectRoomSanctionsOption {
+	return func(ops *selectRoomSanctionsOptions) {
+		ops.roomID = roomID
+	}
+}
+
+func SelectRoomSanctionsOptionFilterByUserIDs(userIDs []string) SelectRoomSanctionsOption {
+	return func(ops *selectRoomSanctionsOptions) {
+		ops.userIDs = userIDs
+	}
+}
+
+func SelectRoomSanctionsOptionFilterByType(sanctionType model.RoomSanctionType) SelectRoomSanctionsOption {
+	return func(ops *selectRoomSanctionsOptions) {
+		ops.sanctionType = sanctionType
+	}
+}
+
+func SelectRoomSanctionsOptionExcludeExpired(excludeExpired bool) SelectRoomSanctionsOption {
+	return func(ops *selectRoomSanctionsOptions) {
+		ops.excludeExpired = excludeExpired
+	}
+}
+
+type selectModerationLogsOptions struct {
+	roomID       string
+	targetUserID string
+}
+
+type SelectModerationLogsOption func(*selectModerationLogsOptions)
+
+func SelectModerationLogsOptionFilterByRoomID(roomID string) SelectModerationLogsOption {
+	return func(ops *selectModerationLogsOptions) {
+		ops.roomID = roomID
+	}
+}
+
+func SelectModerationLogsOptionFilterByTargetUserID(targetUserID string) SelectModerationLogsOption {
+	return func(ops *selectModerationLogsOptions) {
+		ops.targetUserID = targetUserID
+	}
+}
+
+type moderationStore interface {
+	createModerationStore()
+
+	InsertRoomSanction(sanction *model.RoomSanction) error
+	SelectRoomSanctions(opts ...SelectRoomSanctionsOption) ([]*model.RoomSanction, error)
+	DeleteRoomSanction(roomID, userID string, sanctionType model.RoomSanctionType) error
+	InsertModerationLog(moderationLog *model.ModerationLog) error
+	SelectModerationLogs(limit, offset int32, opts ...SelectModerationLogsOption) ([]*model.ModerationLog, error)
+}
@@ -0,0 +1,118 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestStoreInsertRoomSanction   = "[store] insert room sanction test"
+	TestStoreSelectRoomSanctions  = "[store] select room sanctions test"
+	TestStoreDeleteRoomSanction   = "[store] delete room sanction test"
+	TestStoreInsertModerationLog  = "[store] insert moderation log test"
+	TestStoreSelectModerationLogs = "[store] select moderation logs test"
+)
+
+func TestModerationStore(t *testing.T) {
+	t.Run(TestStoreInsertRoomSanction, func(t *testing.T) {
+		nowTimestamp := time.Now().Unix()
+		newSanction := &model.RoomSanction{}
+		newSanction.RoomID = "moderation-store-room-id-0001"
+		newSanction.UserID = "moderation-store-user-id-0001"
+		newSanction.Type = model.RoomSanctionTypeBan
+		newSanction.Created = nowTimestamp
+		err := Provider(ctx).InsertRoomSanction(newSanction)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertRoomSanction, err.Error())
+		}
+
+		expiredSanction := &model.RoomSanction{}
+		expiredSanction.RoomID = "moderation-store-room-id-0001"
+		expiredSanction.UserID = "moderation-store-user-id-0002"
+		expiredSanction.Type = model.RoomSanctionTypeMute
+		expiredSanction.Expired = nowTimestamp - 60
+		expiredSanction.Created = nowTimestamp - 120
+		err = Provider(ctx).InsertRoomSanction(expiredSanction)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertRoomSanction, err.Error())
+		}
+	})
+
+	t.Run(TestStoreSelectRoomSanctions, func(t *testing.T) {
+		sanctions, err := Provider(ctx).SelectRoomSanctions(
+			SelectRoomSanctionsOptionFilterByRoomID("moderation-store-room-id-0001"),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectRoomSanctions, err.Error())
+		}
+		if len(sanctions) != 2 {
+			t.Fatalf("Failed to %s. Expected sanctions count to be 2, but it was %d", TestStoreSelectRoomSanctions, len(sanctions))
+		}
+
+		sanctions, err = Provider(ctx).SelectRoomSanctions(
+			SelectRoomSanctionsOptionFilterByRoomID("moderation-store-room-id-0001"),
+			SelectRoomSanctionsOptionFilterByUserIDs([]string{"moderation-store-user-id-0001", "moderation-store-user-id-0002"}),
+			SelectRoomSanctionsOptionExcludeExpired(true),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectRoomSanctions, err.Error())
+		}
+		if len(sanctions) != 1 {
+			t.Fatalf("Failed to %s. Expected sanctions count to be 1, but it was %d", TestStoreSelectRoomSanctions, len(sanctions))
+		}
+
+		_, err = Provider(ctx).SelectRoomSanctions()
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil, but it was nil", TestStoreSelectRoomSanctions)
+		}
+	})
+
+	t.Run(TestStoreDeleteRoomSanction, func(t *testing.T) {
+		err := Provider(ctx).DeleteRoomSanction("moderation-store-room-id-0001", "moderation-store-user-id-0001", model.RoomSanctionTypeBan)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreDeleteRoomSanction, err.Error())
+		}
+
+		sanctions, err := Provider(ctx).SelectRoomSanctions(
+			SelectRoomSanctionsOptionFilterByRoomID("moderation-store-room-id-0001"),
+			SelectRoomSanctionsOptionFilterByType(model.RoomSanctionTypeBan),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreDeleteRoomSanction, err.Error())
+		}
+		if len(sanctions) != 0 {
+			t.Fatalf("Failed to %s. Expected sanctions count to be 0, but it was %d", TestStoreDeleteRoomSanction, len(sanctions))
+		}
+	})
+
+	t.Run(TestStoreInsertModerationLog, func(t *testing.T) {
+		newModerationLog := &model.ModerationLog{}
+		newModerationLog.LogID = "moderation-store-log-id-0001"
+		newModerationLog.RoomID = "moderation-store-room-id-0001"
+		newModerationLog.Action = model.ModerationActionKick
+		newModerationLog.ModeratorUserID = "moderation-store-user-id-0003"
+		newModerationLog.TargetUserID = "moderation-store-user-id-0001"
+		newModerationLog.Created = time.Now().Unix()
+		err := Provider(ctx).InsertModerationLog(newModerationLog)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertModerationLog, err.Error())
+		}
+	})
+
+	t.Run(TestStoreSelectModerationLogs, func(t *testing.T) {
+		moderationLogs, err := Provider(ctx).SelectModerationLogs(
+			10,
+			0,
+			SelectModerationLogsOptionFilterByRoomID("moderation-store-room-id-0001"),
+			SelectModerationLogsOptionFilterByTargetUserID("moderation-store-user-id-0001"),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectModerationLogs, err.Error())
+		}
+		if len(moderationLogs) != 1 {
+			t.Fatalf("Failed to %s. Expected moderationLogs count to be 1, but it was %d", TestStoreSelectModerationLogs, len(moderationLogs))
+		}
+	})
+}
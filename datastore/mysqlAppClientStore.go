@@ -16,3 +16,13 @@ func (p *mysqlProvider) SelectLatestAppClient(opts ...SelectAppClientOption) (*m
 	replica := RdbStore(p.database).replica()
 	return rdbSelectLatestAppClient(p.ctx, replica, opts...)
 }
+
+func (p *mysqlProvider) SelectAppClients() ([]*model.AppClient, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectAppClients(p.ctx, replica)
+}
+
+func (p *mysqlProvider) UpdateAppClient(appClient *model.AppClient) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateAppClient(p.ctx, master, appClient)
+}
@@ -12,6 +12,11 @@ func (p *mysqlProvider) InsertAsset(asset *model.Asset) error {
 	return rdbInsertAsset(p.ctx, master, asset)
 }
 
+func (p *mysqlProvider) SelectAssets(opts ...SelectAssetsOption) ([]*model.Asset, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectAssets(p.ctx, replica, opts...)
+}
+
 func (p *mysqlProvider) SelectAsset(assetID string) (*model.Asset, error) {
 	replica := RdbStore(p.database).replica()
 	return rdbSelectAsset(p.ctx, replica, assetID)
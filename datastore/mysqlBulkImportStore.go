@@ -0,0 +1,58 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *mysqlProvider) createBulkImportStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateBulkImportStore(p.ctx, master)
+}
+
+func (p *mysqlProvider) InsertBulkImport(bulkImport *model.BulkImport) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertBulkImport(p.ctx, master, bulkImport)
+}
+
+func (p *mysqlProvider) SelectBulkImport(importID string) (*model.BulkImport, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectBulkImport(p.ctx, replica, importID)
+}
+
+func (p *mysqlProvider) UpdateBulkImport(bulkImport *model.BulkImport) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateBulkImport(p.ctx, master, bulkImport)
+}
+
+func (p *mysqlProvider) InsertBulkImportBatch(bulkImport *model.BulkImport, batch *model.BulkImportBatch) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting bulk import batch")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbInsertBulkImportBatch(p.ctx, master, tx, bulkImport, batch)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while inserting bulk import batch")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (p *mysqlProvider) SelectBulkImportRowResults(importID string, limit, offset int32, opts ...SelectBulkImportRowResultsOption) ([]*model.BulkImportRowResult, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectBulkImportRowResults(p.ctx, replica, importID, limit, offset, opts...)
+}
@@ -0,0 +1,18 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *mysqlProvider) createDirectRoomStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateDirectRoomStore(p.ctx, master)
+}
+
+func (p *mysqlProvider) InsertDirectRoom(directRoom *model.DirectRoom) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertDirectRoom(p.ctx, master, directRoom)
+}
+
+func (p *mysqlProvider) SelectDirectRoom(userID, otherUserID string) (*model.DirectRoom, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectDirectRoom(p.ctx, replica, userID, otherUserID)
+}
@@ -0,0 +1,58 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *mysqlProvider) createModerationStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateModerationStore(p.ctx, master)
+}
+
+func (p *mysqlProvider) InsertRoomSanction(sanction *model.RoomSanction) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting room sanction")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbInsertRoomSanction(p.ctx, master, tx, sanction)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while inserting room sanction")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (p *mysqlProvider) SelectRoomSanctions(opts ...SelectRoomSanctionsOption) ([]*model.RoomSanction, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomSanctions(p.ctx, replica, opts...)
+}
+
+func (p *mysqlProvider) DeleteRoomSanction(roomID, userID string, sanctionType model.RoomSanctionType) error {
+	master := RdbStore(p.database).master()
+	return rdbDeleteRoomSanction(p.ctx, master, roomID, userID, sanctionType)
+}
+
+func (p *mysqlProvider) InsertModerationLog(moderationLog *model.ModerationLog) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertModerationLog(p.ctx, master, moderationLog)
+}
+
+func (p *mysqlProvider) SelectModerationLogs(limit, offset int32, opts ...SelectModerationLogsOption) ([]*model.ModerationLog, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectModerationLogs(p.ctx, replica, limit, offset, opts...)
+}
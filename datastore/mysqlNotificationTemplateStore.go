@@ -0,0 +1,33 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *mysqlProvider) createNotificationTemplateStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateNotificationTemplateStore(p.ctx, master)
+}
+
+func (p *mysqlProvider) InsertNotificationTemplate(notificationTemplate *model.NotificationTemplate) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertNotificationTemplate(p.ctx, master, notificationTemplate)
+}
+
+func (p *mysqlProvider) SelectNotificationTemplates() ([]*model.NotificationTemplate, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectNotificationTemplates(p.ctx, replica)
+}
+
+func (p *mysqlProvider) SelectNotificationTemplate(messageType, lang string) (*model.NotificationTemplate, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectNotificationTemplate(p.ctx, replica, messageType, lang)
+}
+
+func (p *mysqlProvider) UpdateNotificationTemplate(notificationTemplate *model.NotificationTemplate) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateNotificationTemplate(p.ctx, master, notificationTemplate)
+}
+
+func (p *mysqlProvider) DeleteNotificationTemplate(messageType, lang string) error {
+	master := RdbStore(p.database).master()
+	return rdbDeleteNotificationTemplate(p.ctx, master, messageType, lang)
+}
@@ -52,6 +52,16 @@ func (p *mysqlProvider) SelectCountRooms(opts ...SelectRoomsOption) (int64, erro
 	return rdbSelectCountRooms(p.ctx, replica, opts...)
 }
 
+func (p *mysqlProvider) SelectPublicRooms(limit, offset int32, opts ...SelectPublicRoomsOption) ([]*model.PublicRoom, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectPublicRooms(p.ctx, replica, limit, offset, opts...)
+}
+
+func (p *mysqlProvider) SelectCountPublicRooms(opts ...SelectPublicRoomsOption) (int64, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectCountPublicRooms(p.ctx, replica, opts...)
+}
+
 func (p *mysqlProvider) UpdateRoom(room *model.Room, opts ...UpdateRoomOption) error {
 	master := RdbStore(p.database).master()
 	tx, err := master.Begin()
@@ -77,3 +87,29 @@ func (p *mysqlProvider) UpdateRoom(room *model.Room, opts ...UpdateRoomOption) e
 
 	return nil
 }
+
+func (p *mysqlProvider) UpdateRoomOwner(room *model.Room, previousOwnerUserID string) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating room owner")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbUpdateRoomOwner(p.ctx, master, tx, room, previousOwnerUserID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while updating room owner")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -57,6 +57,11 @@ func (p *mysqlProvider) SelectUserIDsOfRoomUser(opts ...SelectUserIDsOfRoomUserO
 	return rdbSelectUserIDsOfRoomUser(p.ctx, replica, opts...)
 }
 
+func (p *mysqlProvider) SelectRoomMateUserIDs(userID string, userIDs []string) ([]string, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomMateUserIDs(p.ctx, replica, userID, userIDs)
+}
+
 func (p *mysqlProvider) SelectMiniRoom(roomID, userID string) (*model.MiniRoom, error) {
 	replica := RdbStore(p.database).replica()
 	return rdbSelectMiniRoom(p.ctx, replica, roomID, userID)
@@ -72,6 +77,16 @@ func (p *mysqlProvider) SelectCountMiniRooms(userID string, opts ...SelectMiniRo
 	return rdbSelectCountMiniRooms(p.ctx, replica, userID, opts...)
 }
 
+func (p *mysqlProvider) SelectRoomUnreadCounts(userID string) ([]*model.RoomUnreadCount, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomUnreadCounts(p.ctx, replica, userID)
+}
+
+func (p *mysqlProvider) SelectUnreadCountsOfRoomMembers(roomID string) ([]*model.UserUnreadCount, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUnreadCountsOfRoomMembers(p.ctx, replica, roomID)
+}
+
 func (p *mysqlProvider) UpdateRoomUser(roomUser *model.RoomUser) error {
 	master := RdbStore(p.database).master()
 	tx, err := master.Begin()
@@ -0,0 +1,23 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *mysqlProvider) createUserExportStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateUserExportStore(p.ctx, master)
+}
+
+func (p *mysqlProvider) InsertUserExport(userExport *model.UserExport) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertUserExport(p.ctx, master, userExport)
+}
+
+func (p *mysqlProvider) SelectUserExport(exportID string) (*model.UserExport, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUserExport(p.ctx, replica, exportID)
+}
+
+func (p *mysqlProvider) UpdateUserExport(userExport *model.UserExport) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateUserExport(p.ctx, master, userExport)
+}
@@ -47,9 +47,9 @@ func (p *mysqlProvider) SelectUser(userID string, opts ...SelectUserOption) (*mo
 	return rdbSelectUser(p.ctx, replica, userID, opts...)
 }
 
-func (p *mysqlProvider) SelectCountUsers() (int64, error) {
+func (p *mysqlProvider) SelectCountUsers(opts ...SelectUsersOption) (int64, error) {
 	replica := RdbStore(p.database).replica()
-	return rdbSelectCountUsers(p.ctx, replica)
+	return rdbSelectCountUsers(p.ctx, replica, opts...)
 }
 
 func (p *mysqlProvider) SelectUserIDsOfUser(userIDs []string) ([]string, error) {
@@ -57,6 +57,11 @@ func (p *mysqlProvider) SelectUserIDsOfUser(userIDs []string) ([]string, error)
 	return rdbSelectUserIDsOfUser(p.ctx, replica, userIDs)
 }
 
+func (p *mysqlProvider) SelectUsersByUserIDs(userIDs []string) ([]*model.User, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUsersByUserIDs(p.ctx, replica, userIDs)
+}
+
 func (p *mysqlProvider) UpdateUser(user *model.User, opts ...UpdateUserOption) error {
 	master := RdbStore(p.database).master()
 	tx, err := master.Begin()
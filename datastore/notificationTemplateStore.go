@@ -0,0 +1,13 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type notificationTemplateStore interface {
+	createNotificationTemplateStore()
+
+	InsertNotificationTemplate(notificationTemplate *model.NotificationTemplate) error
+	SelectNotificationTemplates() ([]*model.NotificationTemplate, error)
+	SelectNotificationTemplate(messageType, lang string) (*model.NotificationTemplate, error)
+	UpdateNotificationTemplate(notificationTemplate *model.NotificationTemplate) error
+	DeleteNotificationTemplate(messageType, lang string) error
+}
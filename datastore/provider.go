@@ -14,16 +14,31 @@ type provider interface {
 	DropDatabase() error
 	Close()
 	appClientStore
+	appClientSecretStore
 	assetStore
 	blockUserStore
+	bulkImportStore
+	contactStore
 	deviceStore
+	directRoomStore
+	friendRequestStore
+	invitationStore
+	inviteLinkStore
+	joinRequestStore
 	messageStore
+	moderationStore
+	notificationTemplateStore
+	rateLimitStore
+	roomFolderStore
 	roomStore
 	roomUserStore
+	scimTokenStore
 	settingStore
 	subscriptionStore
+	userExportStore
 	userStore
 	userRoleStore
+	userStatusStore
 	webhookStore
 }
 
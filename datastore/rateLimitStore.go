@@ -0,0 +1,11 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type rateLimitStore interface {
+	createRateLimitStore()
+
+	InsertRateLimit(rateLimit *model.RateLimit) error
+	SelectRateLimit(limitKey string) (*model.RateLimit, error)
+	UpdateRateLimit(rateLimit *model.RateLimit, previousTat int64) (bool, error)
+}
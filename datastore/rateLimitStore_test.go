@@ -0,0 +1,65 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestStoreInsertRateLimit = "[store] insert rate limit test"
+	TestStoreUpdateRateLimit = "[store] update rate limit test"
+)
+
+func TestRateLimitStore(t *testing.T) {
+	t.Run(TestStoreInsertRateLimit, func(t *testing.T) {
+		newRateLimit := &model.RateLimit{}
+		newRateLimit.LimitKey = "slow:rate-limit-store-room-id-0001:rate-limit-store-user-id-0001"
+		newRateLimit.Tat = 100
+		newRateLimit.Modified = time.Now().Unix()
+		err := Provider(ctx).InsertRateLimit(newRateLimit)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertRateLimit, err.Error())
+		}
+
+		newRateLimit.ID = 0
+		err = Provider(ctx).InsertRateLimit(newRateLimit)
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil, but it was nil", TestStoreInsertRateLimit)
+		}
+	})
+
+	t.Run(TestStoreUpdateRateLimit, func(t *testing.T) {
+		rateLimit, err := Provider(ctx).SelectRateLimit("slow:rate-limit-store-room-id-0001:rate-limit-store-user-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateRateLimit, err.Error())
+		}
+
+		rateLimit.Tat = 200
+		updated, err := Provider(ctx).UpdateRateLimit(rateLimit, 100)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateRateLimit, err.Error())
+		}
+		if !updated {
+			t.Fatalf("Failed to %s. Expected updated to be true, but it was false", TestStoreUpdateRateLimit)
+		}
+
+		rateLimit.Tat = 300
+		updated, err = Provider(ctx).UpdateRateLimit(rateLimit, 100)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateRateLimit, err.Error())
+		}
+		if updated {
+			t.Fatalf("Failed to %s. Expected updated to be false when tat was changed by another request, but it was true", TestStoreUpdateRateLimit)
+		}
+
+		rateLimit, err = Provider(ctx).SelectRateLimit("slow:rate-limit-store-room-id-0001:rate-limit-store-user-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateRateLimit, err.Error())
+		}
+		if rateLimit.Tat != 200 {
+			t.Fatalf("Failed to %s. Expected rateLimit.Tat to be 200, but it was %d", TestStoreUpdateRateLimit, rateLimit.Tat)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gopkg.in/gorp.v2"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func rdbCreateAppClientSecretStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateAppClientSecretStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.AppClientSecret{}, tableNameAppClientSecret)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "secret_hash" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating app client secret table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+}
+
+func rdbInsertAppClientSecret(ctx context.Context, dbMap *gorp.DbMap, appClientSecret *model.AppClientSecret) error {
+	span := tracer.StartSpan(ctx, "rdbInsertAppClientSecret", "datastore")
+	defer tracer.Finish(span)
+
+	if err := dbMap.Insert(appClientSecret); err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting app client secret")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// rdbSelectAppClientSecrets selects the secrets of the app client that have not expired
+func rdbSelectAppClientSecrets(ctx context.Context, dbMap *gorp.DbMap, clientID string) ([]*model.AppClientSecret, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectAppClientSecrets", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE client_id=:clientId AND (expired=0 OR expired>:now) ORDER BY created DESC;", tableNameAppClientSecret)
+	params := map[string]interface{}{
+		"clientId": clientID,
+		"now":      time.Now().Unix(),
+	}
+
+	var appClientSecrets []*model.AppClientSecret
+	_, err := dbMap.Select(&appClientSecrets, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting app client secrets")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return appClientSecrets, nil
+}
+
+func rdbUpdateAppClientSecret(ctx context.Context, dbMap *gorp.DbMap, appClientSecret *model.AppClientSecret) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateAppClientSecret", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(appClientSecret)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating app client secret")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -126,3 +126,37 @@ func rdbSelectLatestAppClient(ctx context.Context, dbMap *gorp.DbMap, opts ...Se
 
 	return nil, nil
 }
+
+func rdbSelectAppClients(ctx context.Context, dbMap *gorp.DbMap) ([]*model.AppClient, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectAppClients", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE expired=0 OR expired>:now ORDER BY created;", tableNameAppClient)
+	params := map[string]interface{}{"now": time.Now().Unix()}
+
+	var appClients []*model.AppClient
+	_, err := dbMap.Select(&appClients, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting appClients")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return appClients, nil
+}
+
+func rdbUpdateAppClient(ctx context.Context, dbMap *gorp.DbMap, appClient *model.AppClient) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateAppClient", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(appClient)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating appClient")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -30,6 +30,17 @@ func rdbCreateAssetStore(ctx context.Context, dbMap *gorp.DbMap) {
 		tracer.SetError(span, err)
 		return
 	}
+
+	// Columns added since the table was first released
+	err = addColumnsIfNotExist(dbMap, tableNameAsset, []string{
+		"user_id VARCHAR(255)",
+	})
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating asset table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
 }
 
 func rdbInsertAsset(ctx context.Context, dbMap *gorp.DbMap, asset *model.Asset) error {
@@ -46,6 +57,36 @@ func rdbInsertAsset(ctx context.Context, dbMap *gorp.DbMap, asset *model.Asset)
 	return nil
 }
 
+func rdbSelectAssets(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectAssetsOption) ([]*model.Asset, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectAssets", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectAssetsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.userID == "" {
+		err := errors.New("An error occurred while getting assets. Be sure to specify userId")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	var assets []*model.Asset
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id=:userId AND deleted = 0 ORDER BY created ASC;", tableNameAsset)
+	params := map[string]interface{}{"userId": opt.userID}
+	_, err := dbMap.Select(&assets, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting assets")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return assets, nil
+}
+
 func rdbSelectAsset(ctx context.Context, dbMap *gorp.DbMap, assetID string) (*model.Asset, error) {
 	span := tracer.StartSpan(ctx, "rdbSelectAsset", "datastore")
 	defer tracer.Finish(span)
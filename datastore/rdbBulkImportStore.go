@@ -0,0 +1,220 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateBulkImportStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateBulkImportStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.BulkImport{}, tableNameBulkImport)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "import_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	tableMap = dbMap.AddTableWithName(model.BulkImportRowResult{}, tableNameBulkImportRow)
+	tableMap.SetKeys(true, "id")
+	tableMap.SetUniqueTogether("import_id", "row_index")
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating bulk import table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	var addIndexQuery string
+	if config.Config().Datastore.Provider == "sqlite" {
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS bulk_import_row_result_import_id_status ON %s(import_id, status)", tableNameBulkImportRow)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating bulk import row result table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+	} else {
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX bulk_import_row_result_import_id_status (import_id, status)", tableNameBulkImportRow)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating bulk import row result table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+	}
+}
+
+func rdbInsertBulkImport(ctx context.Context, dbMap *gorp.DbMap, bulkImport *model.BulkImport) error {
+	span := tracer.StartSpan(ctx, "rdbInsertBulkImport", "datastore")
+	defer tracer.Finish(span)
+
+	if err := dbMap.Insert(bulkImport); err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting bulk import")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectBulkImport(ctx context.Context, dbMap *gorp.DbMap, importID string) (*model.BulkImport, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectBulkImport", "datastore")
+	defer tracer.Finish(span)
+
+	var bulkImports []*model.BulkImport
+	query := fmt.Sprintf("SELECT * FROM %s WHERE import_id=:importId;", tableNameBulkImport)
+	params := map[string]interface{}{"importId": importID}
+	_, err := dbMap.Select(&bulkImports, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting bulk import")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(bulkImports) == 1 {
+		return bulkImports[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateBulkImport(ctx context.Context, dbMap *gorp.DbMap, bulkImport *model.BulkImport) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateBulkImport", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(bulkImport)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating bulk import")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// rdbInsertBulkImportBatch inserts the rows of a batch with their results, and saves the progress of the import.
+// Either all of them are committed or none of them, so that the import resumes from the first row of a failed batch
+func rdbInsertBulkImportBatch(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, bulkImport *model.BulkImport, batch *model.BulkImportBatch) error {
+	span := tracer.StartSpan(ctx, "rdbInsertBulkImportBatch", "datastore")
+	defer tracer.Finish(span)
+
+	for _, user := range batch.Users {
+		err := tx.Insert(user)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while inserting bulk import batch")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return err
+		}
+	}
+
+	if len(batch.BlockUsers) > 0 {
+		err := rdbInsertBlockUsers(ctx, dbMap, tx, batch.BlockUsers)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(batch.UserRoles) > 0 {
+		err := rdbInsertUserRoles(ctx, dbMap, tx, batch.UserRoles)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, room := range batch.Rooms {
+		err := rdbInsertRoom(ctx, dbMap, tx, room)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, directRoom := range batch.DirectRooms {
+		err := tx.Insert(directRoom)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while inserting bulk import batch")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return err
+		}
+	}
+
+	if len(batch.RoomUsers) > 0 {
+		err := rdbInsertRoomUsers(ctx, dbMap, tx, batch.RoomUsers)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, result := range batch.Results {
+		err := tx.Insert(result)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while inserting bulk import batch")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return err
+		}
+	}
+
+	_, err := tx.Update(bulkImport)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting bulk import batch")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectBulkImportRowResults(ctx context.Context, dbMap *gorp.DbMap, importID string, limit, offset int32, opts ...SelectBulkImportRowResultsOption) ([]*model.BulkImportRowResult, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectBulkImportRowResults", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectBulkImportRowResultsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	var results []*model.BulkImportRowResult
+	query := fmt.Sprintf("SELECT * FROM %s WHERE import_id=:importId", tableNameBulkImportRow)
+	params := map[string]interface{}{"importId": importID}
+
+	if opt.status != 0 {
+		query = fmt.Sprintf("%s AND status=:status", query)
+		params["status"] = opt.status
+	}
+
+	query = fmt.Sprintf("%s ORDER BY row_index ASC LIMIT :limit OFFSET :offset;", query)
+	params["limit"] = limit
+	params["offset"] = offset
+
+	_, err := dbMap.Select(&results, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting bulk import row results")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return results, nil
+}
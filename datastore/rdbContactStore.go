@@ -0,0 +1,116 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateContactStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateContactStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.Contact{}, tableNameContact)
+	tableMap.SetUniqueTogether("user_id", "contact_user_id")
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating contact table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+}
+
+func rdbInsertContacts(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, contacts []*model.Contact) error {
+	span := tracer.StartSpan(ctx, "rdbInsertContacts", "datastore")
+	defer tracer.Finish(span)
+
+	for _, contact := range contacts {
+		existContact, err := rdbSelectContact(ctx, dbMap, contact.UserID, contact.ContactUserID)
+		if err != nil {
+			return err
+		}
+		if existContact != nil {
+			continue
+		}
+
+		err = tx.Insert(contact)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while inserting contacts")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func rdbSelectContact(ctx context.Context, dbMap *gorp.DbMap, userID, contactUserID string) (*model.Contact, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectContact", "datastore")
+	defer tracer.Finish(span)
+
+	var contacts []*model.Contact
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id=:userId AND contact_user_id=:contactUserId;", tableNameContact)
+	params := map[string]interface{}{
+		"userId":        userID,
+		"contactUserId": contactUserID,
+	}
+	_, err := dbMap.Select(&contacts, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting contact")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(contacts) == 1 {
+		return contacts[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbSelectContactUserIDs(ctx context.Context, dbMap *gorp.DbMap, userID string, contactUserIDs []string) ([]string, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectContactUserIDs", "datastore")
+	defer tracer.Finish(span)
+
+	var userIDs []string
+	contactUserIDsQuery, params := makePrepareExpressionParamsForInOperand(contactUserIDs)
+	if contactUserIDsQuery == "" {
+		return userIDs, nil
+	}
+
+	query := fmt.Sprintf("SELECT contact_user_id FROM %s WHERE user_id=:userId AND contact_user_id IN (%s);", tableNameContact, contactUserIDsQuery)
+	params["userId"] = userID
+	_, err := dbMap.Select(&userIDs, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting contact userIds")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+func rdbDeleteContacts(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, userID, contactUserID string) error {
+	span := tracer.StartSpan(ctx, "rdbDeleteContacts", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE (user_id=? AND contact_user_id=?) OR (user_id=? AND contact_user_id=?)", tableNameContact)
+	_, err := tx.Exec(query, userID, contactUserID, contactUserID, userID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting contacts")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
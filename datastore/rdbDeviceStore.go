@@ -70,8 +70,8 @@ func rdbSelectDevices(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectDevi
 		o(&opt)
 	}
 
-	if opt.userID == "" && opt.platform == scpb.Platform_PlatformNone && opt.token == "" {
-		err := errors.New("An error occurred while getting devices. Be sure to specify either userId or platform or token")
+	if opt.userID == "" && opt.userIDs == nil && opt.platform == scpb.Platform_PlatformNone && opt.token == "" {
+		err := errors.New("An error occurred while getting devices. Be sure to specify either userId or userIds or platform or token")
 		logger.Error(err.Error())
 		tracer.SetError(span, err)
 		return nil, err
@@ -92,6 +92,14 @@ func rdbSelectDevices(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectDevi
 		params["userId"] = opt.userID
 	}
 
+	if opt.userIDs != nil {
+		userIDsQuery, userIDsParams := makePrepareExpressionParamsForInOperand(opt.userIDs)
+		query = fmt.Sprintf("%s user_id IN (%s) AND", query, userIDsQuery)
+		for k, v := range userIDsParams {
+			params[k] = v
+		}
+	}
+
 	if opt.platform != scpb.Platform_PlatformNone {
 		query = fmt.Sprintf("%s platform=:platform AND", query)
 		params["platform"] = opt.platform
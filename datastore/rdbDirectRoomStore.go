@@ -0,0 +1,91 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateDirectRoomStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateDirectRoomStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.DirectRoom{}, tableNameDirectRoom)
+	tableMap.SetKeys(true, "id")
+	tableMap.SetUniqueTogether("first_user_id", "second_user_id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "room_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating direct room table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+}
+
+func rdbInsertDirectRoom(ctx context.Context, dbMap *gorp.DbMap, directRoom *model.DirectRoom) error {
+	span := tracer.StartSpan(ctx, "rdbInsertDirectRoom", "datastore")
+	defer tracer.Finish(span)
+
+	err := dbMap.Insert(directRoom)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting direct room")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectDirectRoom(ctx context.Context, dbMap *gorp.DbMap, userID, otherUserID string) (*model.DirectRoom, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectDirectRoom", "datastore")
+	defer tracer.Finish(span)
+
+	firstUserID, secondUserID := model.SortDirectRoomUserIDs(userID, otherUserID)
+
+	var directRooms []*model.DirectRoom
+	query := fmt.Sprintf("SELECT * FROM %s WHERE first_user_id=:firstUserId AND second_user_id=:secondUserId;", tableNameDirectRoom)
+	params := map[string]interface{}{
+		"firstUserId":  firstUserID,
+		"secondUserId": secondUserID,
+	}
+	_, err := dbMap.Select(&directRooms, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting direct room")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(directRooms) == 1 {
+		return directRooms[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbDeleteDirectRoom(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, roomID string) error {
+	span := tracer.StartSpan(ctx, "rdbDeleteDirectRoom", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE room_id=?;", tableNameDirectRoom)
+	_, err := tx.Exec(query, roomID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting direct room")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateFriendRequestStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateFriendRequestStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.FriendRequest{}, tableNameFriendRequest)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "friend_request_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating friend request table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	indexes := map[string]string{
+		"friend_request_requester_user_id_status": "requester_user_id, status",
+		"friend_request_addressee_user_id_status": "addressee_user_id, status",
+	}
+	for indexName, columns := range indexes {
+		var addIndexQuery string
+		if config.Config().Datastore.Provider == "sqlite" {
+			addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s(%s)", indexName, tableNameFriendRequest, columns)
+			_, err = dbMap.Exec(addIndexQuery)
+			if err != nil {
+				err = errors.Wrap(err, "An error occurred while creating friend request table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		} else {
+			addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX %s (%s)", tableNameFriendRequest, indexName, columns)
+			_, err = dbMap.Exec(addIndexQuery)
+			if err != nil {
+				errMessage := err.Error()
+				if strings.Index(errMessage, "Duplicate key name") < 0 {
+					err = errors.Wrap(err, "An error occurred while creating friend request table")
+					logger.Error(err.Error())
+					tracer.SetError(span, err)
+					return
+				}
+			}
+		}
+	}
+}
+
+func rdbInsertFriendRequest(ctx context.Context, dbMap *gorp.DbMap, friendRequest *model.FriendRequest) error {
+	span := tracer.StartSpan(ctx, "rdbInsertFriendRequest", "datastore")
+	defer tracer.Finish(span)
+
+	err := dbMap.Insert(friendRequest)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting friend request")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectFriendRequests(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectFriendRequestsOption) ([]*model.FriendRequest, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectFriendRequests", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectFriendRequestsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.requesterUserID == "" && opt.addresseeUserID == "" {
+		err := errors.New("An error occurred while getting friend requests. Be sure to specify either requesterUserId or addresseeUserId")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	var friendRequests []*model.FriendRequest
+	query := fmt.Sprintf("SELECT * FROM %s WHERE deleted=0", tableNameFriendRequest)
+	params := make(map[string]interface{})
+
+	if opt.requesterUserID != "" {
+		query = fmt.Sprintf("%s AND requester_user_id=:requesterUserId", query)
+		params["requesterUserId"] = opt.requesterUserID
+	}
+
+	if opt.addresseeUserID != "" {
+		query = fmt.Sprintf("%s AND addressee_user_id=:addresseeUserId", query)
+		params["addresseeUserId"] = opt.addresseeUserID
+	}
+
+	if opt.status != 0 {
+		query = fmt.Sprintf("%s AND status=:status", query)
+		params["status"] = opt.status
+	}
+
+	query = fmt.Sprintf("%s ORDER BY created DESC;", query)
+
+	_, err := dbMap.Select(&friendRequests, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting friend requests")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return friendRequests, nil
+}
+
+func rdbSelectFriendRequest(ctx context.Context, dbMap *gorp.DbMap, friendRequestID string) (*model.FriendRequest, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectFriendRequest", "datastore")
+	defer tracer.Finish(span)
+
+	var friendRequests []*model.FriendRequest
+	query := fmt.Sprintf("SELECT * FROM %s WHERE friend_request_id=:friendRequestId AND deleted=0;", tableNameFriendRequest)
+	params := map[string]interface{}{"friendRequestId": friendRequestID}
+	_, err := dbMap.Select(&friendRequests, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting friend request")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(friendRequests) == 1 {
+		return friendRequests[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateFriendRequest(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, friendRequest *model.FriendRequest, opts ...UpdateFriendRequestOption) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateFriendRequest", "datastore")
+	defer tracer.Finish(span)
+
+	opt := updateFriendRequestOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	_, err := tx.Update(friendRequest)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating friend request")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	if opt.contacts != nil {
+		err = rdbInsertContacts(ctx, dbMap, tx, opt.contacts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateInvitationStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateInvitationStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.Invitation{}, tableNameInvitation)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "invitation_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating invitation table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	var addIndexQuery string
+	if config.Config().Datastore.Provider == "sqlite" {
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS invitee_user_id_status ON %s(invitee_user_id, status)", tableNameInvitation)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating invitation table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+	} else {
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX invitee_user_id_status (invitee_user_id, status)", tableNameInvitation)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating invitation table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+	}
+}
+
+func rdbInsertInvitations(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, invitations []*model.Invitation) error {
+	span := tracer.StartSpan(ctx, "rdbInsertInvitations", "datastore")
+	defer tracer.Finish(span)
+
+	for _, invitation := range invitations {
+		err := tx.Insert(invitation)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while inserting invitations")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func rdbSelectInvitations(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectInvitationsOption) ([]*model.Invitation, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectInvitations", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectInvitationsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.roomID == "" && opt.inviteeUserID == "" {
+		err := errors.New("An error occurred while getting invitations. Be sure to specify either roomId or inviteeUserId")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	var invitations []*model.Invitation
+	query := fmt.Sprintf("SELECT * FROM %s WHERE deleted=0", tableNameInvitation)
+	params := make(map[string]interface{})
+
+	if opt.roomID != "" {
+		query = fmt.Sprintf("%s AND room_id=:roomId", query)
+		params["roomId"] = opt.roomID
+	}
+
+	if opt.inviteeUserID != "" {
+		query = fmt.Sprintf("%s AND invitee_user_id=:inviteeUserId", query)
+		params["inviteeUserId"] = opt.inviteeUserID
+	}
+
+	if opt.status != 0 {
+		query = fmt.Sprintf("%s AND status=:status", query)
+		params["status"] = opt.status
+	}
+
+	if opt.excludeExpired {
+		query = fmt.Sprintf("%s AND expired>:now", query)
+		params["now"] = time.Now().Unix()
+	}
+
+	query = fmt.Sprintf("%s ORDER BY created DESC;", query)
+
+	_, err := dbMap.Select(&invitations, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting invitations")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func rdbSelectInvitation(ctx context.Context, dbMap *gorp.DbMap, invitationID string) (*model.Invitation, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectInvitation", "datastore")
+	defer tracer.Finish(span)
+
+	var invitations []*model.Invitation
+	query := fmt.Sprintf("SELECT * FROM %s WHERE invitation_id=:invitationId AND deleted=0;", tableNameInvitation)
+	params := map[string]interface{}{"invitationId": invitationID}
+	_, err := dbMap.Select(&invitations, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting invitation")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(invitations) == 1 {
+		return invitations[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateInvitation(ctx context.Context, dbMap *gorp.DbMap, invitation *model.Invitation) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateInvitation", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(invitation)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating invitation")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
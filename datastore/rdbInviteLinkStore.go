@@ -0,0 +1,226 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateInviteLinkStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateInviteLinkStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.InviteLink{}, tableNameInviteLink)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "token" {
+			columnMap.SetUnique(true)
+		}
+	}
+	tableMap = dbMap.AddTableWithName(model.InviteLinkUse{}, tableNameInviteLinkUse)
+	tableMap.SetKeys(true, "id")
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating invite link table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	var addIndexQuery string
+	if config.Config().Datastore.Provider == "sqlite" {
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS invite_link_room_id ON %s(room_id)", tableNameInviteLink)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating invite link table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS invite_link_use_token ON %s(token)", tableNameInviteLinkUse)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating invite link use table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+	} else {
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX invite_link_room_id (room_id)", tableNameInviteLink)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating invite link table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX invite_link_use_token (token)", tableNameInviteLinkUse)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating invite link use table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+	}
+}
+
+func rdbInsertInviteLink(ctx context.Context, dbMap *gorp.DbMap, inviteLink *model.InviteLink) error {
+	span := tracer.StartSpan(ctx, "rdbInsertInviteLink", "datastore")
+	defer tracer.Finish(span)
+
+	err := dbMap.Insert(inviteLink)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting invite link")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectInviteLinks(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectInviteLinksOption) ([]*model.InviteLink, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectInviteLinks", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectInviteLinksOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.roomID == "" {
+		err := errors.New("An error occurred while getting invite links. Be sure to specify roomId")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	var inviteLinks []*model.InviteLink
+	query := fmt.Sprintf("SELECT * FROM %s WHERE room_id=:roomId AND deleted=0", tableNameInviteLink)
+	params := map[string]interface{}{"roomId": opt.roomID}
+
+	if opt.excludeRevoked {
+		query = fmt.Sprintf("%s AND revoked=0", query)
+	}
+
+	query = fmt.Sprintf("%s ORDER BY created DESC;", query)
+
+	_, err := dbMap.Select(&inviteLinks, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting invite links")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return inviteLinks, nil
+}
+
+func rdbSelectInviteLink(ctx context.Context, dbMap *gorp.DbMap, token string) (*model.InviteLink, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectInviteLink", "datastore")
+	defer tracer.Finish(span)
+
+	var inviteLinks []*model.InviteLink
+	query := fmt.Sprintf("SELECT * FROM %s WHERE token=:token AND deleted=0;", tableNameInviteLink)
+	params := map[string]interface{}{"token": token}
+	_, err := dbMap.Select(&inviteLinks, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting invite link")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(inviteLinks) == 1 {
+		return inviteLinks[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateInviteLink(ctx context.Context, dbMap *gorp.DbMap, inviteLink *model.InviteLink) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateInviteLink", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(inviteLink)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating invite link")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbInsertInviteLinkUse(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, inviteLinkUse *model.InviteLinkUse) error {
+	span := tracer.StartSpan(ctx, "rdbInsertInviteLinkUse", "datastore")
+	defer tracer.Finish(span)
+
+	// Count the use only while the link still has uses left, so concurrent redemptions can not exceed max_uses
+	query := fmt.Sprintf("UPDATE %s SET uses=uses+1, modified=? WHERE token=? AND deleted=0 AND revoked=0 AND (max_uses=0 OR uses<max_uses);", tableNameInviteLink)
+	result, err := tx.Exec(query, time.Now().Unix(), inviteLinkUse.Token)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting invite link use")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting invite link use")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+	if count == 0 {
+		err = errors.Wrap(ErrInviteLinkExhausted, "An error occurred while inserting invite link use")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	err = tx.Insert(inviteLinkUse)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting invite link use")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectInviteLinkUses(ctx context.Context, dbMap *gorp.DbMap, token string) ([]*model.InviteLinkUse, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectInviteLinkUses", "datastore")
+	defer tracer.Finish(span)
+
+	var inviteLinkUses []*model.InviteLinkUse
+	query := fmt.Sprintf("SELECT * FROM %s WHERE token=:token ORDER BY created ASC;", tableNameInviteLinkUse)
+	params := map[string]interface{}{"token": token}
+	_, err := dbMap.Select(&inviteLinkUses, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting invite link uses")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return inviteLinkUses, nil
+}
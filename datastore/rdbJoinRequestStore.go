@@ -0,0 +1,158 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateJoinRequestStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateJoinRequestStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.JoinRequest{}, tableNameJoinRequest)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "join_request_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating join request table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	var addIndexQuery string
+	if config.Config().Datastore.Provider == "sqlite" {
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS join_request_room_id_status ON %s(room_id, status)", tableNameJoinRequest)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating join request table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+	} else {
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX join_request_room_id_status (room_id, status)", tableNameJoinRequest)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating join request table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+	}
+}
+
+func rdbInsertJoinRequest(ctx context.Context, dbMap *gorp.DbMap, joinRequest *model.JoinRequest) error {
+	span := tracer.StartSpan(ctx, "rdbInsertJoinRequest", "datastore")
+	defer tracer.Finish(span)
+
+	err := dbMap.Insert(joinRequest)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting join request")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectJoinRequests(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectJoinRequestsOption) ([]*model.JoinRequest, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectJoinRequests", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectJoinRequestsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.roomID == "" && opt.userID == "" {
+		err := errors.New("An error occurred while getting join requests. Be sure to specify either roomId or userId")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	var joinRequests []*model.JoinRequest
+	query := fmt.Sprintf("SELECT * FROM %s WHERE deleted=0", tableNameJoinRequest)
+	params := make(map[string]interface{})
+
+	if opt.roomID != "" {
+		query = fmt.Sprintf("%s AND room_id=:roomId", query)
+		params["roomId"] = opt.roomID
+	}
+
+	if opt.userID != "" {
+		query = fmt.Sprintf("%s AND user_id=:userId", query)
+		params["userId"] = opt.userID
+	}
+
+	if opt.status != 0 {
+		query = fmt.Sprintf("%s AND status=:status", query)
+		params["status"] = opt.status
+	}
+
+	query = fmt.Sprintf("%s ORDER BY created;", query)
+
+	_, err := dbMap.Select(&joinRequests, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting join requests")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return joinRequests, nil
+}
+
+func rdbSelectJoinRequest(ctx context.Context, dbMap *gorp.DbMap, joinRequestID string) (*model.JoinRequest, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectJoinRequest", "datastore")
+	defer tracer.Finish(span)
+
+	var joinRequests []*model.JoinRequest
+	query := fmt.Sprintf("SELECT * FROM %s WHERE join_request_id=:joinRequestId AND deleted=0;", tableNameJoinRequest)
+	params := map[string]interface{}{"joinRequestId": joinRequestID}
+	_, err := dbMap.Select(&joinRequests, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting join request")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(joinRequests) == 1 {
+		return joinRequests[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateJoinRequest(ctx context.Context, dbMap *gorp.DbMap, joinRequest *model.JoinRequest) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateJoinRequest", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(joinRequest)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating join request")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
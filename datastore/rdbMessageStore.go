@@ -66,6 +66,11 @@ func rdbInsertMessage(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transacti
 		return err
 	}
 
+	// System messages record changes of the room. They are neither the last message nor counted as unread
+	if message.IsSystemMessage() {
+		return nil
+	}
+
 	var rooms []*model.Room
 	query := fmt.Sprintf("SELECT * FROM %s WHERE room_id=:roomId AND deleted=0;", tableNameRoom)
 	params := map[string]interface{}{"roomId": message.RoomID}
@@ -139,6 +144,18 @@ func rdbInsertMessage(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transacti
 			tracer.SetError(span, err)
 			return err
 		}
+
+		if !message.Mentions(user.UserID) {
+			continue
+		}
+		query = fmt.Sprintf("UPDATE %s SET mention_count=mention_count+1 WHERE room_id=? AND user_id=?;", tableNameRoomUser)
+		_, err = tx.Exec(query, message.RoomID, user.UserID)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while inserting message")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return err
+		}
 	}
 
 	return nil
@@ -162,12 +179,26 @@ func rdbSelectMessages(ctx context.Context, dbMap *gorp.DbMap, limit, offset int
 		query = fmt.Sprintf("%s AND room_id = :roomId", query)
 	}
 
+	if opt.userID != "" {
+		params["userId"] = opt.userID
+		query = fmt.Sprintf("%s AND user_id = :userId", query)
+	}
+
 	if opt.roleIDs != nil {
 		roleIDsQuery, roleIDsParam := makePrepareExpressionParamsForInOperand(opt.roleIDs)
 		params = utils.MergeMap(params, roleIDsParam)
 		query = fmt.Sprintf("%s AND role IN (%s)", query, roleIDsQuery)
 	}
 
+	if opt.excludeHidden {
+		query = fmt.Sprintf("%s AND %s", query, makeHiddenMessagesCondition(params))
+	}
+
+	if opt.blockerUserID != "" {
+		params["blockerUserId"] = opt.blockerUserID
+		query = fmt.Sprintf("%s AND user_id NOT IN (SELECT block_user_id FROM %s WHERE user_id=:blockerUserId)", query, tableNameBlockUser)
+	}
+
 	if opt.limitTimestamp != 0 {
 		params["limitTimestamp"] = opt.limitTimestamp
 		query = fmt.Sprintf("%s AND created >= :limitTimestamp", query)
@@ -246,12 +277,26 @@ func rdbSelectCountMessages(ctx context.Context, dbMap *gorp.DbMap, opts ...Sele
 		query = fmt.Sprintf("%s AND room_id = :roomId", query)
 	}
 
+	if opt.userID != "" {
+		params["userId"] = opt.userID
+		query = fmt.Sprintf("%s AND user_id = :userId", query)
+	}
+
 	if opt.roleIDs != nil {
 		roleIDsQuery, roleIDsParam := makePrepareExpressionParamsForInOperand(opt.roleIDs)
 		params = utils.MergeMap(params, roleIDsParam)
 		query = fmt.Sprintf("%s AND role IN (%s)", query, roleIDsQuery)
 	}
 
+	if opt.excludeHidden {
+		query = fmt.Sprintf("%s AND %s", query, makeHiddenMessagesCondition(params))
+	}
+
+	if opt.blockerUserID != "" {
+		params["blockerUserId"] = opt.blockerUserID
+		query = fmt.Sprintf("%s AND user_id NOT IN (SELECT block_user_id FROM %s WHERE user_id=:blockerUserId)", query, tableNameBlockUser)
+	}
+
 	count, err := dbMap.SelectInt(query, params)
 	if err != nil {
 		err = errors.Wrap(err, "An error occurred while getting message count")
@@ -277,3 +322,17 @@ func rdbUpdateMessage(ctx context.Context, dbMap *gorp.DbMap, message *model.Mes
 
 	return nil
 }
+
+// makeHiddenMessagesCondition makes the condition excluding the messages of the users
+// whose messages are hidden by a suspension or a deactivation in effect
+func makeHiddenMessagesCondition(params map[string]interface{}) string {
+	params["hiddenStatusSuspended"] = model.UserStatusSuspended
+	params["hiddenStatusDeactivated"] = model.UserStatusDeactivated
+	params["hiddenNow"] = time.Now().Unix()
+	return fmt.Sprintf(`user_id NOT IN (
+		SELECT user_id FROM %s
+		WHERE
+			messages_hidden=1 AND
+			(status=:hiddenStatusDeactivated OR (status=:hiddenStatusSuspended AND (status_expired=0 OR status_expired>:hiddenNow)))
+	)`, tableNameUser)
+}
@@ -0,0 +1,226 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateModerationStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateModerationStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.RoomSanction{}, tableNameRoomSanction)
+	tableMap.SetKeys(true, "id")
+	tableMap = dbMap.AddTableWithName(model.ModerationLog{}, tableNameModerationLog)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "log_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating moderation table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	var addIndexQuery string
+	if config.Config().Datastore.Provider == "sqlite" {
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS room_sanction_room_id_user_id ON %s(room_id, user_id)", tableNameRoomSanction)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating room sanction table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS moderation_log_room_id_created ON %s(room_id, created)", tableNameModerationLog)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating moderation log table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+	} else {
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX room_sanction_room_id_user_id (room_id, user_id)", tableNameRoomSanction)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating room sanction table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX moderation_log_room_id_created (room_id, created)", tableNameModerationLog)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating moderation log table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+	}
+}
+
+func rdbInsertRoomSanction(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, sanction *model.RoomSanction) error {
+	span := tracer.StartSpan(ctx, "rdbInsertRoomSanction", "datastore")
+	defer tracer.Finish(span)
+
+	// A new sanction replaces the one of the same type that is currently in effect
+	query := fmt.Sprintf("UPDATE %s SET deleted=? WHERE room_id=? AND user_id=? AND type=? AND deleted=0;", tableNameRoomSanction)
+	_, err := tx.Exec(query, time.Now().Unix(), sanction.RoomID, sanction.UserID, sanction.Type)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting room sanction")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	err = tx.Insert(sanction)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting room sanction")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectRoomSanctions(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectRoomSanctionsOption) ([]*model.RoomSanction, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectRoomSanctions", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectRoomSanctionsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.roomID == "" {
+		err := errors.New("An error occurred while getting room sanctions. Be sure to specify roomId")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	var sanctions []*model.RoomSanction
+	query := fmt.Sprintf("SELECT * FROM %s WHERE room_id=:roomId AND deleted=0", tableNameRoomSanction)
+	params := map[string]interface{}{"roomId": opt.roomID}
+
+	if opt.userIDs != nil {
+		userIDsQuery, userIDsParams := makePrepareExpressionParamsForInOperand(opt.userIDs)
+		params = utils.MergeMap(params, userIDsParams)
+		query = fmt.Sprintf("%s AND user_id IN (%s)", query, userIDsQuery)
+	}
+
+	if opt.sanctionType != 0 {
+		query = fmt.Sprintf("%s AND type=:type", query)
+		params["type"] = opt.sanctionType
+	}
+
+	if opt.excludeExpired {
+		query = fmt.Sprintf("%s AND (expired=0 OR expired>:now)", query)
+		params["now"] = time.Now().Unix()
+	}
+
+	query = fmt.Sprintf("%s ORDER BY created DESC;", query)
+
+	_, err := dbMap.Select(&sanctions, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting room sanctions")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return sanctions, nil
+}
+
+func rdbDeleteRoomSanction(ctx context.Context, dbMap *gorp.DbMap, roomID, userID string, sanctionType model.RoomSanctionType) error {
+	span := tracer.StartSpan(ctx, "rdbDeleteRoomSanction", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("UPDATE %s SET deleted=? WHERE room_id=? AND user_id=? AND type=? AND deleted=0;", tableNameRoomSanction)
+	_, err := dbMap.Exec(query, time.Now().Unix(), roomID, userID, sanctionType)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting room sanction")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbInsertModerationLog(ctx context.Context, dbMap *gorp.DbMap, moderationLog *model.ModerationLog) error {
+	span := tracer.StartSpan(ctx, "rdbInsertModerationLog", "datastore")
+	defer tracer.Finish(span)
+
+	err := dbMap.Insert(moderationLog)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting moderation log")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectModerationLogs(ctx context.Context, dbMap *gorp.DbMap, limit, offset int32, opts ...SelectModerationLogsOption) ([]*model.ModerationLog, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectModerationLogs", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectModerationLogsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.roomID == "" {
+		err := errors.New("An error occurred while getting moderation logs. Be sure to specify roomId")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	var moderationLogs []*model.ModerationLog
+	query := fmt.Sprintf("SELECT * FROM %s WHERE room_id=:roomId", tableNameModerationLog)
+	params := map[string]interface{}{"roomId": opt.roomID}
+
+	if opt.targetUserID != "" {
+		query = fmt.Sprintf("%s AND target_user_id=:targetUserId", query)
+		params["targetUserId"] = opt.targetUserID
+	}
+
+	query = fmt.Sprintf("%s ORDER BY created DESC, id DESC LIMIT :limit OFFSET :offset;", query)
+	params["limit"] = limit
+	params["offset"] = offset
+
+	_, err := dbMap.Select(&moderationLogs, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting moderation logs")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return moderationLogs, nil
+}
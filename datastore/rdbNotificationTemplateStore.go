@@ -0,0 +1,120 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/gorp.v2"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func rdbCreateNotificationTemplateStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateNotificationTemplateStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.NotificationTemplate{}, tableNameNotificationTemplate)
+	tableMap.SetKeys(true, "id")
+	tableMap.SetUniqueTogether("message_type", "lang")
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating notification template table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+}
+
+func rdbInsertNotificationTemplate(ctx context.Context, dbMap *gorp.DbMap, notificationTemplate *model.NotificationTemplate) error {
+	span := tracer.StartSpan(ctx, "rdbInsertNotificationTemplate", "datastore")
+	defer tracer.Finish(span)
+
+	if err := dbMap.Insert(notificationTemplate); err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting notification template")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectNotificationTemplates(ctx context.Context, dbMap *gorp.DbMap) ([]*model.NotificationTemplate, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectNotificationTemplates", "datastore")
+	defer tracer.Finish(span)
+
+	var notificationTemplates []*model.NotificationTemplate
+	query := fmt.Sprintf("SELECT * FROM %s ORDER BY message_type, lang;", tableNameNotificationTemplate)
+	_, err := dbMap.Select(&notificationTemplates, query)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting notification templates")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return notificationTemplates, nil
+}
+
+func rdbSelectNotificationTemplate(ctx context.Context, dbMap *gorp.DbMap, messageType, lang string) (*model.NotificationTemplate, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectNotificationTemplate", "datastore")
+	defer tracer.Finish(span)
+
+	var notificationTemplates []*model.NotificationTemplate
+	query := fmt.Sprintf("SELECT * FROM %s WHERE message_type=:messageType AND lang=:lang;", tableNameNotificationTemplate)
+	params := map[string]interface{}{
+		"messageType": messageType,
+		"lang":        lang,
+	}
+	_, err := dbMap.Select(&notificationTemplates, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting notification template")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(notificationTemplates) == 1 {
+		return notificationTemplates[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateNotificationTemplate(ctx context.Context, dbMap *gorp.DbMap, notificationTemplate *model.NotificationTemplate) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateNotificationTemplate", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(notificationTemplate)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating notification template")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbDeleteNotificationTemplate(ctx context.Context, dbMap *gorp.DbMap, messageType, lang string) error {
+	span := tracer.StartSpan(ctx, "rdbDeleteNotificationTemplate", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE message_type=:messageType AND lang=:lang;", tableNameNotificationTemplate)
+	params := map[string]interface{}{
+		"messageType": messageType,
+		"lang":        lang,
+	}
+	_, err := dbMap.Exec(query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting notification template")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateRateLimitStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateRateLimitStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.RateLimit{}, tableNameRateLimit)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "limit_key" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating rate limit table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+}
+
+func rdbInsertRateLimit(ctx context.Context, dbMap *gorp.DbMap, rateLimit *model.RateLimit) error {
+	span := tracer.StartSpan(ctx, "rdbInsertRateLimit", "datastore")
+	defer tracer.Finish(span)
+
+	err := dbMap.Insert(rateLimit)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting rate limit")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectRateLimit(ctx context.Context, dbMap *gorp.DbMap, limitKey string) (*model.RateLimit, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectRateLimit", "datastore")
+	defer tracer.Finish(span)
+
+	var rateLimits []*model.RateLimit
+	query := fmt.Sprintf("SELECT * FROM %s WHERE limit_key=:limitKey;", tableNameRateLimit)
+	params := map[string]interface{}{"limitKey": limitKey}
+	_, err := dbMap.Select(&rateLimits, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting rate limit")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(rateLimits) == 1 {
+		return rateLimits[0], nil
+	}
+
+	return nil, nil
+}
+
+// rdbUpdateRateLimit updates tat only while nobody else has updated it since it was selected.
+// It returns false when another request won the race
+func rdbUpdateRateLimit(ctx context.Context, dbMap *gorp.DbMap, rateLimit *model.RateLimit, previousTat int64) (bool, error) {
+	span := tracer.StartSpan(ctx, "rdbUpdateRateLimit", "datastore")
+	defer tracer.Finish(span)
+
+	rateLimit.Modified = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET tat=?, modified=? WHERE limit_key=? AND tat=?;", tableNameRateLimit)
+	result, err := dbMap.Exec(query, rateLimit.Tat, rateLimit.Modified, rateLimit.LimitKey, previousTat)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating rate limit")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating rate limit")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return false, err
+	}
+
+	return count == 1, nil
+}
@@ -0,0 +1,155 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateRoomFolderStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateRoomFolderStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.RoomFolder{}, tableNameRoomFolder)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "folder_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating room folder table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	var addIndexQuery string
+	if config.Config().Datastore.Provider == "sqlite" {
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS room_folder_user_id ON %s(user_id)", tableNameRoomFolder)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating room folder table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+	} else {
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX room_folder_user_id (user_id)", tableNameRoomFolder)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating room folder table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+	}
+}
+
+func rdbInsertRoomFolder(ctx context.Context, dbMap *gorp.DbMap, roomFolder *model.RoomFolder) error {
+	span := tracer.StartSpan(ctx, "rdbInsertRoomFolder", "datastore")
+	defer tracer.Finish(span)
+
+	err := dbMap.Insert(roomFolder)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting room folder")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectRoomFolders(ctx context.Context, dbMap *gorp.DbMap, userID string) ([]*model.RoomFolder, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectRoomFolders", "datastore")
+	defer tracer.Finish(span)
+
+	var roomFolders []*model.RoomFolder
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id=:userId AND deleted=0 ORDER BY position ASC, created ASC;", tableNameRoomFolder)
+	params := map[string]interface{}{"userId": userID}
+	_, err := dbMap.Select(&roomFolders, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting room folders")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return roomFolders, nil
+}
+
+func rdbSelectRoomFolder(ctx context.Context, dbMap *gorp.DbMap, folderID string) (*model.RoomFolder, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectRoomFolder", "datastore")
+	defer tracer.Finish(span)
+
+	var roomFolders []*model.RoomFolder
+	query := fmt.Sprintf("SELECT * FROM %s WHERE folder_id=:folderId AND deleted=0;", tableNameRoomFolder)
+	params := map[string]interface{}{"folderId": folderID}
+	_, err := dbMap.Select(&roomFolders, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting room folder")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(roomFolders) == 1 {
+		return roomFolders[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateRoomFolder(ctx context.Context, dbMap *gorp.DbMap, roomFolder *model.RoomFolder) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateRoomFolder", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(roomFolder)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating room folder")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbDeleteRoomFolder(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, roomFolder *model.RoomFolder) error {
+	span := tracer.StartSpan(ctx, "rdbDeleteRoomFolder", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("UPDATE %s SET deleted=? WHERE folder_id=?;", tableNameRoomFolder)
+	_, err := tx.Exec(query, time.Now().Unix(), roomFolder.FolderID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting room folder")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	// Rooms in the folder go back to the top level of the room list
+	query = fmt.Sprintf("UPDATE %s SET folder_id='' WHERE user_id=? AND folder_id=?;", tableNameRoomUser)
+	_, err = tx.Exec(query, roomFolder.UserID, roomFolder.FolderID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting room folder")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
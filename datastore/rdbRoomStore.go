@@ -7,7 +7,9 @@ import (
 	logger "github.com/betchi/zapper"
 	"github.com/pkg/errors"
 	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
 	"github.com/betchi/tracer"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 	"gopkg.in/gorp.v2"
 )
 
@@ -28,6 +30,21 @@ func rdbCreateRoomStore(ctx context.Context, dbMap *gorp.DbMap) {
 		tracer.SetError(span, err)
 		return
 	}
+
+	// Columns added since the table was first released
+	err = addColumnsIfNotExist(dbMap, tableNameRoom, []string{
+		"join_policy INTEGER NOT NULL DEFAULT 0",
+		"archived BIGINT NOT NULL DEFAULT 0",
+		"slow_mode_interval INTEGER NOT NULL DEFAULT 0",
+		"burst_limit INTEGER NOT NULL DEFAULT 0",
+		"burst_window INTEGER NOT NULL DEFAULT 0",
+	})
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating room table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
 }
 
 func rdbInsertRoom(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, room *model.Room, opts ...InsertRoomOption) error {
@@ -66,6 +83,16 @@ func rdbInsertRoom(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction,
 		}
 	}
 
+	if opt.directRoom != nil {
+		err = tx.Insert(opt.directRoom)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while inserting room")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -89,12 +116,27 @@ func rdbSelectRooms(ctx context.Context, dbMap *gorp.DbMap, limit, offset int32,
 	type,
 	last_message,
 	last_message_updated,
+	archived,
 	created,
 	modified
 	FROM %s
 	WHERE deleted = 0`, tableNameRoom)
 	params := make(map[string]interface{})
 
+	if opt.archived != nil {
+		if *opt.archived {
+			query = fmt.Sprintf("%s AND archived!=0", query)
+		} else {
+			query = fmt.Sprintf("%s AND archived=0", query)
+		}
+	}
+
+	if opt.metaDataFilters != nil {
+		metaDataQuery, metaDataParams := makeMetaDataFilterCondition("meta_data", opt.metaDataFilters)
+		query = fmt.Sprintf("%s%s", query, metaDataQuery)
+		params = utils.MergeMap(params, metaDataParams)
+	}
+
 	query = fmt.Sprintf("%s ORDER BY", query)
 	if opt.orders == nil {
 		query = fmt.Sprintf("%s created DESC", query)
@@ -175,6 +217,7 @@ u.name,
 u.picture_url,
 u.information_url,
 u.meta_data,
+u.public_profile_scope,
 u.can_block,
 u.last_accessed,
 u.created,
@@ -200,8 +243,29 @@ func rdbSelectCountRooms(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectR
 	span := tracer.StartSpan(ctx, "rdbSelectCountRooms", "datastore")
 	defer tracer.Finish(span)
 
-	query := fmt.Sprintf("SELECT count(id) FROM %s WHERE deleted = 0;", tableNameRoom)
-	count, err := dbMap.SelectInt(query)
+	opt := selectRoomsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	query := fmt.Sprintf("SELECT count(id) FROM %s WHERE deleted = 0", tableNameRoom)
+	params := make(map[string]interface{})
+
+	if opt.archived != nil {
+		if *opt.archived {
+			query = fmt.Sprintf("%s AND archived!=0", query)
+		} else {
+			query = fmt.Sprintf("%s AND archived=0", query)
+		}
+	}
+
+	if opt.metaDataFilters != nil {
+		metaDataQuery, metaDataParams := makeMetaDataFilterCondition("meta_data", opt.metaDataFilters)
+		query = fmt.Sprintf("%s%s", query, metaDataQuery)
+		params = utils.MergeMap(params, metaDataParams)
+	}
+
+	count, err := dbMap.SelectInt(query, params)
 	if err != nil {
 		err = errors.Wrap(err, "An error occurred while getting room count")
 		logger.Error(err.Error())
@@ -212,6 +276,93 @@ func rdbSelectCountRooms(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectR
 	return count, nil
 }
 
+func rdbSelectPublicRooms(ctx context.Context, dbMap *gorp.DbMap, limit, offset int32, opts ...SelectPublicRoomsOption) ([]*model.PublicRoom, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectPublicRooms", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectPublicRoomsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	var rooms []*model.PublicRoom
+	query := fmt.Sprintf(`SELECT
+r.room_id,
+r.user_id,
+r.name,
+r.picture_url,
+r.information_url,
+r.meta_data,
+r.join_policy,
+r.last_message_updated,
+r.created,
+(SELECT count(ru.user_id) FROM %s AS ru WHERE ru.room_id = r.room_id) AS member_count
+FROM %s AS r`, tableNameRoomUser, tableNameRoom)
+	where, params := makePublicRoomsCondition(opt)
+	query = fmt.Sprintf("%s %s", query, where)
+
+	switch opt.sort {
+	case model.PublicRoomsSortMemberCount:
+		query = fmt.Sprintf("%s ORDER BY member_count DESC", query)
+	default:
+		query = fmt.Sprintf("%s ORDER BY r.last_message_updated DESC", query)
+	}
+	query = fmt.Sprintf("%s, r.id DESC LIMIT :limit OFFSET :offset", query)
+	params["limit"] = limit
+	params["offset"] = offset
+
+	_, err := dbMap.Select(&rooms, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting public rooms")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+func rdbSelectCountPublicRooms(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectPublicRoomsOption) (int64, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectCountPublicRooms", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectPublicRoomsOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	where, params := makePublicRoomsCondition(opt)
+	query := fmt.Sprintf("SELECT count(r.id) FROM %s AS r %s", tableNameRoom, where)
+	count, err := dbMap.SelectInt(query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting public room count")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// makePublicRoomsCondition makes the WHERE clause of the public room directory.
+// Tags are matched against the string values in the room's metaData
+func makePublicRoomsCondition(opt selectPublicRoomsOptions) (string, map[string]interface{}) {
+	query := "WHERE r.type=:type AND r.deleted=0 AND r.archived=0"
+	params := map[string]interface{}{"type": scpb.RoomType_PublicRoom}
+
+	if opt.name != "" {
+		query = fmt.Sprintf("%s AND r.name LIKE :name", query)
+		params["name"] = fmt.Sprintf("%%%s%%", opt.name)
+	}
+
+	for i, tag := range opt.tags {
+		query = fmt.Sprintf("%s AND r.meta_data LIKE :tag%d", query, i)
+		params[fmt.Sprintf("tag%d", i)] = fmt.Sprintf("%%\"%s\"%%", tag)
+	}
+
+	return query, params
+}
+
 func rdbUpdateRoom(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, room *model.Room, opts ...UpdateRoomOption) error {
 	span := tracer.StartSpan(ctx, "rdbUpdateRoom", "datastore")
 	defer tracer.Finish(span)
@@ -233,15 +384,15 @@ func rdbUpdateRoom(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction,
 		return err
 	}
 
+	// Only the users who are not members yet are inserted, so that the role, folder, favorite, mute and joined of the members are kept
 	for _, ru := range opt.users {
-		query := fmt.Sprintf("DELETE FROM %s WHERE room_id=?;", tableNameRoomUser)
-		_, err := tx.Exec(query, room.RoomID)
+		existRoomUser, err := rdbSelectRoomUser(ctx, dbMap, ru.RoomID, ru.UserID)
 		if err != nil {
-			err := errors.Wrap(err, "An error occurred while inserting room")
-			logger.Error(err.Error())
-			tracer.SetError(span, err)
 			return err
 		}
+		if existRoomUser != nil {
+			continue
+		}
 
 		err = tx.Insert(ru)
 		if err != nil {
@@ -255,6 +406,41 @@ func rdbUpdateRoom(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction,
 	return nil
 }
 
+// rdbUpdateRoomOwner changes the owner of the room to room.UserID.
+// The previous owner stays in the room as an admin
+func rdbUpdateRoomOwner(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, room *model.Room, previousOwnerUserID string) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateRoomOwner", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("UPDATE %s SET user_id=?, modified=? WHERE room_id=?;", tableNameRoom)
+	_, err := tx.Exec(query, room.UserID, room.ModifiedTimestamp, room.RoomID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating room owner")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	query = fmt.Sprintf("UPDATE %s SET member_role=? WHERE room_id=? AND user_id=?;", tableNameRoomUser)
+	_, err = tx.Exec(query, model.RoomMemberRoleAdmin, room.RoomID, previousOwnerUserID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating room owner")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	_, err = tx.Exec(query, model.RoomMemberRoleOwner, room.RoomID, room.UserID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating room owner")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
 func rdbUpdateRoomDeleted(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, room *model.Room) error {
 	span := tracer.StartSpan(ctx, "rdbUpdateRoomDeleted", "datastore")
 	defer tracer.Finish(span)
@@ -280,6 +466,11 @@ func rdbUpdateRoomDeleted(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Trans
 		return err
 	}
 
+	err = rdbDeleteDirectRoom(ctx, dbMap, tx, room.RoomID)
+	if err != nil {
+		return err
+	}
+
 	query := fmt.Sprintf("UPDATE %s SET deleted=? WHERE room_id=?;", tableNameRoom)
 	_, err = tx.Exec(query, room.DeletedTimestamp, room.RoomID)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 
 	logger "github.com/betchi/zapper"
 	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
 	"github.com/betchi/tracer"
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
@@ -32,6 +33,24 @@ func rdbCreateRoomUserStore(ctx context.Context, dbMap *gorp.DbMap) {
 		tracer.SetError(span, err)
 		return
 	}
+
+	// Columns added since the table was first released
+	err = addColumnsIfNotExist(dbMap, tableNameRoomUser, []string{
+		"member_role INTEGER NOT NULL DEFAULT 0",
+		"notification_level INTEGER NOT NULL DEFAULT 0",
+		"muted_until BIGINT NOT NULL DEFAULT 0",
+		"favorite BOOLEAN NOT NULL DEFAULT 0",
+		"folder_id VARCHAR(255) NOT NULL DEFAULT ''",
+		"position INTEGER NOT NULL DEFAULT 0",
+		"joined BIGINT NOT NULL DEFAULT 0",
+		"mention_count INTEGER NOT NULL DEFAULT 0",
+	})
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating room user table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
 }
 
 func rdbInsertRoomUsers(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, roomUsers []*model.RoomUser, opts ...InsertRoomUsersOption) error {
@@ -54,6 +73,13 @@ func rdbInsertRoomUsers(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transac
 		}
 	}
 
+	if opt.inviteLinkUse != nil {
+		err := rdbInsertInviteLinkUse(ctx, dbMap, tx, opt.inviteLinkUse)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, ru := range roomUsers {
 		if opt.beforeCleanRoomID != "" {
 			existroomUser, err := rdbSelectRoomUser(ctx, dbMap, ru.RoomID, ru.UserID)
@@ -108,7 +134,7 @@ func rdbSelectRoomUsers(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectRo
 	}
 
 	var roomUsers []*model.RoomUser
-	query := fmt.Sprintf("SELECT ru.room_id, ru.user_id, ru.unread_count, ru.display FROM %s as ru", tableNameRoomUser)
+	query := fmt.Sprintf("SELECT ru.room_id, ru.user_id, ru.unread_count, ru.mention_count, ru.display, ru.member_role, ru.notification_level, ru.muted_until, ru.favorite, ru.folder_id, ru.position, ru.joined FROM %s as ru", tableNameRoomUser)
 
 	if opt.roles != nil {
 		rolesQuery, params := makePrepareExpressionParamsForInOperand(opt.roles)
@@ -303,6 +329,37 @@ func rdbSelectUserIDsOfRoomUser(ctx context.Context, dbMap *gorp.DbMap, opts ...
 	return userIDs, nil
 }
 
+// rdbSelectRoomMateUserIDs selects the users of userIDs who share a room other than a notice room with the user
+func rdbSelectRoomMateUserIDs(ctx context.Context, dbMap *gorp.DbMap, userID string, userIDs []string) ([]string, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectRoomMateUserIDs", "datastore")
+	defer tracer.Finish(span)
+
+	var roomMateUserIDs []string
+	userIDsQuery, params := makePrepareExpressionParamsForInOperand(userIDs)
+	if userIDsQuery == "" {
+		return roomMateUserIDs, nil
+	}
+
+	query := fmt.Sprintf(`SELECT DISTINCT ru.user_id FROM %s AS ru
+WHERE ru.user_id IN (%s)
+AND ru.room_id IN (
+	SELECT mru.room_id FROM %s AS mru
+	LEFT JOIN %s AS r ON mru.room_id=r.room_id
+	WHERE mru.user_id=:userId AND r.type!=:type
+);`, tableNameRoomUser, userIDsQuery, tableNameRoomUser, tableNameRoom)
+	params["userId"] = userID
+	params["type"] = scpb.RoomType_NoticeRoom
+	_, err := dbMap.Select(&roomMateUserIDs, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting room mate userIds")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return roomMateUserIDs, nil
+}
+
 func rdbSelectMiniRoom(ctx context.Context, dbMap *gorp.DbMap, roomID, userID string) (*model.MiniRoom, error) {
 	span := tracer.StartSpan(ctx, "rdbSelectMiniRoom", "datastore")
 	defer tracer.Finish(span)
@@ -319,9 +376,13 @@ r.type,
 r.last_message,
 r.last_message_updated,
 r.can_left,
+r.archived,
 r.created,
 r.modified,
-ru.unread_count AS ru_unread_count
+ru.unread_count AS ru_unread_count,
+ru.favorite AS ru_favorite,
+ru.folder_id AS ru_folder_id,
+ru.position AS ru_position
 FROM %s AS ru
 LEFT JOIN %s AS r ON ru.room_id = r.room_id
 LEFT JOIN %s AS u ON ru.user_id = u.user_id
@@ -353,6 +414,7 @@ u.name,
 u.picture_url,
 u.information_url,
 u.meta_data,
+u.public_profile_scope,
 u.can_block,
 u.last_accessed,
 u.created,
@@ -395,9 +457,13 @@ r.type,
 r.last_message,
 r.last_message_updated,
 r.can_left,
+r.archived,
 r.created,
 r.modified,
-ru.unread_count AS ru_unread_count
+ru.unread_count AS ru_unread_count,
+ru.favorite AS ru_favorite,
+ru.folder_id AS ru_folder_id,
+ru.position AS ru_position
 FROM %s AS ru
 LEFT JOIN %s AS r ON ru.room_id = r.room_id
 LEFT JOIN %s AS u ON ru.user_id = u.user_id
@@ -414,10 +480,42 @@ WHERE ru.room_id IN (
 		query = fmt.Sprintf("%s AND ru.unread_count!=0", query)
 	}
 
+	if opt.archived != nil {
+		if *opt.archived {
+			query = fmt.Sprintf("%s AND r.archived!=0", query)
+		} else {
+			query = fmt.Sprintf("%s AND r.archived=0", query)
+		}
+	}
+
+	if opt.favorite != nil {
+		if *opt.favorite {
+			query = fmt.Sprintf("%s AND ru.favorite!=0", query)
+		} else {
+			query = fmt.Sprintf("%s AND ru.favorite=0", query)
+		}
+	}
+
+	if opt.folderID != "" {
+		query = fmt.Sprintf("%s AND ru.folder_id=:folderId", query)
+		params["folderId"] = opt.folderID
+	}
+
+	if opt.metaDataFilters != nil {
+		metaDataQuery, metaDataParams := makeMetaDataFilterCondition("r.meta_data", opt.metaDataFilters)
+		query = fmt.Sprintf("%s%s", query, metaDataQuery)
+		params = utils.MergeMap(params, metaDataParams)
+	}
+
 	query = fmt.Sprintf("%s ORDER BY", query)
-	if opt.orders == nil {
+	switch {
+	case opt.sort == model.MiniRoomsSortUnreadFirst:
+		query = fmt.Sprintf("%s CASE WHEN ru.unread_count!=0 THEN 0 ELSE 1 END ASC, r.last_message_updated DESC", query)
+	case opt.sort == model.MiniRoomsSortManual:
+		query = fmt.Sprintf("%s ru.position ASC, r.last_message_updated DESC", query)
+	case opt.sort == model.MiniRoomsSortLastMessage || opt.orders == nil:
 		query = fmt.Sprintf("%s r.last_message_updated DESC", query)
-	} else {
+	default:
 		i := 1
 		for _, orderInfo := range opt.orders {
 			query = fmt.Sprintf("%s r.%s %s", query, orderInfo.Field, orderInfo.Order.String())
@@ -458,6 +556,7 @@ u.name,
 u.picture_url,
 u.information_url,
 u.meta_data,
+u.public_profile_scope,
 u.can_block,
 u.last_accessed,
 u.created,
@@ -512,6 +611,33 @@ WHERE ru.room_id IN (
 	case scpb.UserRoomsFilter_Unread:
 		query = fmt.Sprintf("%s AND ru.unread_count!=0", query)
 	}
+
+	if opt.archived != nil {
+		if *opt.archived {
+			query = fmt.Sprintf("%s AND r.archived!=0", query)
+		} else {
+			query = fmt.Sprintf("%s AND r.archived=0", query)
+		}
+	}
+
+	if opt.favorite != nil {
+		if *opt.favorite {
+			query = fmt.Sprintf("%s AND ru.favorite!=0", query)
+		} else {
+			query = fmt.Sprintf("%s AND ru.favorite=0", query)
+		}
+	}
+
+	if opt.folderID != "" {
+		query = fmt.Sprintf("%s AND ru.folder_id=:folderId", query)
+		params["folderId"] = opt.folderID
+	}
+
+	if opt.metaDataFilters != nil {
+		metaDataQuery, metaDataParams := makeMetaDataFilterCondition("r.meta_data", opt.metaDataFilters)
+		query = fmt.Sprintf("%s%s", query, metaDataQuery)
+		params = utils.MergeMap(params, metaDataParams)
+	}
 	count, err := dbMap.SelectInt(query, params)
 	if err != nil {
 		err := errors.Wrap(err, "An error occurred while selecting mini rooms count")
@@ -522,12 +648,68 @@ WHERE ru.room_id IN (
 	return count, nil
 }
 
+func rdbSelectRoomUnreadCounts(ctx context.Context, dbMap *gorp.DbMap, userID string) ([]*model.RoomUnreadCount, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectRoomUnreadCounts", "datastore")
+	defer tracer.Finish(span)
+
+	var roomUnreadCounts []*model.RoomUnreadCount
+	query := fmt.Sprintf(`SELECT
+ru.room_id,
+ru.unread_count,
+ru.mention_count
+FROM %s AS ru
+LEFT JOIN %s AS r ON ru.room_id = r.room_id
+WHERE ru.user_id=:userId AND r.deleted=0 AND (ru.unread_count>0 OR ru.mention_count>0)
+ORDER BY r.last_message_updated DESC`, tableNameRoomUser, tableNameRoom)
+	params := map[string]interface{}{
+		"userId": userID,
+	}
+
+	_, err := dbMap.Select(&roomUnreadCounts, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting room unread counts")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return roomUnreadCounts, nil
+}
+
+// rdbSelectUnreadCountsOfRoomMembers totals the unread counts of each member of the room across their rooms in one query
+func rdbSelectUnreadCountsOfRoomMembers(ctx context.Context, dbMap *gorp.DbMap, roomID string) ([]*model.UserUnreadCount, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectUnreadCountsOfRoomMembers", "datastore")
+	defer tracer.Finish(span)
+
+	var userUnreadCounts []*model.UserUnreadCount
+	query := fmt.Sprintf(`SELECT
+ru.user_id,
+SUM(ru.unread_count) AS unread_count
+FROM %s AS ru
+LEFT JOIN %s AS r ON ru.room_id = r.room_id
+WHERE ru.user_id IN (SELECT user_id FROM %s WHERE room_id=:roomId) AND r.deleted=0
+GROUP BY ru.user_id`, tableNameRoomUser, tableNameRoom, tableNameRoomUser)
+	params := map[string]interface{}{
+		"roomId": roomID,
+	}
+
+	_, err := dbMap.Select(&userUnreadCounts, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting unread counts of room members")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return userUnreadCounts, nil
+}
+
 func rdbUpdateRoomUser(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, ru *model.RoomUser) error {
 	span := tracer.StartSpan(ctx, "rdbUpdateRoomUser", "datastore")
 	defer tracer.Finish(span)
 
-	query := fmt.Sprintf("UPDATE %s SET unread_count=?, display=? WHERE room_id=? AND user_id=?;", tableNameRoomUser)
-	_, err := tx.Exec(query, ru.UnreadCount, ru.Display, ru.RoomID, ru.UserID)
+	query := fmt.Sprintf("UPDATE %s SET unread_count=?, mention_count=?, display=?, member_role=?, notification_level=?, muted_until=?, favorite=?, folder_id=?, position=? WHERE room_id=? AND user_id=?;", tableNameRoomUser)
+	_, err := tx.Exec(query, ru.UnreadCount, ru.MentionCount, ru.Display, ru.MemberRole, ru.NotificationLevel, ru.MutedUntil, ru.Favorite, ru.FolderID, ru.Position, ru.RoomID, ru.UserID)
 	if err != nil {
 		err := errors.Wrap(err, "An error occurred while updating room user")
 		logger.Error(err.Error())
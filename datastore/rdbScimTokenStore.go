@@ -0,0 +1,107 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/gorp.v2"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func rdbCreateScimTokenStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateScimTokenStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.ScimToken{}, tableNameScimToken)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "token_id" || columnMap.ColumnName == "token_hash" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating scim token table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+}
+
+func rdbInsertScimToken(ctx context.Context, dbMap *gorp.DbMap, scimToken *model.ScimToken) error {
+	span := tracer.StartSpan(ctx, "rdbInsertScimToken", "datastore")
+	defer tracer.Finish(span)
+
+	if err := dbMap.Insert(scimToken); err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting scim token")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectScimToken(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectScimTokenOption) (*model.ScimToken, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectScimToken", "datastore")
+	defer tracer.Finish(span)
+
+	opt := selectScimTokenOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if (opt.tokenID == "" && opt.tokenHash == "") || (opt.tokenID != "" && opt.tokenHash != "") {
+		err := errors.New("An error occurred while getting scim token. Be sure to specify either tokenID or tokenHash")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE deleted=0", tableNameScimToken)
+	params := make(map[string]interface{})
+
+	if opt.tokenID != "" {
+		query = fmt.Sprintf("%s AND token_id=:tokenId", query)
+		params["tokenId"] = opt.tokenID
+	}
+
+	if opt.tokenHash != "" {
+		query = fmt.Sprintf("%s AND token_hash=:tokenHash", query)
+		params["tokenHash"] = opt.tokenHash
+	}
+
+	var scimTokens []*model.ScimToken
+	_, err := dbMap.Select(&scimTokens, fmt.Sprintf("%s;", query), params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting scim token")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(scimTokens) == 1 {
+		return scimTokens[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateScimToken(ctx context.Context, dbMap *gorp.DbMap, scimToken *model.ScimToken) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateScimToken", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(scimToken)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating scim token")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -11,20 +11,38 @@ import (
 )
 
 var (
-	rdbStores             = make(map[string]*rdbStore)
-	tableNameAppClient    = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "app_client")
-	tableNameAsset        = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "asset")
-	tableNameBlockUser    = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "block_user")
-	tableNameBot          = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "bot")
-	tableNameDevice       = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "device")
-	tableNameMessage      = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "message")
-	tableNameRoom         = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "room")
-	tableNameRoomUser     = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "room_user")
-	tableNameSetting      = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "setting")
-	tableNameSubscription = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "subscription")
-	tableNameUser         = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "user")
-	tableNameUserRole     = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "user_role")
-	tableNameWebhook      = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "webhook")
+	rdbStores                     = make(map[string]*rdbStore)
+	tableNameAppClient            = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "app_client")
+	tableNameAppClientSecret      = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "app_client_secret")
+	tableNameAsset                = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "asset")
+	tableNameBlockUser            = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "block_user")
+	tableNameBulkImport           = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "bulk_import")
+	tableNameBulkImportRow        = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "bulk_import_row_result")
+	tableNameBot                  = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "bot")
+	tableNameContact              = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "contact")
+	tableNameDevice               = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "device")
+	tableNameDirectRoom           = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "direct_room")
+	tableNameFriendRequest        = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "friend_request")
+	tableNameInvitation           = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "invitation")
+	tableNameInviteLink           = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "invite_link")
+	tableNameInviteLinkUse        = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "invite_link_use")
+	tableNameJoinRequest          = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "join_request")
+	tableNameMessage              = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "message")
+	tableNameModerationLog        = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "moderation_log")
+	tableNameNotificationTemplate = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "notification_template")
+	tableNameRateLimit            = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "rate_limit")
+	tableNameRoom                 = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "room")
+	tableNameRoomFolder           = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "room_folder")
+	tableNameRoomSanction         = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "room_sanction")
+	tableNameRoomUser             = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "room_user")
+	tableNameScimToken            = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "scim_token")
+	tableNameSetting              = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "setting")
+	tableNameSubscription         = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "subscription")
+	tableNameUser                 = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "user")
+	tableNameUserExport           = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "user_export")
+	tableNameUserRole             = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "user_role")
+	tableNameUserStatusLog        = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "user_status_log")
+	tableNameWebhook              = fmt.Sprintf("%s%s", config.Config().Datastore.TableNamePrefix, "webhook")
 )
 
 type rdbStore struct {
@@ -0,0 +1,84 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/gorp.v2"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func rdbCreateUserExportStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateUserExportStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.UserExport{}, tableNameUserExport)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "export_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating user export table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+}
+
+func rdbInsertUserExport(ctx context.Context, dbMap *gorp.DbMap, userExport *model.UserExport) error {
+	span := tracer.StartSpan(ctx, "rdbInsertUserExport", "datastore")
+	defer tracer.Finish(span)
+
+	if err := dbMap.Insert(userExport); err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting user export")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func rdbSelectUserExport(ctx context.Context, dbMap *gorp.DbMap, exportID string) (*model.UserExport, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectUserExport", "datastore")
+	defer tracer.Finish(span)
+
+	var userExports []*model.UserExport
+	query := fmt.Sprintf("SELECT * FROM %s WHERE export_id=:exportId AND deleted=0;", tableNameUserExport)
+	params := map[string]interface{}{"exportId": exportID}
+	_, err := dbMap.Select(&userExports, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting user export")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	if len(userExports) == 1 {
+		return userExports[0], nil
+	}
+
+	return nil, nil
+}
+
+func rdbUpdateUserExport(ctx context.Context, dbMap *gorp.DbMap, userExport *model.UserExport) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateUserExport", "datastore")
+	defer tracer.Finish(span)
+
+	_, err := dbMap.Update(userExport)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating user export")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	return nil
+}
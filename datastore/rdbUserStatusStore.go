@@ -0,0 +1,119 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	gorp "gopkg.in/gorp.v2"
+)
+
+func rdbCreateUserStatusStore(ctx context.Context, dbMap *gorp.DbMap) {
+	span := tracer.StartSpan(ctx, "rdbCreateUserStatusStore", "datastore")
+	defer tracer.Finish(span)
+
+	tableMap := dbMap.AddTableWithName(model.UserStatusLog{}, tableNameUserStatusLog)
+	tableMap.SetKeys(true, "id")
+	for _, columnMap := range tableMap.Columns {
+		if columnMap.ColumnName == "log_id" {
+			columnMap.SetUnique(true)
+		}
+	}
+	err := dbMap.CreateTablesIfNotExists()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating user status log table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	var addIndexQuery string
+	if config.Config().Datastore.Provider == "sqlite" {
+		addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS user_status_log_user_id_created ON %s(user_id, created)", tableNameUserStatusLog)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			err = errors.Wrap(err, "An error occurred while creating user status log table")
+			logger.Error(err.Error())
+			tracer.SetError(span, err)
+			return
+		}
+	} else {
+		addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX user_status_log_user_id_created (user_id, created)", tableNameUserStatusLog)
+		_, err = dbMap.Exec(addIndexQuery)
+		if err != nil {
+			errMessage := err.Error()
+			if strings.Index(errMessage, "Duplicate key name") < 0 {
+				err = errors.Wrap(err, "An error occurred while creating user status log table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		}
+	}
+}
+
+func rdbUpdateUserStatus(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, user *model.User, userStatusLog *model.UserStatusLog) error {
+	span := tracer.StartSpan(ctx, "rdbUpdateUserStatus", "datastore")
+	defer tracer.Finish(span)
+
+	query := fmt.Sprintf("UPDATE %s SET status=?, status_reason=?, status_expired=?, messages_hidden=?, modified=? WHERE user_id=? AND deleted=0;", tableNameUser)
+	_, err := tx.Exec(query, user.Status, user.StatusReason, user.StatusExpired, user.MessagesHidden, user.ModifiedTimestamp, user.UserID)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating user status")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	err = tx.Insert(userStatusLog)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating user status")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return err
+	}
+
+	// The subscriptions are logically deleted so that the devices can be unsubscribed from the notification topics
+	if user.Status != model.UserStatusActive {
+		err = rdbDeleteSubscriptions(
+			ctx,
+			dbMap,
+			tx,
+			DeleteSubscriptionsOptionWithLogicalDeleted(user.ModifiedTimestamp),
+			DeleteSubscriptionsOptionFilterByUserID(user.UserID),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func rdbSelectUserStatusLogs(ctx context.Context, dbMap *gorp.DbMap, userID string, limit, offset int32) ([]*model.UserStatusLog, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectUserStatusLogs", "datastore")
+	defer tracer.Finish(span)
+
+	var userStatusLogs []*model.UserStatusLog
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id=:userId ORDER BY created DESC, id DESC LIMIT :limit OFFSET :offset;", tableNameUserStatusLog)
+	params := map[string]interface{}{
+		"userId": userID,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	_, err := dbMap.Select(&userStatusLogs, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting user status logs")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return userStatusLogs, nil
+}
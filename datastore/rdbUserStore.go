@@ -3,12 +3,15 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"gopkg.in/gorp.v2"
 
 	logger "github.com/betchi/zapper"
 	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
 	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
 	"github.com/betchi/tracer"
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
@@ -30,6 +33,54 @@ func rdbCreateUserStore(ctx context.Context, dbMap *gorp.DbMap) {
 		tracer.SetError(span, err)
 		return
 	}
+
+	// Columns added since the table was first released
+	err = addColumnsIfNotExist(dbMap, tableNameUser, []string{
+		"dnd_start VARCHAR(255)",
+		"dnd_end VARCHAR(255)",
+		"dnd_timezone VARCHAR(255)",
+		"status INTEGER NOT NULL DEFAULT 0",
+		"status_reason VARCHAR(255)",
+		"status_expired BIGINT NOT NULL DEFAULT 0",
+		"messages_hidden BOOLEAN NOT NULL DEFAULT 0",
+	})
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while creating user table")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return
+	}
+
+	// Indexes of the user directory search
+	indexes := map[string]string{
+		"user_name":                         "name",
+		"user_public_profile_scope_deleted": "public_profile_scope, deleted",
+	}
+	for indexName, columns := range indexes {
+		var addIndexQuery string
+		if config.Config().Datastore.Provider == "sqlite" {
+			addIndexQuery = fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s(%s)", indexName, tableNameUser, columns)
+			_, err = dbMap.Exec(addIndexQuery)
+			if err != nil {
+				err = errors.Wrap(err, "An error occurred while creating user table")
+				logger.Error(err.Error())
+				tracer.SetError(span, err)
+				return
+			}
+		} else {
+			addIndexQuery = fmt.Sprintf("ALTER TABLE %s ADD INDEX %s (%s)", tableNameUser, indexName, columns)
+			_, err = dbMap.Exec(addIndexQuery)
+			if err != nil {
+				errMessage := err.Error()
+				if strings.Index(errMessage, "Duplicate key name") < 0 {
+					err = errors.Wrap(err, "An error occurred while creating user table")
+					logger.Error(err.Error())
+					tracer.SetError(span, err)
+					return
+				}
+			}
+		}
+	}
 }
 
 func rdbInsertUser(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, user *model.User, opts ...InsertUserOption) error {
@@ -76,11 +127,14 @@ func rdbSelectUsers(ctx context.Context, dbMap *gorp.DbMap, limit, offset int32,
 	}
 
 	var users []*model.User
-	query := fmt.Sprintf("SELECT user_id, name, picture_url, information_url, unread_count, meta_data, public_profile_scope, can_block, created, modified FROM %s WHERE deleted = 0 ORDER BY", tableNameUser)
-	params := make(map[string]interface{})
+	where, params := makeUsersCondition(opt)
+	query := fmt.Sprintf("SELECT user_id, name, picture_url, information_url, unread_count, meta_data, public_profile_scope, can_block, status, status_reason, status_expired, created, modified FROM %s %s", tableNameUser, where)
 
-	// query = fmt.Sprintf("%s ORDER BY", query)
-	if opt.orders == nil {
+	query = fmt.Sprintf("%s ORDER BY", query)
+	if opt.orders == nil && opt.name != "" {
+		query = fmt.Sprintf("%s CASE WHEN name LIKE :namePrefix ESCAPE '%s' THEN 0 ELSE 1 END, name ASC", query, likeEscape)
+		params["namePrefix"] = escapeLikePattern(opt.name) + "%"
+	} else if opt.orders == nil {
 		query = fmt.Sprintf("%s unread_count DESC", query)
 	} else {
 		i := 1
@@ -162,12 +216,17 @@ func rdbSelectUser(ctx context.Context, dbMap *gorp.DbMap, userID string, opts .
 	return user, nil
 }
 
-func rdbSelectCountUsers(ctx context.Context, dbMap *gorp.DbMap) (int64, error) {
+func rdbSelectCountUsers(ctx context.Context, dbMap *gorp.DbMap, opts ...SelectUsersOption) (int64, error) {
 	span := tracer.StartSpan(ctx, "rdbSelectCountUsers", "datastore")
 	defer tracer.Finish(span)
 
-	query := fmt.Sprintf("SELECT count(id) FROM %s WHERE deleted = 0", tableNameUser)
-	params := make(map[string]interface{})
+	opt := selectUsersOptions{}
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	where, params := makeUsersCondition(opt)
+	query := fmt.Sprintf("SELECT count(id) FROM %s %s", tableNameUser, where)
 
 	count, err := dbMap.SelectInt(query, params)
 	if err != nil {
@@ -180,6 +239,37 @@ func rdbSelectCountUsers(ctx context.Context, dbMap *gorp.DbMap) (int64, error)
 	return count, nil
 }
 
+// makeUsersCondition makes the WHERE clause of the user list and the user directory search
+func makeUsersCondition(opt selectUsersOptions) (string, map[string]interface{}) {
+	query := "WHERE deleted = 0"
+	params := make(map[string]interface{})
+
+	if opt.name != "" {
+		query = fmt.Sprintf("%s AND name LIKE :name ESCAPE '%s'", query, likeEscape)
+		if opt.nameMatch == model.UserSearchMatchFuzzy {
+			params["name"] = makeFuzzyLikePattern(opt.name)
+		} else {
+			params["name"] = escapeLikePattern(opt.name) + "%"
+		}
+	}
+
+	if opt.visibleTo != "" {
+		query = fmt.Sprintf(`%s AND user_id!=:visibleTo AND public_profile_scope=:publicProfileScope
+AND user_id NOT IN (SELECT block_user_id FROM %s WHERE user_id=:visibleTo)
+AND user_id NOT IN (SELECT user_id FROM %s WHERE block_user_id=:visibleTo)`, query, tableNameBlockUser, tableNameBlockUser)
+		params["visibleTo"] = opt.visibleTo
+		params["publicProfileScope"] = scpb.PublicProfileScope_All
+	}
+
+	if opt.metaDataFilters != nil {
+		metaDataQuery, metaDataParams := makeMetaDataFilterCondition("meta_data", opt.metaDataFilters)
+		query = fmt.Sprintf("%s%s", query, metaDataQuery)
+		params = utils.MergeMap(params, metaDataParams)
+	}
+
+	return query, params
+}
+
 func rdbSelectUserIDsOfUser(ctx context.Context, dbMap *gorp.DbMap, userIDs []string) ([]string, error) {
 	span := tracer.StartSpan(ctx, "rdbSelectUserIDsOfUser", "datastore")
 	defer tracer.Finish(span)
@@ -203,6 +293,28 @@ func rdbSelectUserIDsOfUser(ctx context.Context, dbMap *gorp.DbMap, userIDs []st
 	return resultUserIDs, nil
 }
 
+func rdbSelectUsersByUserIDs(ctx context.Context, dbMap *gorp.DbMap, userIDs []string) ([]*model.User, error) {
+	span := tracer.StartSpan(ctx, "rdbSelectUsersByUserIDs", "datastore")
+	defer tracer.Finish(span)
+
+	var users []*model.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	userIDsQuery, params := makePrepareExpressionParamsForInOperand(userIDs)
+	query := fmt.Sprintf("SELECT * FROM %s WHERE user_id IN (%s) AND deleted=0;", tableNameUser, userIDsQuery)
+	_, err := dbMap.Select(&users, query, params)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while getting users")
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func rdbUpdateUser(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction, user *model.User, opts ...UpdateUserOption) error {
 	span := tracer.StartSpan(ctx, "rdbUpdateUser", "datastore")
 	defer tracer.Finish(span)
@@ -213,7 +325,7 @@ func rdbUpdateUser(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Transaction,
 	}
 
 	if opt.markAllAsRead {
-		query := fmt.Sprintf("UPDATE %s SET unread_count=0 WHERE user_id=?;", tableNameRoomUser)
+		query := fmt.Sprintf("UPDATE %s SET unread_count=0, mention_count=0 WHERE user_id=?;", tableNameRoomUser)
 		_, err := tx.Exec(query, user.UserID)
 		if err != nil {
 			err = errors.Wrap(err, "An error occurred while updating user")
@@ -315,6 +427,44 @@ func rdbUpdateUserDeleted(ctx context.Context, dbMap *gorp.DbMap, tx *gorp.Trans
 	return nil
 }
 
+// makeContactsCondition makes the condition of the contacts in the contact mode.
+// Implicit contacts are the users sharing a room, explicit ones are the users who accepted a friend request
+func makeContactsCondition(mode model.ContactMode) string {
+	implicit := fmt.Sprintf(`	(u.public_profile_scope=:publicProfileScope AND u.user_id!=:userId AND u.deleted=0)
+	OR
+	(
+		u.user_id IN (
+			SELECT ru.user_id FROM %s as ru
+			WHERE
+				ru.user_id!=:userId AND
+				ru.room_id IN (
+					SELECT ru.room_id FROM %s as ru
+					LEFT JOIN %s as r ON ru.room_id = r.room_id
+					WHERE ru.user_id=:userId AND r.type!=:type
+				)
+		) AND
+		u.public_profile_scope=:publicProfileScope AND
+		u.deleted=0
+	)`, tableNameRoomUser, tableNameRoomUser, tableNameRoom)
+
+	explicit := fmt.Sprintf(`	(
+		u.user_id IN (
+			SELECT c.contact_user_id FROM %s as c
+			WHERE c.user_id=:userId
+		) AND
+		u.deleted=0
+	)`, tableNameContact)
+
+	switch mode {
+	case model.ContactModeExplicit:
+		return explicit
+	case model.ContactModeBoth:
+		return fmt.Sprintf("%s\n\tOR\n%s", implicit, explicit)
+	default:
+		return implicit
+	}
+}
+
 func rdbSelectContacts(ctx context.Context, dbMap *gorp.DbMap, userID string, limit, offset int32, opts ...SelectContactsOption) ([]*model.User, error) {
 	span := tracer.StartSpan(ctx, "rdbSelectContacts", "datastore")
 	defer tracer.Finish(span)
@@ -337,23 +487,8 @@ u.created,
 u.modified
 FROM %s as u
 WHERE
-	(u.public_profile_scope=:publicProfileScope AND u.user_id!=:userId AND u.deleted=0)
-	OR
-	(
-		u.user_id IN (
-			SELECT ru.user_id FROM %s as ru
-			WHERE
-				ru.user_id!=:userId AND
-				ru.room_id IN (
-					SELECT ru.room_id FROM %s as ru
-					LEFT JOIN %s as r ON ru.room_id = r.room_id
-					WHERE ru.user_id=:userId AND r.type!=:type
-				)
-		) AND
-		u.public_profile_scope=:publicProfileScope AND
-		u.deleted=0
-	)
-GROUP BY u.user_id`, tableNameUser, tableNameRoomUser, tableNameRoomUser, tableNameRoom)
+%s
+GROUP BY u.user_id`, tableNameUser, makeContactsCondition(opt.mode))
 	params := make(map[string]interface{})
 	params["publicProfileScope"] = scpb.PublicProfileScope_All
 	params["type"] = scpb.RoomType_NoticeRoom
@@ -0,0 +1,13 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type roomFolderStore interface {
+	createRoomFolderStore()
+
+	InsertRoomFolder(roomFolder *model.RoomFolder) error
+	SelectRoomFolders(userID string) ([]*model.RoomFolder, error)
+	SelectRoomFolder(folderID string) (*model.RoomFolder, error)
+	UpdateRoomFolder(roomFolder *model.RoomFolder) error
+	DeleteRoomFolder(roomFolder *model.RoomFolder) error
+}
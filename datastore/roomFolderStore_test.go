@@ -0,0 +1,123 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestStoreInsertRoomFolder  = "[store] insert room folder test"
+	TestStoreSelectRoomFolders = "[store] select room folders test"
+	TestStoreUpdateRoomFolder  = "[store] update room folder test"
+	TestStoreDeleteRoomFolder  = "[store] delete room folder test"
+)
+
+func TestRoomFolderStore(t *testing.T) {
+	t.Run(TestStoreInsertRoomFolder, func(t *testing.T) {
+		nowTimestamp := time.Now().Unix()
+		newRoomFolder := &model.RoomFolder{}
+		newRoomFolder.FolderID = "room-folder-store-folder-id-0001"
+		newRoomFolder.UserID = "room-folder-store-user-id-0001"
+		newRoomFolder.Name = "work"
+		newRoomFolder.Position = 1
+		newRoomFolder.Created = nowTimestamp
+		newRoomFolder.Modified = nowTimestamp
+		err := Provider(ctx).InsertRoomFolder(newRoomFolder)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertRoomFolder, err.Error())
+		}
+
+		newRoomFolder = &model.RoomFolder{}
+		newRoomFolder.FolderID = "room-folder-store-folder-id-0002"
+		newRoomFolder.UserID = "room-folder-store-user-id-0001"
+		newRoomFolder.Name = "family"
+		newRoomFolder.Position = 0
+		newRoomFolder.Created = nowTimestamp
+		newRoomFolder.Modified = nowTimestamp
+		err = Provider(ctx).InsertRoomFolder(newRoomFolder)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertRoomFolder, err.Error())
+		}
+	})
+
+	t.Run(TestStoreSelectRoomFolders, func(t *testing.T) {
+		roomFolders, err := Provider(ctx).SelectRoomFolders("room-folder-store-user-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectRoomFolders, err.Error())
+		}
+		if len(roomFolders) != 2 {
+			t.Fatalf("Failed to %s. Expected roomFolders count to be 2, but it was %d", TestStoreSelectRoomFolders, len(roomFolders))
+		}
+		if roomFolders[0].FolderID != "room-folder-store-folder-id-0002" {
+			t.Fatalf("Failed to %s. Expected roomFolders[0].FolderID to be \"room-folder-store-folder-id-0002\", but it was %s", TestStoreSelectRoomFolders, roomFolders[0].FolderID)
+		}
+
+		roomFolder, err := Provider(ctx).SelectRoomFolder("not-exist-folder-id")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectRoomFolders, err.Error())
+		}
+		if roomFolder != nil {
+			t.Fatalf("Failed to %s. Expected roomFolder to be nil, but it was not nil", TestStoreSelectRoomFolders)
+		}
+	})
+
+	t.Run(TestStoreUpdateRoomFolder, func(t *testing.T) {
+		roomFolder, err := Provider(ctx).SelectRoomFolder("room-folder-store-folder-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateRoomFolder, err.Error())
+		}
+
+		roomFolder.Name = "office"
+		err = Provider(ctx).UpdateRoomFolder(roomFolder)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateRoomFolder, err.Error())
+		}
+
+		roomFolder, err = Provider(ctx).SelectRoomFolder("room-folder-store-folder-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateRoomFolder, err.Error())
+		}
+		if roomFolder.Name != "office" {
+			t.Fatalf("Failed to %s. Expected roomFolder.Name to be \"office\", but it was %s", TestStoreUpdateRoomFolder, roomFolder.Name)
+		}
+	})
+
+	t.Run(TestStoreDeleteRoomFolder, func(t *testing.T) {
+		newRoomUser := &model.RoomUser{}
+		newRoomUser.RoomID = "room-folder-store-room-id-0001"
+		newRoomUser.UserID = "room-folder-store-user-id-0001"
+		newRoomUser.FolderID = "room-folder-store-folder-id-0001"
+		err := Provider(ctx).InsertRoomUsers([]*model.RoomUser{newRoomUser})
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreDeleteRoomFolder, err.Error())
+		}
+
+		roomFolder, err := Provider(ctx).SelectRoomFolder("room-folder-store-folder-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreDeleteRoomFolder, err.Error())
+		}
+
+		err = Provider(ctx).DeleteRoomFolder(roomFolder)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreDeleteRoomFolder, err.Error())
+		}
+
+		roomFolder, err = Provider(ctx).SelectRoomFolder("room-folder-store-folder-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreDeleteRoomFolder, err.Error())
+		}
+		if roomFolder != nil {
+			t.Fatalf("Failed to %s. Expected roomFolder to be nil, but it was not nil", TestStoreDeleteRoomFolder)
+		}
+
+		roomUser, err := Provider(ctx).SelectRoomUser("room-folder-store-room-id-0001", "room-folder-store-user-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreDeleteRoomFolder, err.Error())
+		}
+		if roomUser.FolderID != "" {
+			t.Fatalf("Failed to %s. Expected roomUser.FolderID to be empty, but it was %s", TestStoreDeleteRoomFolder, roomUser.FolderID)
+		}
+	})
+}
@@ -8,7 +8,8 @@ import (
 type InsertRoomOption func(*insertRoomOptions)
 
 type insertRoomOptions struct {
-	users []*model.RoomUser
+	users      []*model.RoomUser
+	directRoom *model.DirectRoom
 }
 
 func InsertRoomOptionWithRoomUser(users []*model.RoomUser) InsertRoomOption {
@@ -17,10 +18,18 @@ func InsertRoomOptionWithRoomUser(users []*model.RoomUser) InsertRoomOption {
 	}
 }
 
+func InsertRoomOptionWithDirectRoom(directRoom *model.DirectRoom) InsertRoomOption {
+	return func(ops *insertRoomOptions) {
+		ops.directRoom = directRoom
+	}
+}
+
 type SelectRoomsOption func(*selectRoomsOptions)
 
 type selectRoomsOptions struct {
-	orders []*scpb.OrderInfo
+	orders          []*scpb.OrderInfo
+	archived        *bool
+	metaDataFilters []*model.MetaDataFilter
 }
 
 func SelectRoomsOptionWithOrders(orders []*scpb.OrderInfo) SelectRoomsOption {
@@ -29,6 +38,44 @@ func SelectRoomsOptionWithOrders(orders []*scpb.OrderInfo) SelectRoomsOption {
 	}
 }
 
+func SelectRoomsOptionFilterByArchived(archived bool) SelectRoomsOption {
+	return func(ops *selectRoomsOptions) {
+		ops.archived = &archived
+	}
+}
+
+func SelectRoomsOptionFilterByMetaData(metaDataFilters []*model.MetaDataFilter) SelectRoomsOption {
+	return func(ops *selectRoomsOptions) {
+		ops.metaDataFilters = metaDataFilters
+	}
+}
+
+type SelectPublicRoomsOption func(*selectPublicRoomsOptions)
+
+type selectPublicRoomsOptions struct {
+	name string
+	tags []string
+	sort model.PublicRoomsSort
+}
+
+func SelectPublicRoomsOptionFilterByName(name string) SelectPublicRoomsOption {
+	return func(ops *selectPublicRoomsOptions) {
+		ops.name = name
+	}
+}
+
+func SelectPublicRoomsOptionFilterByTags(tags []string) SelectPublicRoomsOption {
+	return func(ops *selectPublicRoomsOptions) {
+		ops.tags = tags
+	}
+}
+
+func SelectPublicRoomsOptionWithSort(sort model.PublicRoomsSort) SelectPublicRoomsOption {
+	return func(ops *selectPublicRoomsOptions) {
+		ops.sort = sort
+	}
+}
+
 type SelectRoomOption func(*selectRoomOptions)
 
 type selectRoomOptions struct {
@@ -60,5 +107,8 @@ type roomStore interface {
 	SelectRooms(limit, offset int32, opts ...SelectRoomsOption) ([]*model.Room, error)
 	SelectRoom(roomID string, opts ...SelectRoomOption) (*model.Room, error)
 	SelectCountRooms(opts ...SelectRoomsOption) (int64, error)
+	SelectPublicRooms(limit, offset int32, opts ...SelectPublicRoomsOption) ([]*model.PublicRoom, error)
+	SelectCountPublicRooms(opts ...SelectPublicRoomsOption) (int64, error)
 	UpdateRoom(room *model.Room, opts ...UpdateRoomOption) error
+	UpdateRoomOwner(room *model.Room, previousOwnerUserID string) error
 }
@@ -15,7 +15,9 @@ const (
 	TestNameSelectRooms      = "select rooms test"
 	TestNameSelectRoom       = "select room test"
 	TestNameSelectCountRooms = "select count rooms test"
+	TestNameSelectRoomsMeta  = "select rooms by metaData test"
 	TestNameUpdateRoom       = "update room test"
+	TestNameUpdateRoomOwner  = "update room owner test"
 	TestRoomStoreTearDown    = "roomStore tear down"
 )
 
@@ -135,6 +137,64 @@ func TestRoomStore(t *testing.T) {
 		}
 	})
 
+	t.Run(TestNameUpdateRoomOwner, func(t *testing.T) {
+		ownerRoomID := "room-store-owner-room-id-0001"
+		newRoom := &model.Room{}
+		newRoom.RoomID = ownerRoomID
+		newRoom.UserID = "room-store-user-id-0001"
+		newRoom.MetaData = []byte(`{"key":"value"}`)
+		owner := &model.RoomUser{}
+		owner.RoomID = ownerRoomID
+		owner.UserID = "room-store-user-id-0001"
+		owner.MemberRole = model.RoomMemberRoleOwner
+		member := &model.RoomUser{}
+		member.RoomID = ownerRoomID
+		member.UserID = "room-store-user-id-0002"
+		err = Provider(ctx).InsertRoom(
+			newRoom,
+			InsertRoomOptionWithRoomUser([]*model.RoomUser{owner, member}),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+
+		newRoom.UserID = "room-store-user-id-0002"
+		err = Provider(ctx).UpdateRoomOwner(newRoom, "room-store-user-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+
+		updatedRoom, err := Provider(ctx).SelectRoom(ownerRoomID)
+		if err != nil || updatedRoom == nil {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+		if updatedRoom.UserID != "room-store-user-id-0002" {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+
+		ru, err := Provider(ctx).SelectRoomUser(ownerRoomID, "room-store-user-id-0001")
+		if err != nil || ru == nil {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+		if ru.MemberRole != model.RoomMemberRoleAdmin {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+
+		ru, err = Provider(ctx).SelectRoomUser(ownerRoomID, "room-store-user-id-0002")
+		if err != nil || ru == nil {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+		if ru.MemberRole != model.RoomMemberRoleOwner {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+
+		updatedRoom.DeletedTimestamp = 1
+		err = Provider(ctx).UpdateRoom(updatedRoom)
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameUpdateRoomOwner)
+		}
+	})
+
 	t.Run(TestNameSelectRooms, func(t *testing.T) {
 		rooms, err := Provider(ctx).SelectRooms(
 			0,
@@ -220,6 +280,58 @@ func TestRoomStore(t *testing.T) {
 		if count != 20 {
 			t.Fatalf("Failed to %s", TestNameSelectCountRooms)
 		}
+
+		count, err = Provider(ctx).SelectCountRooms(SelectRoomsOptionFilterByArchived(false))
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameSelectCountRooms)
+		}
+		if count != 20 {
+			t.Fatalf("Failed to %s", TestNameSelectCountRooms)
+		}
+
+		count, err = Provider(ctx).SelectCountRooms(SelectRoomsOptionFilterByArchived(true))
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameSelectCountRooms)
+		}
+		if count != 0 {
+			t.Fatalf("Failed to %s", TestNameSelectCountRooms)
+		}
+	})
+
+	t.Run(TestNameSelectRoomsMeta, func(t *testing.T) {
+		filters := []*model.MetaDataFilter{
+			&model.MetaDataFilter{Key: "key", Operator: model.MetaDataFilterOperatorEq, Values: []string{"value"}},
+		}
+		rooms, err := Provider(ctx).SelectRooms(100, 0, SelectRoomsOptionFilterByMetaData(filters))
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameSelectRoomsMeta)
+		}
+		if len(rooms) != 20 {
+			t.Fatalf("Failed to %s", TestNameSelectRoomsMeta)
+		}
+
+		filters = []*model.MetaDataFilter{
+			&model.MetaDataFilter{Key: "key", Operator: model.MetaDataFilterOperatorIn, Values: []string{"a", "b"}},
+		}
+		count, err := Provider(ctx).SelectCountRooms(SelectRoomsOptionFilterByMetaData(filters))
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameSelectRoomsMeta)
+		}
+		if count != 0 {
+			t.Fatalf("Failed to %s", TestNameSelectRoomsMeta)
+		}
+
+		filters = []*model.MetaDataFilter{
+			&model.MetaDataFilter{Key: "key", Operator: model.MetaDataFilterOperatorExists, Exists: true},
+			&model.MetaDataFilter{Key: "missing", Operator: model.MetaDataFilterOperatorExists, Exists: false},
+		}
+		count, err = Provider(ctx).SelectCountRooms(SelectRoomsOptionFilterByMetaData(filters))
+		if err != nil {
+			t.Fatalf("Failed to %s", TestNameSelectRoomsMeta)
+		}
+		if count != 20 {
+			t.Fatalf("Failed to %s", TestNameSelectRoomsMeta)
+		}
 	})
 
 	t.Run(TestRoomStoreTearDown, func(t *testing.T) {
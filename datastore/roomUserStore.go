@@ -7,6 +7,7 @@ import (
 
 type insertRoomUsersOptions struct {
 	beforeCleanRoomID string
+	inviteLinkUse     *model.InviteLinkUse
 }
 
 type InsertRoomUsersOption func(*insertRoomUsersOptions)
@@ -17,6 +18,13 @@ func InsertRoomUsersOptionBeforeCleanRoomID(beforeCleanRoomID string) InsertRoom
 	}
 }
 
+// InsertRoomUsersOptionWithInviteLinkUse counts the use of an invite link in the same transaction as the room users
+func InsertRoomUsersOptionWithInviteLinkUse(inviteLinkUse *model.InviteLinkUse) InsertRoomUsersOption {
+	return func(ops *insertRoomUsersOptions) {
+		ops.inviteLinkUse = inviteLinkUse
+	}
+}
+
 type selectRoomUsersOptions struct {
 	roomID  string
 	userIDs []string
@@ -70,8 +78,13 @@ func SelectUserIDsOfRoomUserOptionWithRoles(roles []int32) SelectUserIDsOfRoomUs
 }
 
 type selectMiniRoomsOptions struct {
-	orders []*scpb.OrderInfo
-	filter scpb.UserRoomsFilter
+	orders          []*scpb.OrderInfo
+	filter          scpb.UserRoomsFilter
+	archived        *bool
+	favorite        *bool
+	folderID        string
+	sort            model.MiniRoomsSort
+	metaDataFilters []*model.MetaDataFilter
 }
 
 type SelectMiniRoomsOption func(*selectMiniRoomsOptions)
@@ -88,6 +101,38 @@ func SelectMiniRoomsOptionFilter(filter scpb.UserRoomsFilter) SelectMiniRoomsOpt
 	}
 }
 
+func SelectMiniRoomsOptionFilterByArchived(archived bool) SelectMiniRoomsOption {
+	return func(ops *selectMiniRoomsOptions) {
+		ops.archived = &archived
+	}
+}
+
+func SelectMiniRoomsOptionFilterByFavorite(favorite *bool) SelectMiniRoomsOption {
+	return func(ops *selectMiniRoomsOptions) {
+		ops.favorite = favorite
+	}
+}
+
+func SelectMiniRoomsOptionFilterByFolderID(folderID string) SelectMiniRoomsOption {
+	return func(ops *selectMiniRoomsOptions) {
+		ops.folderID = folderID
+	}
+}
+
+// SelectMiniRoomsOptionFilterByMetaData filters by the metaData of the rooms
+func SelectMiniRoomsOptionFilterByMetaData(metaDataFilters []*model.MetaDataFilter) SelectMiniRoomsOption {
+	return func(ops *selectMiniRoomsOptions) {
+		ops.metaDataFilters = metaDataFilters
+	}
+}
+
+// SelectMiniRoomsOptionWithSort takes precedence over SelectMiniRoomsOptionWithOrders
+func SelectMiniRoomsOptionWithSort(sort model.MiniRoomsSort) SelectMiniRoomsOption {
+	return func(ops *selectMiniRoomsOptions) {
+		ops.sort = sort
+	}
+}
+
 type deleteRoomUsersOptions struct {
 	roomIDs []string
 	userIDs []string
@@ -115,9 +160,12 @@ type roomUserStore interface {
 	SelectRoomUser(roomID, userID string) (*model.RoomUser, error)
 	SelectRoomUserOfOneOnOne(myUserID, opponentUserID string) (*model.RoomUser, error)
 	SelectUserIDsOfRoomUser(opts ...SelectUserIDsOfRoomUserOption) ([]string, error)
+	SelectRoomMateUserIDs(userID string, userIDs []string) ([]string, error)
 	SelectMiniRoom(roomID, userID string) (*model.MiniRoom, error)
 	SelectMiniRooms(limit, offset int32, userID string, opts ...SelectMiniRoomsOption) ([]*model.MiniRoom, error)
 	SelectCountMiniRooms(userID string, opts ...SelectMiniRoomsOption) (int64, error)
+	SelectRoomUnreadCounts(userID string) ([]*model.RoomUnreadCount, error)
+	SelectUnreadCountsOfRoomMembers(roomID string) ([]*model.UserUnreadCount, error)
 	UpdateRoomUser(roomUser *model.RoomUser) error
 	DeleteRoomUsers(opts ...DeleteRoomUsersOption) error
 }
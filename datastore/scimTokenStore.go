@@ -0,0 +1,30 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type SelectScimTokenOption func(*selectScimTokenOptions)
+
+type selectScimTokenOptions struct {
+	tokenID   string
+	tokenHash string
+}
+
+func SelectScimTokenOptionFilterByTokenID(tokenID string) SelectScimTokenOption {
+	return func(ops *selectScimTokenOptions) {
+		ops.tokenID = tokenID
+	}
+}
+
+func SelectScimTokenOptionFilterByTokenHash(tokenHash string) SelectScimTokenOption {
+	return func(ops *selectScimTokenOptions) {
+		ops.tokenHash = tokenHash
+	}
+}
+
+type scimTokenStore interface {
+	createScimTokenStore()
+
+	InsertScimToken(scimToken *model.ScimToken) error
+	SelectScimToken(opts ...SelectScimTokenOption) (*model.ScimToken, error)
+	UpdateScimToken(scimToken *model.ScimToken) error
+}
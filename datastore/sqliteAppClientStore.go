@@ -16,3 +16,13 @@ func (p *sqliteProvider) SelectLatestAppClient(opts ...SelectAppClientOption) (*
 	replica := RdbStore(p.database).replica()
 	return rdbSelectLatestAppClient(p.ctx, replica, opts...)
 }
+
+func (p *sqliteProvider) SelectAppClients() ([]*model.AppClient, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectAppClients(p.ctx, replica)
+}
+
+func (p *sqliteProvider) UpdateAppClient(appClient *model.AppClient) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateAppClient(p.ctx, master, appClient)
+}
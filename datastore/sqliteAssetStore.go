@@ -12,6 +12,11 @@ func (p *sqliteProvider) InsertAsset(asset *model.Asset) error {
 	return rdbInsertAsset(p.ctx, master, asset)
 }
 
+func (p *sqliteProvider) SelectAssets(opts ...SelectAssetsOption) ([]*model.Asset, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectAssets(p.ctx, replica, opts...)
+}
+
 func (p *sqliteProvider) SelectAsset(assetID string) (*model.Asset, error) {
 	replica := RdbStore(p.database).replica()
 	return rdbSelectAsset(p.ctx, replica, assetID)
@@ -0,0 +1,48 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *sqliteProvider) createContactStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateContactStore(p.ctx, master)
+}
+
+func (p *sqliteProvider) SelectContact(userID, contactUserID string) (*model.Contact, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectContact(p.ctx, replica, userID, contactUserID)
+}
+
+func (p *sqliteProvider) SelectContactUserIDs(userID string, contactUserIDs []string) ([]string, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectContactUserIDs(p.ctx, replica, userID, contactUserIDs)
+}
+
+func (p *sqliteProvider) DeleteContacts(userID, contactUserID string) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while deleting contacts")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbDeleteContacts(p.ctx, master, tx, userID, contactUserID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while deleting contacts")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *sqliteProvider) createFriendRequestStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateFriendRequestStore(p.ctx, master)
+}
+
+func (p *sqliteProvider) InsertFriendRequest(friendRequest *model.FriendRequest) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertFriendRequest(p.ctx, master, friendRequest)
+}
+
+func (p *sqliteProvider) SelectFriendRequests(opts ...SelectFriendRequestsOption) ([]*model.FriendRequest, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectFriendRequests(p.ctx, replica, opts...)
+}
+
+func (p *sqliteProvider) SelectFriendRequest(friendRequestID string) (*model.FriendRequest, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectFriendRequest(p.ctx, replica, friendRequestID)
+}
+
+func (p *sqliteProvider) UpdateFriendRequest(friendRequest *model.FriendRequest, opts ...UpdateFriendRequestOption) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while updating friend request")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbUpdateFriendRequest(p.ctx, master, tx, friendRequest, opts...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while updating friend request")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package datastore
+
+import (
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/model"
+)
+
+func (p *sqliteProvider) createInvitationStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateInvitationStore(p.ctx, master)
+}
+
+func (p *sqliteProvider) InsertInvitations(invitations []*model.Invitation) error {
+	master := RdbStore(p.database).master()
+	tx, err := master.Begin()
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred while inserting invitations")
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = rdbInsertInvitations(p.ctx, master, tx, invitations)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		err = errors.Wrap(err, "An error occurred while inserting invitations")
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (p *sqliteProvider) SelectInvitations(opts ...SelectInvitationsOption) ([]*model.Invitation, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectInvitations(p.ctx, replica, opts...)
+}
+
+func (p *sqliteProvider) SelectInvitation(invitationID string) (*model.Invitation, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectInvitation(p.ctx, replica, invitationID)
+}
+
+func (p *sqliteProvider) UpdateInvitation(invitation *model.Invitation) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateInvitation(p.ctx, master, invitation)
+}
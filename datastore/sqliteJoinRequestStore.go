@@ -0,0 +1,28 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *sqliteProvider) createJoinRequestStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateJoinRequestStore(p.ctx, master)
+}
+
+func (p *sqliteProvider) InsertJoinRequest(joinRequest *model.JoinRequest) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertJoinRequest(p.ctx, master, joinRequest)
+}
+
+func (p *sqliteProvider) SelectJoinRequests(opts ...SelectJoinRequestsOption) ([]*model.JoinRequest, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectJoinRequests(p.ctx, replica, opts...)
+}
+
+func (p *sqliteProvider) SelectJoinRequest(joinRequestID string) (*model.JoinRequest, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectJoinRequest(p.ctx, replica, joinRequestID)
+}
+
+func (p *sqliteProvider) UpdateJoinRequest(joinRequest *model.JoinRequest) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateJoinRequest(p.ctx, master, joinRequest)
+}
@@ -0,0 +1,33 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *sqliteProvider) createNotificationTemplateStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateNotificationTemplateStore(p.ctx, master)
+}
+
+func (p *sqliteProvider) InsertNotificationTemplate(notificationTemplate *model.NotificationTemplate) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertNotificationTemplate(p.ctx, master, notificationTemplate)
+}
+
+func (p *sqliteProvider) SelectNotificationTemplates() ([]*model.NotificationTemplate, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectNotificationTemplates(p.ctx, replica)
+}
+
+func (p *sqliteProvider) SelectNotificationTemplate(messageType, lang string) (*model.NotificationTemplate, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectNotificationTemplate(p.ctx, replica, messageType, lang)
+}
+
+func (p *sqliteProvider) UpdateNotificationTemplate(notificationTemplate *model.NotificationTemplate) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateNotificationTemplate(p.ctx, master, notificationTemplate)
+}
+
+func (p *sqliteProvider) DeleteNotificationTemplate(messageType, lang string) error {
+	master := RdbStore(p.database).master()
+	return rdbDeleteNotificationTemplate(p.ctx, master, messageType, lang)
+}
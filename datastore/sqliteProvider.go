@@ -66,16 +66,31 @@ func (p *sqliteProvider) Connect(dsCfg *config.Datastore) error {
 
 func (p *sqliteProvider) CreateTables() {
 	p.createAppClientStore()
+	p.createAppClientSecretStore()
 	p.createAssetStore()
 	p.createBlockUserStore()
+	p.createBulkImportStore()
+	p.createContactStore()
 	p.createDeviceStore()
+	p.createDirectRoomStore()
+	p.createFriendRequestStore()
+	p.createInvitationStore()
+	p.createInviteLinkStore()
+	p.createJoinRequestStore()
 	p.createMessageStore()
+	p.createModerationStore()
+	p.createNotificationTemplateStore()
+	p.createRateLimitStore()
+	p.createRoomFolderStore()
 	p.createRoomStore()
 	p.createRoomUserStore()
+	p.createScimTokenStore()
 	p.createSettingStore()
 	p.createSubscriptionStore()
+	p.createUserExportStore()
 	p.createUserStore()
 	p.createUserRoleStore()
+	p.createUserStatusStore()
 	p.createWebhookStore()
 }
 
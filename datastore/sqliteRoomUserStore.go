@@ -57,6 +57,11 @@ func (p *sqliteProvider) SelectUserIDsOfRoomUser(opts ...SelectUserIDsOfRoomUser
 	return rdbSelectUserIDsOfRoomUser(p.ctx, replica, opts...)
 }
 
+func (p *sqliteProvider) SelectRoomMateUserIDs(userID string, userIDs []string) ([]string, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomMateUserIDs(p.ctx, replica, userID, userIDs)
+}
+
 func (p *sqliteProvider) SelectMiniRoom(roomID, userID string) (*model.MiniRoom, error) {
 	replica := RdbStore(p.database).replica()
 	return rdbSelectMiniRoom(p.ctx, replica, roomID, userID)
@@ -72,6 +77,16 @@ func (p *sqliteProvider) SelectCountMiniRooms(userID string, opts ...SelectMiniR
 	return rdbSelectCountMiniRooms(p.ctx, replica, userID, opts...)
 }
 
+func (p *sqliteProvider) SelectRoomUnreadCounts(userID string) ([]*model.RoomUnreadCount, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectRoomUnreadCounts(p.ctx, replica, userID)
+}
+
+func (p *sqliteProvider) SelectUnreadCountsOfRoomMembers(roomID string) ([]*model.UserUnreadCount, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUnreadCountsOfRoomMembers(p.ctx, replica, roomID)
+}
+
 func (p *sqliteProvider) UpdateRoomUser(roomUser *model.RoomUser) error {
 	master := RdbStore(p.database).master()
 	tx, err := master.Begin()
@@ -0,0 +1,23 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+func (p *sqliteProvider) createUserExportStore() {
+	master := RdbStore(p.database).master()
+	rdbCreateUserExportStore(p.ctx, master)
+}
+
+func (p *sqliteProvider) InsertUserExport(userExport *model.UserExport) error {
+	master := RdbStore(p.database).master()
+	return rdbInsertUserExport(p.ctx, master, userExport)
+}
+
+func (p *sqliteProvider) SelectUserExport(exportID string) (*model.UserExport, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUserExport(p.ctx, replica, exportID)
+}
+
+func (p *sqliteProvider) UpdateUserExport(userExport *model.UserExport) error {
+	master := RdbStore(p.database).master()
+	return rdbUpdateUserExport(p.ctx, master, userExport)
+}
@@ -47,9 +47,9 @@ func (p *sqliteProvider) SelectUser(userID string, opts ...SelectUserOption) (*m
 	return rdbSelectUser(p.ctx, replica, userID, opts...)
 }
 
-func (p *sqliteProvider) SelectCountUsers() (int64, error) {
+func (p *sqliteProvider) SelectCountUsers(opts ...SelectUsersOption) (int64, error) {
 	replica := RdbStore(p.database).replica()
-	return rdbSelectCountUsers(p.ctx, replica)
+	return rdbSelectCountUsers(p.ctx, replica, opts...)
 }
 
 func (p *sqliteProvider) SelectUserIDsOfUser(userIDs []string) ([]string, error) {
@@ -57,6 +57,11 @@ func (p *sqliteProvider) SelectUserIDsOfUser(userIDs []string) ([]string, error)
 	return rdbSelectUserIDsOfUser(p.ctx, replica, userIDs)
 }
 
+func (p *sqliteProvider) SelectUsersByUserIDs(userIDs []string) ([]*model.User, error) {
+	replica := RdbStore(p.database).replica()
+	return rdbSelectUsersByUserIDs(p.ctx, replica, userIDs)
+}
+
 func (p *sqliteProvider) UpdateUser(user *model.User, opts ...UpdateUserOption) error {
 	master := RdbStore(p.database).master()
 	tx, err := master.Begin()
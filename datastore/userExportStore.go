@@ -0,0 +1,11 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type userExportStore interface {
+	createUserExportStore()
+
+	InsertUserExport(userExport *model.UserExport) error
+	SelectUserExport(exportID string) (*model.UserExport, error)
+	UpdateUserExport(userExport *model.UserExport) error
+}
@@ -0,0 +1,75 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestStoreInsertUserExport = "[store] insert user export test"
+	TestStoreSelectUserExport = "[store] select user export test"
+	TestStoreUpdateUserExport = "[store] update user export test"
+)
+
+func TestUserExportStore(t *testing.T) {
+	t.Run(TestStoreInsertUserExport, func(t *testing.T) {
+		nowTimestamp := time.Now().Unix()
+		newUserExport := &model.UserExport{}
+		newUserExport.ExportID = "user-export-store-export-id-0001"
+		newUserExport.UserID = "user-export-store-user-id-0001"
+		newUserExport.Status = model.UserExportStatusProcessing
+		newUserExport.Created = nowTimestamp
+		newUserExport.Modified = nowTimestamp
+		err := Provider(ctx).InsertUserExport(newUserExport)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreInsertUserExport, err.Error())
+		}
+	})
+
+	t.Run(TestStoreSelectUserExport, func(t *testing.T) {
+		userExport, err := Provider(ctx).SelectUserExport("user-export-store-export-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUserExport, err.Error())
+		}
+		if userExport == nil {
+			t.Fatalf("Failed to %s. Expected userExport to be not nil, but it was nil", TestStoreSelectUserExport)
+		}
+		if userExport.UserID != "user-export-store-user-id-0001" {
+			t.Fatalf("Failed to %s. Expected userExport.UserID to be \"user-export-store-user-id-0001\", but it was %s", TestStoreSelectUserExport, userExport.UserID)
+		}
+
+		userExport, err = Provider(ctx).SelectUserExport("user-export-store-export-id-9999")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUserExport, err.Error())
+		}
+		if userExport != nil {
+			t.Fatalf("Failed to %s. Expected userExport to be nil, but it was not nil", TestStoreSelectUserExport)
+		}
+	})
+
+	t.Run(TestStoreUpdateUserExport, func(t *testing.T) {
+		userExport, err := Provider(ctx).SelectUserExport("user-export-store-export-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateUserExport, err.Error())
+		}
+
+		userExport.Complete("export-user-export-store-export-id-0001.json")
+		err = Provider(ctx).UpdateUserExport(userExport)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateUserExport, err.Error())
+		}
+
+		userExport, err = Provider(ctx).SelectUserExport("user-export-store-export-id-0001")
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreUpdateUserExport, err.Error())
+		}
+		if userExport.Status != model.UserExportStatusCompleted {
+			t.Fatalf("Failed to %s. Expected userExport.Status to be %d, but it was %d", TestStoreUpdateUserExport, model.UserExportStatusCompleted, userExport.Status)
+		}
+		if userExport.IsExpired() {
+			t.Fatalf("Failed to %s. Expected userExport.IsExpired() to be false, but it was true", TestStoreUpdateUserExport)
+		}
+	})
+}
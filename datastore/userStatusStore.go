@@ -0,0 +1,10 @@
+package datastore
+
+import "github.com/swagchat/chat-api/model"
+
+type userStatusStore interface {
+	createUserStatusStore()
+
+	UpdateUserStatus(user *model.User, userStatusLog *model.UserStatusLog) error
+	SelectUserStatusLogs(userID string, limit, offset int32) ([]*model.UserStatusLog, error)
+}
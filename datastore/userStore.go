@@ -27,7 +27,11 @@ func InsertUserOptionWithUserRoles(userRoles []*model.UserRole) InsertUserOption
 type SelectUsersOption func(*selectUsersOptions)
 
 type selectUsersOptions struct {
-	orders []*scpb.OrderInfo
+	orders          []*scpb.OrderInfo
+	metaDataFilters []*model.MetaDataFilter
+	name            string
+	nameMatch       model.UserSearchMatch
+	visibleTo       string
 }
 
 func SelectUsersOptionWithOrders(orders []*scpb.OrderInfo) SelectUsersOption {
@@ -36,10 +40,32 @@ func SelectUsersOptionWithOrders(orders []*scpb.OrderInfo) SelectUsersOption {
 	}
 }
 
+func SelectUsersOptionFilterByMetaData(metaDataFilters []*model.MetaDataFilter) SelectUsersOption {
+	return func(ops *selectUsersOptions) {
+		ops.metaDataFilters = metaDataFilters
+	}
+}
+
+func SelectUsersOptionFilterByName(name string, match model.UserSearchMatch) SelectUsersOption {
+	return func(ops *selectUsersOptions) {
+		ops.name = name
+		ops.nameMatch = match
+	}
+}
+
+// SelectUsersOptionVisibleTo limits users to those the user can find in the user directory.
+// The user, private profiles, and users blocking or blocked by the user are excluded
+func SelectUsersOptionVisibleTo(userID string) SelectUsersOption {
+	return func(ops *selectUsersOptions) {
+		ops.visibleTo = userID
+	}
+}
+
 type SelectContactsOption func(*selectContactsOptions)
 
 type selectContactsOptions struct {
 	orders []*scpb.OrderInfo
+	mode   model.ContactMode
 }
 
 func SelectContactsOptionWithOrders(orders []*scpb.OrderInfo) SelectContactsOption {
@@ -48,6 +74,12 @@ func SelectContactsOptionWithOrders(orders []*scpb.OrderInfo) SelectContactsOpti
 	}
 }
 
+func SelectContactsOptionWithMode(mode model.ContactMode) SelectContactsOption {
+	return func(ops *selectContactsOptions) {
+		ops.mode = mode
+	}
+}
+
 type selectUserOptions struct {
 	withBlocks  bool
 	withDevices bool
@@ -106,8 +138,9 @@ type userStore interface {
 	InsertUser(user *model.User, opts ...InsertUserOption) error
 	SelectUsers(limit, offset int32, opts ...SelectUsersOption) ([]*model.User, error)
 	SelectUser(userID string, opts ...SelectUserOption) (*model.User, error)
-	SelectCountUsers() (int64, error)
+	SelectCountUsers(opts ...SelectUsersOption) (int64, error)
 	SelectUserIDsOfUser(userIDs []string) ([]string, error)
+	SelectUsersByUserIDs(userIDs []string) ([]*model.User, error)
 	UpdateUser(user *model.User, opts ...UpdateUserOption) error
 
 	SelectContacts(userID string, limit, offset int32, opts ...SelectContactsOption) ([]*model.User, error)
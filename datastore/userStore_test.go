@@ -15,6 +15,8 @@ const (
 	TestStoreSelectUsers         = "[store] select users test"
 	TestStoreSelectUser          = "[store] select user test"
 	TestStoreSelectCountUsers    = "[store] select count users test"
+	TestStoreSelectUsersMetaData = "[store] select users by metaData test"
+	TestStoreSelectUsersName     = "[store] select users by name test"
 	TestStoreSelectUserIDsOfUser = "[store] select userIds of user test"
 	TestStoreUpdateUser          = "[store] update user test"
 	TestStoreSelectContacts      = "[store] select contacts test"
@@ -223,6 +225,111 @@ func TestUserStore(t *testing.T) {
 		}
 	})
 
+	t.Run(TestStoreSelectUsersMetaData, func(t *testing.T) {
+		all, err := Provider(ctx).SelectCountUsers()
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersMetaData, err.Error())
+		}
+
+		eqFilters := []*model.MetaDataFilter{
+			&model.MetaDataFilter{Key: "key", Operator: model.MetaDataFilterOperatorEq, Values: []string{"value"}},
+		}
+		eqCount, err := Provider(ctx).SelectCountUsers(SelectUsersOptionFilterByMetaData(eqFilters))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersMetaData, err.Error())
+		}
+		if eqCount == 0 {
+			t.Fatalf("Failed to %s. Expected eq count to be greater than 0, but it was 0", TestStoreSelectUsersMetaData)
+		}
+
+		users, err := Provider(ctx).SelectUsers(100, 0, SelectUsersOptionFilterByMetaData(eqFilters))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersMetaData, err.Error())
+		}
+		if int64(len(users)) != eqCount {
+			t.Fatalf("Failed to %s. Expected users count to be %d, but it was %d", TestStoreSelectUsersMetaData, eqCount, len(users))
+		}
+
+		notEqFilters := []*model.MetaDataFilter{
+			&model.MetaDataFilter{Key: "key", Operator: model.MetaDataFilterOperatorEq, Values: []string{"other"}},
+		}
+		count, err := Provider(ctx).SelectCountUsers(SelectUsersOptionFilterByMetaData(notEqFilters))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersMetaData, err.Error())
+		}
+		if count != 0 {
+			t.Fatalf("Failed to %s. Expected count to be 0, but it was %d", TestStoreSelectUsersMetaData, count)
+		}
+
+		inFilters := []*model.MetaDataFilter{
+			&model.MetaDataFilter{Key: "key", Operator: model.MetaDataFilterOperatorIn, Values: []string{"other", "value"}},
+		}
+		count, err = Provider(ctx).SelectCountUsers(SelectUsersOptionFilterByMetaData(inFilters))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersMetaData, err.Error())
+		}
+		if count != eqCount {
+			t.Fatalf("Failed to %s. Expected count to be %d, but it was %d", TestStoreSelectUsersMetaData, eqCount, count)
+		}
+
+		notExistsFilters := []*model.MetaDataFilter{
+			&model.MetaDataFilter{Key: "key", Operator: model.MetaDataFilterOperatorExists, Exists: false},
+		}
+		count, err = Provider(ctx).SelectCountUsers(SelectUsersOptionFilterByMetaData(notExistsFilters))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersMetaData, err.Error())
+		}
+		if count+eqCount != all {
+			t.Fatalf("Failed to %s. Expected count to be %d, but it was %d", TestStoreSelectUsersMetaData, all-eqCount, count)
+		}
+	})
+
+	t.Run(TestStoreSelectUsersName, func(t *testing.T) {
+		count, err := Provider(ctx).SelectCountUsers(SelectUsersOptionFilterByName("na", model.UserSearchMatchPrefix))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersName, err.Error())
+		}
+		if count != 2 {
+			t.Fatalf("Failed to %s. Expected prefix count to be 2, but it was %d", TestStoreSelectUsersName, count)
+		}
+
+		count, err = Provider(ctx).SelectCountUsers(SelectUsersOptionFilterByName("am", model.UserSearchMatchPrefix))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersName, err.Error())
+		}
+		if count != 0 {
+			t.Fatalf("Failed to %s. Expected prefix count to be 0, but it was %d", TestStoreSelectUsersName, count)
+		}
+
+		users, err := Provider(ctx).SelectUsers(10, 0, SelectUsersOptionFilterByName("nme", model.UserSearchMatchFuzzy))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersName, err.Error())
+		}
+		if len(users) != 2 {
+			t.Fatalf("Failed to %s. Expected fuzzy users count to be 2, but it was %d", TestStoreSelectUsersName, len(users))
+		}
+
+		count, err = Provider(ctx).SelectCountUsers(SelectUsersOptionFilterByName("n%", model.UserSearchMatchPrefix))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersName, err.Error())
+		}
+		if count != 0 {
+			t.Fatalf("Failed to %s. Expected escaped count to be 0, but it was %d", TestStoreSelectUsersName, count)
+		}
+
+		// user-store-insert-user-id-0002 blocks user-store-insert-user-id-0001
+		count, err = Provider(ctx).SelectCountUsers(
+			SelectUsersOptionFilterByName("name", model.UserSearchMatchPrefix),
+			SelectUsersOptionVisibleTo("user-store-insert-user-id-0001"),
+		)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestStoreSelectUsersName, err.Error())
+		}
+		if count != 0 {
+			t.Fatalf("Failed to %s. Expected visible count to be 0, but it was %d", TestStoreSelectUsersName, count)
+		}
+	})
+
 	t.Run(TestStoreSelectUserIDsOfUser, func(t *testing.T) {
 		userIDs, err := Provider(ctx).SelectUserIDsOfUser([]string{"user-store-insert-user-id-0001"})
 		if err != nil {
@@ -19,7 +19,7 @@ func (us *roomServiceServer) CreateRoom(ctx context.Context, in *scpb.CreateRoom
 			return &scpb.Room{}, err
 		}
 	}
-	req := &model.CreateRoomRequest{*in, metaData}
+	req := &model.CreateRoomRequest{CreateRoomRequest: *in, MetaData: metaData}
 	room, errRes := service.CreateRoom(ctx, req)
 	if errRes != nil {
 		return &scpb.Room{}, errRes.Error
@@ -30,7 +30,8 @@ func (us *roomServiceServer) CreateRoom(ctx context.Context, in *scpb.CreateRoom
 }
 
 func (us *roomServiceServer) RetrieveUsers(ctx context.Context, in *scpb.RetrieveRoomsRequest) (*scpb.RoomsResponse, error) {
-	req := &model.RetrieveRoomsRequest{*in}
+	req := &model.RetrieveRoomsRequest{RetrieveRoomsRequest: *in}
+	req.MetaDataFilters = model.MetaDataFiltersFromPb(in.MetaDataFilters)
 	rooms, errRes := service.RetrieveRooms(ctx, req)
 	if errRes != nil {
 		return &scpb.RoomsResponse{}, errRes.Error
@@ -59,7 +60,7 @@ func (us *roomServiceServer) UpdateRoom(ctx context.Context, in *scpb.UpdateRoom
 			return &scpb.Room{}, err
 		}
 	}
-	req := &model.UpdateRoomRequest{*in, metaData}
+	req := &model.UpdateRoomRequest{UpdateRoomRequest: *in, MetaData: metaData}
 	room, errRes := service.UpdateRoom(ctx, req)
 	if errRes != nil {
 		return &scpb.Room{}, errRes.Error
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+type roomFolderServiceServer struct{}
+
+func (rfs *roomFolderServiceServer) CreateRoomFolder(ctx context.Context, in *scpb.CreateRoomFolderRequest) (*scpb.RoomFolder, error) {
+	req := &model.CreateRoomFolderRequest{
+		UserID:   in.UserID,
+		Name:     in.Name,
+		Position: in.Position,
+	}
+	roomFolder, errRes := service.CreateRoomFolder(ctx, req)
+	if errRes != nil {
+		return &scpb.RoomFolder{}, errRes.Error
+	}
+
+	pbRoomFolder := roomFolder.ConvertToPbRoomFolder()
+	return pbRoomFolder, nil
+}
+
+func (rfs *roomFolderServiceServer) RetrieveRoomFolders(ctx context.Context, in *scpb.RetrieveRoomFoldersRequest) (*scpb.RoomFoldersResponse, error) {
+	req := &model.RetrieveRoomFoldersRequest{
+		UserID: in.UserID,
+	}
+	res, errRes := service.RetrieveRoomFolders(ctx, req)
+	if errRes != nil {
+		return &scpb.RoomFoldersResponse{}, errRes.Error
+	}
+
+	roomFolders := res.ConvertToPbRoomFolders()
+	return roomFolders, nil
+}
+
+func (rfs *roomFolderServiceServer) UpdateRoomFolder(ctx context.Context, in *scpb.UpdateRoomFolderRequest) (*scpb.RoomFolder, error) {
+	req := &model.UpdateRoomFolderRequest{
+		UserID:   in.UserID,
+		FolderID: in.FolderID,
+		Name:     in.Name,
+		Position: in.Position,
+	}
+	roomFolder, errRes := service.UpdateRoomFolder(ctx, req)
+	if errRes != nil {
+		return &scpb.RoomFolder{}, errRes.Error
+	}
+
+	pbRoomFolder := roomFolder.ConvertToPbRoomFolder()
+	return pbRoomFolder, nil
+}
+
+func (rfs *roomFolderServiceServer) DeleteRoomFolder(ctx context.Context, in *scpb.DeleteRoomFolderRequest) (*empty.Empty, error) {
+	req := &model.DeleteRoomFolderRequest{
+		UserID:   in.UserID,
+		FolderID: in.FolderID,
+	}
+	errRes := service.DeleteRoomFolder(ctx, req)
+	if errRes != nil {
+		return &empty.Empty{}, errRes.Error
+	}
+
+	return &empty.Empty{}, nil
+}
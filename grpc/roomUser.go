@@ -43,6 +43,22 @@ func (urs *roomUserServiceServer) RetrieveRoomUserIds(ctx context.Context, in *s
 	return roomUserIDs, nil
 }
 
+func (urs *roomUserServiceServer) UpdateRoomUserPreference(ctx context.Context, in *scpb.UpdateRoomUserPreferenceRequest) (*empty.Empty, error) {
+	req := &model.UpdateRoomUserPreferenceRequest{
+		RoomID:   in.RoomID,
+		UserID:   in.UserID,
+		Favorite: in.Favorite,
+		FolderID: in.FolderID,
+		Position: in.Position,
+	}
+	_, errRes := service.UpdateRoomUserPreference(ctx, req)
+	if errRes != nil {
+		return &empty.Empty{}, errRes.Error
+	}
+
+	return &empty.Empty{}, nil
+}
+
 func (urs *roomUserServiceServer) UpdateRoomUser(ctx context.Context, in *scpb.UpdateRoomUserRequest) (*empty.Empty, error) {
 	req := &model.UpdateRoomUserRequest{*in}
 	errRes := service.UpdateRoomUser(ctx, req)
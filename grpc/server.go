@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
 
 	"github.com/betchi/tracer"
@@ -11,14 +12,27 @@ import (
 
 	logger "github.com/betchi/zapper"
 	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 func unaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if config.Config().Authentication.Mode == config.AuthenticationModeJWT {
+			var err error
+			ctx, err = jwtAuthn(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return invoke(ctx, req, info, handler)
+		}
+
 		workspace := ""
 
 		headers, ok := metadata.FromIncomingContext(ctx)
@@ -28,6 +42,12 @@ func unaryServerInterceptor() grpc.UnaryServerInterceptor {
 					workspace = v[0]
 				}
 			}
+
+			if v, ok := headers[strings.ToLower(config.HeaderUserID)]; ok {
+				if len(v) > 0 {
+					ctx = context.WithValue(ctx, config.CtxUserID, v[0])
+				}
+			}
 		}
 
 		if workspace == "" {
@@ -36,16 +56,81 @@ func unaryServerInterceptor() grpc.UnaryServerInterceptor {
 
 		ctx = context.WithValue(ctx, config.CtxWorkspace, workspace)
 
-		ctx, _ = tracer.StartTransaction(ctx, fmt.Sprintf("%s:%v", info.FullMethod, info.Server), "GRPC")
-		defer tracer.CloseTransaction(ctx)
+		if userID, ok := ctx.Value(config.CtxUserID).(string); ok {
+			errRes := service.UserStatusAuthz(ctx, userID)
+			if errRes != nil {
+				if errRes.Error != nil {
+					return nil, errRes.Error
+				}
+				return nil, status.Error(codes.PermissionDenied, errRes.Message)
+			}
+		}
+
+		return invoke(ctx, req, info, handler)
+	}
+}
 
-		reply, err := handler(ctx, req)
-		if err != nil {
-			return nil, err
+// jwtAuthn verifies the bearer token of the authorization metadata in the jwt authentication mode
+func jwtAuthn(ctx context.Context) (context.Context, error) {
+	token := ""
+	if headers, ok := metadata.FromIncomingContext(ctx); ok {
+		if v, ok := headers["authorization"]; ok && len(v) > 0 {
+			if strings.HasPrefix(strings.ToLower(v[0]), "bearer ") {
+				token = strings.TrimSpace(v[0][len("bearer "):])
+			}
 		}
+	}
+
+	principal, errRes := service.TokenAuthn(token)
+	if errRes != nil {
+		return nil, errorResponseToError(errRes, codes.Unauthenticated)
+	}
 
-		return reply, nil
+	workspace := principal.Workspace
+	if workspace == "" {
+		workspace = config.Config().Datastore.Database
 	}
+	ctx = context.WithValue(ctx, config.CtxWorkspace, workspace)
+	ctx = context.WithValue(ctx, config.CtxUserID, principal.UserID)
+	if principal.RoleIDs != nil {
+		ctx = context.WithValue(ctx, config.CtxRoleIDs, principal.RoleIDs)
+	}
+
+	// The client of the token is an app client only if it is registered
+	clientID, errRes := service.AppClientAuthn(ctx, principal.ClientID)
+	if errRes != nil {
+		return nil, errorResponseToError(errRes, codes.Internal)
+	}
+	ctx = context.WithValue(ctx, config.CtxClientID, clientID)
+
+	// Requests of an app client are not rejected so that an admin can reactivate the user
+	if clientID == "" {
+		errRes = service.UserStatusAuthz(ctx, principal.UserID)
+		if errRes != nil {
+			return nil, errorResponseToError(errRes, codes.PermissionDenied)
+		}
+	}
+
+	return ctx, nil
+}
+
+func errorResponseToError(errRes *model.ErrorResponse, code codes.Code) error {
+	if errRes.Error != nil && errRes.Status == http.StatusInternalServerError {
+		return errRes.Error
+	}
+	return status.Error(code, errRes.Message)
+}
+
+func invoke(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, _ = tracer.StartTransaction(ctx, fmt.Sprintf("%s:%v", info.FullMethod, info.Server), "GRPC")
+	defer tracer.CloseTransaction(ctx)
+
+	reply, err := handler(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
 }
 
 // Run runs GRPC API server
@@ -64,6 +149,7 @@ func Run(ctx context.Context) {
 	scpb.RegisterBlockUserServiceServer(s, &blockUserServiceServer{})
 	scpb.RegisterDeviceServiceServer(s, &deviceServiceServer{})
 	scpb.RegisterMessageServiceServer(s, &messageServer{})
+	scpb.RegisterRoomFolderServiceServer(s, &roomFolderServiceServer{})
 	scpb.RegisterRoomUserServiceServer(s, &roomUserServiceServer{})
 	scpb.RegisterUserServiceServer(s, &userServiceServer{})
 	scpb.RegisterUserRoleServiceServer(s, &userRoleServiceServer{})
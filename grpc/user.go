@@ -30,7 +30,8 @@ func (us *userServiceServer) CreateUser(ctx context.Context, in *scpb.CreateUser
 }
 
 func (us *userServiceServer) RetrieveUsers(ctx context.Context, in *scpb.RetrieveUsersRequest) (*scpb.UsersResponse, error) {
-	req := &model.RetrieveUsersRequest{*in}
+	req := &model.RetrieveUsersRequest{RetrieveUsersRequest: *in}
+	req.MetaDataFilters = model.MetaDataFiltersFromPb(in.MetaDataFilters)
 	users, errRes := service.RetrieveUsers(ctx, req)
 	if errRes != nil {
 		return &scpb.UsersResponse{}, errRes.Error
@@ -69,6 +70,33 @@ func (us *userServiceServer) UpdateUser(ctx context.Context, in *scpb.UpdateUser
 	return pbUser, nil
 }
 
+func (us *userServiceServer) GetOrCreateDirectRoom(ctx context.Context, in *scpb.GetOrCreateDirectRoomRequest) (*scpb.Room, error) {
+	req := &model.GetOrCreateDirectRoomRequest{
+		UserID:      in.UserID,
+		OtherUserID: in.OtherUserID,
+	}
+	room, errRes := service.GetOrCreateDirectRoom(ctx, req)
+	if errRes != nil {
+		return &scpb.Room{}, errRes.Error
+	}
+
+	pbRoom := room.ConvertToPbRoom()
+	return pbRoom, nil
+}
+
+func (us *userServiceServer) RetrieveUnreadSummary(ctx context.Context, in *scpb.RetrieveUnreadSummaryRequest) (*scpb.UnreadSummary, error) {
+	req := &model.RetrieveUnreadSummaryRequest{
+		UserID: in.UserID,
+	}
+	unreadSummary, errRes := service.RetrieveUnreadSummary(ctx, req)
+	if errRes != nil {
+		return &scpb.UnreadSummary{}, errRes.Error
+	}
+
+	pbUnreadSummary := unreadSummary.ConvertToPbUnreadSummary()
+	return pbUnreadSummary, nil
+}
+
 func (us *userServiceServer) DeleteUser(ctx context.Context, in *scpb.DeleteUserRequest) (*empty.Empty, error) {
 	req := &model.DeleteUserRequest{*in}
 	errRes := service.DeleteUser(ctx, req)
@@ -80,7 +108,11 @@ func (us *userServiceServer) DeleteUser(ctx context.Context, in *scpb.DeleteUser
 }
 
 func (urs *userServiceServer) RetrieveUserRooms(ctx context.Context, in *scpb.RetrieveUserRoomsRequest) (*scpb.UserRoomsResponse, error) {
-	req := &model.RetrieveUserRoomsRequest{*in}
+	req := &model.RetrieveUserRoomsRequest{RetrieveUserRoomsRequest: *in}
+	req.Favorite = in.Favorite
+	req.FolderID = in.FolderID
+	req.Sort = model.MiniRoomsSort(in.Sort)
+	req.MetaDataFilters = model.MetaDataFiltersFromPb(in.MetaDataFilters)
 	res, errRes := service.RetrieveUserRooms(ctx, req)
 	if errRes != nil {
 		return &scpb.UserRoomsResponse{}, errRes.Error
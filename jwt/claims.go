@@ -0,0 +1,157 @@
+package jwt
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+)
+
+// Claims is the payload of a token. Numbers are decoded as json.Number
+type Claims map[string]interface{}
+
+// String returns the claim if it is a string, otherwise ""
+func (c Claims) String(name string) string {
+	if s, ok := c[name].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// Strings returns the claim if it is a string or an array of strings, such as aud
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Int32s returns the claim as integers. Numbers and numeric strings are accepted either alone or in an array
+func (c Claims) Int32s(name string) []int32 {
+	var values []interface{}
+	switch v := c[name].(type) {
+	case []interface{}:
+		values = v
+	case nil:
+		return nil
+	default:
+		values = []interface{}{v}
+	}
+
+	ints := make([]int32, 0, len(values))
+	for _, e := range values {
+		var s string
+		switch v := e.(type) {
+		case json.Number:
+			s = v.String()
+		case string:
+			s = v
+		default:
+			continue
+		}
+		i, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			continue
+		}
+		ints = append(ints, int32(i))
+	}
+	return ints
+}
+
+// time returns the claim of NumericDate, the seconds from the epoch
+func (c Claims) time(name string) (time.Time, bool) {
+	n, ok := c[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+// Principal is who the token is issued to, mapped from the claims by authentication.jwt settings
+type Principal struct {
+	UserID    string
+	Workspace string
+	ClientID  string
+	RoleIDs   []int32
+}
+
+// NewPrincipal maps the claims to a principal. A token without a user is accepted if it is issued to a client
+func NewPrincipal(claims Claims, cfg *config.JWT) (*Principal, error) {
+	p := &Principal{
+		UserID: claims.String(cfg.UserIDClaim),
+	}
+	if cfg.WorkspaceClaim != "" {
+		p.Workspace = claims.String(cfg.WorkspaceClaim)
+	}
+	if cfg.ClientIDClaim != "" {
+		p.ClientID = claims.String(cfg.ClientIDClaim)
+	}
+	if cfg.RolesClaim != "" {
+		p.RoleIDs = claims.Int32s(cfg.RolesClaim)
+	}
+
+	if p.UserID == "" && p.ClientID == "" {
+		return nil, errors.Wrapf(ErrInvalidToken, "%s is required", cfg.UserIDClaim)
+	}
+
+	return p, nil
+}
+
+// ClientIDClaim returns the claim of the app client of the settings. The default is "client_id"
+func ClientIDClaim(cfg *config.JWT) string {
+	if cfg.ClientIDClaim == "" {
+		return "client_id"
+	}
+	return cfg.ClientIDClaim
+}
+
+// AccessTokenClientID returns the app client that an access token is issued to
+func AccessTokenClientID(claims Claims, cfg *config.JWT) (string, error) {
+	clientIDClaim := ClientIDClaim(cfg)
+	clientID := claims.String(clientIDClaim)
+	if clientID == "" {
+		return "", errors.Wrapf(ErrInvalidToken, "%s is required", clientIDClaim)
+	}
+	return clientID, nil
+}
+
+// Authenticate verifies the token with the default verifier and returns its principal
+func Authenticate(token string) (*Principal, error) {
+	v, err := DefaultVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := v.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrincipal(claims, config.Config().Authentication.JWT)
+}
+
+// AuthenticateAccessToken verifies the token only with the secret of oauth.signingSecret and returns the app client it is issued to
+func AuthenticateAccessToken(token string) (string, error) {
+	cfg := config.Config()
+	claims, err := NewAccessTokenVerifier(cfg.OAuth.SigningSecret, cfg.Authentication.JWT).Verify(token)
+	if err != nil {
+		return "", err
+	}
+
+	return AccessTokenClientID(claims, cfg.Authentication.JWT)
+}
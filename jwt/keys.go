@@ -0,0 +1,175 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// key is a verification key. kid is empty for the keys without a key ID
+type key struct {
+	kid        string
+	hmacSecret []byte
+	publicKey  crypto.PublicKey
+}
+
+// supports reports whether the key can verify a signature of the algorithm
+func (k *key) supports(alg string) bool {
+	switch alg {
+	case AlgorithmHS256:
+		return len(k.hmacSecret) > 0
+	case AlgorithmRS256:
+		_, ok := k.publicKey.(*rsa.PublicKey)
+		return ok
+	case AlgorithmES256:
+		pub, ok := k.publicKey.(*ecdsa.PublicKey)
+		return ok && pub.Curve == elliptic.P256()
+	}
+	return false
+}
+
+// parsePEMKeys parses RSA or ECDSA public keys and certificates in PEM format
+func parsePEMKeys(data []byte) ([]*key, error) {
+	var keys []*key
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var publicKey crypto.PublicKey
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			cert, err = x509.ParseCertificate(block.Bytes)
+			if err == nil {
+				publicKey = cert.PublicKey
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "An error occurred while parsing public key")
+		}
+
+		keys = append(keys, &key{publicKey: publicKey})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("An error occurred while parsing public key. No public key is found")
+	}
+
+	return keys, nil
+}
+
+type jsonWebKeySet struct {
+	Keys []*jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// parseJWKS parses a JSON Web Key Set. Keys of other uses than signatures and unsupported types are skipped.
+// Symmetric keys are skipped unless allowSymmetric is true, because a shared secret must not be published at a URL
+func parseJWKS(data []byte, allowSymmetric bool) ([]*key, error) {
+	var jwks jsonWebKeySet
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, errors.Wrap(err, "An error occurred while parsing JWKS")
+	}
+
+	keys := make([]*key, 0, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if jwk.Kty == "oct" && !allowSymmetric {
+			continue
+		}
+
+		k, err := jwk.key()
+		if err != nil {
+			return nil, err
+		}
+		if k != nil {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys, nil
+}
+
+func (jwk *jsonWebKey) key() (*key, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &key{
+			kid:       jwk.Kid,
+			publicKey: &rsa.PublicKey{N: n, E: int(e.Int64())},
+		}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &key{
+			kid:       jwk.Kid,
+			publicKey: &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil {
+			return nil, errors.Wrap(err, "An error occurred while parsing JWKS")
+		}
+		return &key{
+			kid:        jwk.Kid,
+			hmacSecret: secret,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("An error occurred while parsing JWKS. A key parameter is not base64url encoded")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
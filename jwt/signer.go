@@ -0,0 +1,32 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// SignHS256 returns a token of the claims signed with the secret
+func SignHS256(claims Claims, secret []byte) (string, error) {
+	if len(secret) == 0 {
+		return "", errors.New("An error occurred while signing token. The secret is empty")
+	}
+
+	h, err := json.Marshal(&header{Alg: AlgorithmHS256, Typ: "JWT"})
+	if err != nil {
+		return "", errors.Wrap(err, "An error occurred while signing token")
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "An error occurred while signing token")
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,311 @@
+// Package jwt verifies the bearer tokens of requests in the jwt authentication mode, and signs the access tokens of app clients
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+
+	// leeway is the allowed clock skew between the issuer and the API on exp and nbf
+	leeway = 60 * time.Second
+
+	// jwksCacheDuration is how long the keys fetched from a JWKS URL are used
+	jwksCacheDuration = 10 * time.Minute
+	// jwksMinRefreshInterval keeps tokens with unknown kids from fetching the JWKS URL on every request
+	jwksMinRefreshInterval = time.Minute
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed, is not signed by a configured key or its claims are not acceptable
+	ErrInvalidToken = errors.New("Invalid token")
+
+	defaultVerifier     *Verifier
+	defaultVerifierErr  error
+	defaultVerifierOnce sync.Once
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// Verifier verifies the signatures and the registered claims of tokens
+type Verifier struct {
+	audience   string
+	issuer     string
+	staticKeys []*key
+	jwksURL    string
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu         sync.Mutex
+	remoteKeys []*key
+	fetched    time.Time
+}
+
+// DefaultVerifier returns the verifier of the settings of authentication.jwt and oauth
+func DefaultVerifier() (*Verifier, error) {
+	defaultVerifierOnce.Do(func() {
+		cfg := config.Config()
+		defaultVerifier, defaultVerifierErr = NewVerifier(cfg.Authentication.JWT)
+		if defaultVerifierErr == nil && cfg.OAuth.SigningSecret != "" {
+			// Access tokens of the client credentials grant are signed by the API itself
+			defaultVerifier.staticKeys = append(defaultVerifier.staticKeys, &key{hmacSecret: []byte(cfg.OAuth.SigningSecret)})
+		}
+	})
+	return defaultVerifier, defaultVerifierErr
+}
+
+// NewAccessTokenVerifier returns the verifier of the access tokens that the API signs with the secret of oauth.signingSecret
+func NewAccessTokenVerifier(secret string, cfg *config.JWT) *Verifier {
+	return &Verifier{
+		audience:   cfg.Audience,
+		issuer:     cfg.Issuer,
+		staticKeys: []*key{{hmacSecret: []byte(secret)}},
+		now:        time.Now,
+	}
+}
+
+// NewVerifier loads the static keys of the settings. Keys of a JWKS URL are fetched at the first verification
+func NewVerifier(cfg *config.JWT) (*Verifier, error) {
+	v := &Verifier{
+		audience:   cfg.Audience,
+		issuer:     cfg.Issuer,
+		jwksURL:    cfg.JWKSURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+	}
+
+	if cfg.HMACSecret != "" {
+		v.staticKeys = append(v.staticKeys, &key{hmacSecret: []byte(cfg.HMACSecret)})
+	}
+
+	if cfg.PublicKeyFile != "" {
+		data, err := ioutil.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "An error occurred while reading public key file")
+		}
+		keys, err := parsePEMKeys(data)
+		if err != nil {
+			return nil, err
+		}
+		v.staticKeys = append(v.staticKeys, keys...)
+	}
+
+	if cfg.JWKSFile != "" {
+		data, err := ioutil.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "An error occurred while reading JWKS file")
+		}
+		keys, err := parseJWKS(data, true)
+		if err != nil {
+			return nil, err
+		}
+		v.staticKeys = append(v.staticKeys, keys...)
+	}
+
+	return v, nil
+}
+
+// Verify verifies the token and returns its claims.
+// exp is required, and nbf, aud and iss are checked when they are present or configured
+func (v *Verifier) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.Wrap(ErrInvalidToken, "The token is malformed")
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, errors.Wrap(ErrInvalidToken, "The header is malformed")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidToken, "The signature is malformed")
+	}
+
+	keys, err := v.keys(h.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signingInput := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range keys {
+		if k.supports(h.Alg) && verifySignature(h.Alg, k, signingInput, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.Wrap(ErrInvalidToken, "The signature is not valid")
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errors.Wrap(ErrInvalidToken, "The payload is malformed")
+	}
+
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) validateClaims(claims Claims) error {
+	now := v.now()
+
+	exp, ok := claims.time("exp")
+	if !ok {
+		return errors.Wrap(ErrInvalidToken, "exp is required")
+	}
+	if !now.Before(exp.Add(leeway)) {
+		return errors.Wrap(ErrInvalidToken, "The token is expired")
+	}
+
+	if nbf, ok := claims.time("nbf"); ok && now.Add(leeway).Before(nbf) {
+		return errors.Wrap(ErrInvalidToken, "The token is not valid yet")
+	}
+
+	if v.audience != "" && !containsString(claims.Strings("aud"), v.audience) {
+		return errors.Wrap(ErrInvalidToken, "aud is not acceptable")
+	}
+
+	if v.issuer != "" && claims.String("iss") != v.issuer {
+		return errors.Wrap(ErrInvalidToken, "iss is not acceptable")
+	}
+
+	return nil
+}
+
+// keys returns the candidate keys of the kid. Keys without a kid are candidates of every token
+func (v *Verifier) keys(kid string) ([]*key, error) {
+	keys := filterKeys(v.staticKeys, kid)
+	if v.jwksURL == "" {
+		return keys, nil
+	}
+
+	now := v.now()
+	v.mu.Lock()
+	cachedKeys := v.remoteKeys
+	fetched := v.fetched
+	v.mu.Unlock()
+
+	remoteKeys := filterKeys(cachedKeys, kid)
+	expired := now.Sub(fetched) > jwksCacheDuration
+	unknownKid := len(remoteKeys) == 0 && now.Sub(fetched) > jwksMinRefreshInterval
+	if expired || unknownKid {
+		// The JWKS URL is fetched without the lock so that a slow response does not block the verifications of other requests
+		fetchedKeys, err := v.fetchJWKS()
+		if err != nil {
+			// The cached keys are kept while the JWKS URL is not available
+			if len(cachedKeys) == 0 && len(keys) == 0 {
+				return nil, err
+			}
+		} else {
+			v.mu.Lock()
+			if now.After(v.fetched) {
+				v.remoteKeys = fetchedKeys
+				v.fetched = now
+			}
+			v.mu.Unlock()
+			remoteKeys = filterKeys(fetchedKeys, kid)
+		}
+	}
+
+	return append(keys, remoteKeys...), nil
+}
+
+func (v *Verifier) fetchJWKS() ([]*key, error) {
+	res, err := v.httpClient.Get(v.jwksURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred while fetching JWKS")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("An error occurred while fetching JWKS. Status code is %d", res.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred while fetching JWKS")
+	}
+
+	return parseJWKS(data, false)
+}
+
+func filterKeys(keys []*key, kid string) []*key {
+	filtered := make([]*key, 0, len(keys))
+	for _, k := range keys {
+		if kid == "" || k.kid == "" || k.kid == kid {
+			filtered = append(filtered, k)
+		}
+	}
+	return filtered
+}
+
+func verifySignature(alg string, k *key, signingInput, signature []byte) bool {
+	hashed := sha256.Sum256(signingInput)
+
+	switch alg {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, k.hmacSecret)
+		mac.Write(signingInput)
+		return hmac.Equal(signature, mac.Sum(nil))
+	case AlgorithmRS256:
+		return rsa.VerifyPKCS1v15(k.publicKey.(*rsa.PublicKey), crypto.SHA256, hashed[:], signature) == nil
+	case AlgorithmES256:
+		// The signature is the concatenation of r and s, not ASN.1
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(k.publicKey.(*ecdsa.PublicKey), hashed[:], r, s)
+	}
+
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
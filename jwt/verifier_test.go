@@ -0,0 +1,346 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/swagchat/chat-api/config"
+)
+
+const (
+	TestJWTVerifyHS256     = "[jwt] verify HS256 test"
+	TestJWTVerifyRS256     = "[jwt] verify RS256 test"
+	TestJWTVerifyES256     = "[jwt] verify ES256 test"
+	TestJWTVerifyAlgorithm = "[jwt] verify algorithm test"
+	TestJWTVerifyClaims    = "[jwt] verify claims test"
+	TestJWTParseJWKS       = "[jwt] parse JWKS test"
+	TestJWTNewPrincipal    = "[jwt] new principal test"
+	TestJWTSignHS256       = "[jwt] sign HS256 test"
+	TestJWTAccessToken     = "[jwt] access token test"
+)
+
+var testNow = time.Unix(1500000000, 0)
+
+func signTestToken(t *testing.T, alg, kid string, signingKey interface{}, claims map[string]interface{}) string {
+	h := map[string]string{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		h["kid"] = kid
+	}
+	hb, _ := json.Marshal(h)
+	cb, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(cb)
+	hashed := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch k := signingKey.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hashed[:])
+		if err != nil {
+			t.Fatalf("Failed to sign a test token [%s]", err.Error())
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, hashed[:])
+		if err != nil {
+			t.Fatalf("Failed to sign a test token [%s]", err.Error())
+		}
+		signature = make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(signature[32-len(rb):32], rb)
+		copy(signature[64-len(sb):], sb)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestVerifier(keys ...*key) *Verifier {
+	return &Verifier{
+		staticKeys: keys,
+		now:        func() time.Time { return testNow },
+	}
+}
+
+func testClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub": "user-id-0001",
+		"exp": testNow.Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	t.Run(TestJWTVerifyHS256, func(t *testing.T) {
+		secret := []byte("secret")
+		v := newTestVerifier(&key{hmacSecret: secret})
+
+		claims, err := v.Verify(signTestToken(t, AlgorithmHS256, "", secret, testClaims()))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTVerifyHS256, err.Error())
+		}
+		if claims.String("sub") != "user-id-0001" {
+			t.Fatalf("Failed to %s. Expected sub to be user-id-0001, but it was %s", TestJWTVerifyHS256, claims.String("sub"))
+		}
+
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", []byte("other"), testClaims()))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because the secret is different", TestJWTVerifyHS256)
+		}
+	})
+
+	t.Run(TestJWTSignHS256, func(t *testing.T) {
+		secret := []byte("signing-secret")
+		v := newTestVerifier(&key{hmacSecret: secret})
+
+		token, err := SignHS256(Claims{"client_id": "client-id-0001", "exp": testNow.Add(time.Minute).Unix()}, secret)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTSignHS256, err.Error())
+		}
+
+		claims, err := v.Verify(token)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTSignHS256, err.Error())
+		}
+		if claims.String("client_id") != "client-id-0001" {
+			t.Fatalf("Failed to %s. Expected client_id to be client-id-0001, but it was %s", TestJWTSignHS256, claims.String("client_id"))
+		}
+	})
+
+	t.Run(TestJWTVerifyRS256, func(t *testing.T) {
+		privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		v := newTestVerifier(&key{kid: "rsa-1", publicKey: &privateKey.PublicKey})
+
+		_, err := v.Verify(signTestToken(t, AlgorithmRS256, "rsa-1", privateKey, testClaims()))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTVerifyRS256, err.Error())
+		}
+
+		_, err = v.Verify(signTestToken(t, AlgorithmRS256, "rsa-2", privateKey, testClaims()))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because kid is unknown", TestJWTVerifyRS256)
+		}
+	})
+
+	t.Run(TestJWTVerifyES256, func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		v := newTestVerifier(&key{publicKey: &privateKey.PublicKey})
+
+		_, err := v.Verify(signTestToken(t, AlgorithmES256, "", privateKey, testClaims()))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTVerifyES256, err.Error())
+		}
+	})
+
+	t.Run(TestJWTVerifyAlgorithm, func(t *testing.T) {
+		privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		v := newTestVerifier(&key{publicKey: &privateKey.PublicKey})
+
+		// A token signed with HMAC by the public key must not be accepted
+		publicKeyBytes := privateKey.PublicKey.N.Bytes()
+		_, err := v.Verify(signTestToken(t, AlgorithmHS256, "", publicKeyBytes, testClaims()))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because HS256 is not available with a public key", TestJWTVerifyAlgorithm)
+		}
+
+		token := signTestToken(t, "none", "", nil, testClaims())
+		_, err = v.Verify(token)
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because alg is none", TestJWTVerifyAlgorithm)
+		}
+	})
+
+	t.Run(TestJWTVerifyClaims, func(t *testing.T) {
+		secret := []byte("secret")
+		v := newTestVerifier(&key{hmacSecret: secret})
+		v.audience = "chat-api"
+		v.issuer = "https://issuer.example.com"
+
+		claims := testClaims()
+		claims["aud"] = []string{"other", "chat-api"}
+		claims["iss"] = "https://issuer.example.com"
+		_, err := v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTVerifyClaims, err.Error())
+		}
+
+		claims["exp"] = testNow.Add(-2 * time.Minute).Unix()
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because the token is expired", TestJWTVerifyClaims)
+		}
+
+		claims["exp"] = testNow.Add(-30 * time.Second).Unix()
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil within the leeway, but it was not nil [%s]", TestJWTVerifyClaims, err.Error())
+		}
+
+		delete(claims, "exp")
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because exp is missing", TestJWTVerifyClaims)
+		}
+
+		claims["exp"] = testNow.Add(time.Hour).Unix()
+		claims["nbf"] = testNow.Add(5 * time.Minute).Unix()
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because the token is not valid yet", TestJWTVerifyClaims)
+		}
+
+		delete(claims, "nbf")
+		claims["aud"] = "other"
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because aud is different", TestJWTVerifyClaims)
+		}
+
+		claims["aud"] = "chat-api"
+		claims["iss"] = "https://other.example.com"
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because iss is different", TestJWTVerifyClaims)
+		}
+	})
+
+	t.Run(TestJWTParseJWKS, func(t *testing.T) {
+		privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		jwks := map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "rsa-1",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(privateKey.PublicKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.PublicKey.E)).Bytes()),
+				},
+				{
+					"kty": "RSA",
+					"kid": "rsa-enc",
+					"use": "enc",
+					"n":   base64.RawURLEncoding.EncodeToString(privateKey.PublicKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.PublicKey.E)).Bytes()),
+				},
+				{
+					"kty": "oct",
+					"kid": "hmac-1",
+					"k":   base64.RawURLEncoding.EncodeToString([]byte("secret")),
+				},
+			},
+		}
+		data, _ := json.Marshal(jwks)
+
+		keys, err := parseJWKS(data, true)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTParseJWKS, err.Error())
+		}
+		if len(keys) != 2 {
+			t.Fatalf("Failed to %s. Expected keys count to be 2, but it was %d", TestJWTParseJWKS, len(keys))
+		}
+
+		keys, err = parseJWKS(data, false)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTParseJWKS, err.Error())
+		}
+		if len(keys) != 1 {
+			t.Fatalf("Failed to %s. Expected keys count without symmetric keys to be 1, but it was %d", TestJWTParseJWKS, len(keys))
+		}
+
+		v := newTestVerifier(keys...)
+		_, err = v.Verify(signTestToken(t, AlgorithmRS256, "rsa-1", privateKey, testClaims()))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTParseJWKS, err.Error())
+		}
+	})
+}
+
+func TestNewPrincipal(t *testing.T) {
+	t.Run(TestJWTNewPrincipal, func(t *testing.T) {
+		cfg := &config.JWT{
+			UserIDClaim:    "sub",
+			WorkspaceClaim: "tenant",
+			ClientIDClaim:  "client_id",
+			RolesClaim:     "roles",
+		}
+		secret := []byte("secret")
+		v := newTestVerifier(&key{hmacSecret: secret})
+
+		claims := testClaims()
+		claims["tenant"] = "workspace-0001"
+		claims["roles"] = []interface{}{1, "3", "admin"}
+		verified, err := v.Verify(signTestToken(t, AlgorithmHS256, "", secret, claims))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTNewPrincipal, err.Error())
+		}
+
+		p, err := NewPrincipal(verified, cfg)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTNewPrincipal, err.Error())
+		}
+		if p.UserID != "user-id-0001" {
+			t.Fatalf("Failed to %s. Expected userId to be user-id-0001, but it was %s", TestJWTNewPrincipal, p.UserID)
+		}
+		if p.Workspace != "workspace-0001" {
+			t.Fatalf("Failed to %s. Expected workspace to be workspace-0001, but it was %s", TestJWTNewPrincipal, p.Workspace)
+		}
+		if len(p.RoleIDs) != 2 || p.RoleIDs[0] != 1 || p.RoleIDs[1] != 3 {
+			t.Fatalf("Failed to %s. Expected roleIds to be [1 3], but it was %v", TestJWTNewPrincipal, p.RoleIDs)
+		}
+
+		_, err = NewPrincipal(Claims{"exp": json.Number("1500003600")}, cfg)
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because sub and client_id are missing", TestJWTNewPrincipal)
+		}
+	})
+}
+
+func TestAccessTokenVerifier(t *testing.T) {
+	t.Run(TestJWTAccessToken, func(t *testing.T) {
+		cfg := &config.JWT{}
+		secret := []byte("signing-secret")
+		v := NewAccessTokenVerifier(string(secret), cfg)
+		v.now = func() time.Time { return testNow }
+
+		token, err := SignHS256(Claims{"client_id": "client-id-0001", "exp": testNow.Add(time.Minute).Unix()}, secret)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTAccessToken, err.Error())
+		}
+		claims, err := v.Verify(token)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTAccessToken, err.Error())
+		}
+		clientID, err := AccessTokenClientID(claims, cfg)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTAccessToken, err.Error())
+		}
+		if clientID != "client-id-0001" {
+			t.Fatalf("Failed to %s. Expected clientId to be client-id-0001, but it was %s", TestJWTAccessToken, clientID)
+		}
+
+		_, err = v.Verify(signTestToken(t, AlgorithmHS256, "", []byte("other"), testClaims()))
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because the token is not signed with the signing secret", TestJWTAccessToken)
+		}
+
+		claims, err = v.Verify(signTestToken(t, AlgorithmHS256, "", secret, testClaims()))
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestJWTAccessToken, err.Error())
+		}
+		_, err = AccessTokenClientID(claims, cfg)
+		if err == nil {
+			t.Fatalf("Failed to %s. Expected err to be not nil because client_id is missing", TestJWTAccessToken)
+		}
+	})
+}
@@ -1,32 +1,182 @@
 package model
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"net/http"
 	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	OAuthGrantTypeClientCredentials = "client_credentials"
+	OAuthTokenTypeBearer            = "Bearer"
+
+	OAuthErrorInvalidRequest       = "invalid_request"
+	OAuthErrorInvalidClient        = "invalid_client"
+	OAuthErrorUnsupportedGrantType = "unsupported_grant_type"
+	OAuthErrorServerError          = "server_error"
+
+	appClientNameMaxLength = 64
 )
 
 // AppClient is model of app client
 type AppClient struct {
-	ID       uint64 `json:"-" db:"id"`
-	Name     string `json:"name" db:"name,notnull"`
-	ClientID string `json:"clientId" db:"client_id,notnull"`
-	Created  int64  `json:"created" db:"created,notnull"`
-	Expired  int64  `json:"expired" db:"expired,notnull"`
+	ID           uint64 `json:"-" db:"id"`
+	Name         string `json:"name" db:"name,notnull"`
+	ClientID     string `json:"clientId" db:"client_id,notnull"`
+	ClientSecret string `json:"clientSecret,omitempty" db:"-"`
+	Created      int64  `json:"created" db:"created,notnull"`
+	Expired      int64  `json:"expired" db:"expired,notnull"`
 }
 
-// MarshalJSON is MarshalJSON of AppClient
+// MarshalJSON is MarshalJSON of AppClient.
+// clientSecret is included only in the responses of the creation and the rotation
 func (ac *AppClient) MarshalJSON() ([]byte, error) {
 	l, _ := time.LoadLocation("Etc/GMT")
 	return json.Marshal(&struct {
 		Name         string `json:"name"`
 		ClientID     string `json:"clientId"`
-		ClientSecret string `json:"clientSecret"`
+		ClientSecret string `json:"clientSecret,omitempty"`
 		Created      string `json:"created"`
 		Expired      string `json:"expired"`
 	}{
-		Name:     ac.Name,
-		ClientID: ac.ClientID,
-		Created:  time.Unix(ac.Created, 0).In(l).Format(time.RFC3339),
-		Expired:  time.Unix(ac.Expired, 0).In(l).Format(time.RFC3339),
+		Name:         ac.Name,
+		ClientID:     ac.ClientID,
+		ClientSecret: ac.ClientSecret,
+		Created:      time.Unix(ac.Created, 0).In(l).Format(time.RFC3339),
+		Expired:      time.Unix(ac.Expired, 0).In(l).Format(time.RFC3339),
 	})
 }
+
+type AppClientsResponse struct {
+	AppClients []*AppClient `json:"appClients"`
+}
+
+type CreateAppClientRequest struct {
+	Name string `json:"name"`
+}
+
+func (req *CreateAppClientRequest) Validate() *ErrorResponse {
+	if req.Name == "" || len(req.Name) > appClientNameMaxLength {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "name",
+				Reason: "name is required, and must be 64 characters or less.",
+			},
+		}
+		return NewErrorResponse("Failed to create app client.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (req *CreateAppClientRequest) GenerateAppClient() *AppClient {
+	ac := &AppClient{}
+	ac.Name = req.Name
+	ac.ClientID = utils.GenerateClientID()
+	ac.Created = time.Now().Unix()
+	return ac
+}
+
+type RevokeAppClientRequest struct {
+	ClientID string
+}
+
+type RotateAppClientSecretRequest struct {
+	ClientID string
+}
+
+// AppClientSecret is a secret of an app client. Only the hash of the secret is stored.
+// The previous secrets stay valid until they expire after a rotation, so that the client can switch to the new secret
+type AppClientSecret struct {
+	ID         uint64 `json:"-" db:"id"`
+	ClientID   string `json:"clientId" db:"client_id,notnull"`
+	SecretHash string `json:"-" db:"secret_hash,notnull"`
+	Created    int64  `json:"created" db:"created,notnull"`
+	Expired    int64  `json:"expired" db:"expired,notnull"`
+}
+
+// NewAppClientSecret issues a secret of the app client. The secret itself is returned only once
+func NewAppClientSecret(clientID string) (*AppClientSecret, string) {
+	secret := utils.GenerateClientID() + utils.GenerateClientID()
+
+	acs := &AppClientSecret{}
+	acs.ClientID = clientID
+	acs.SecretHash = HashAppClientSecret(secret)
+	acs.Created = time.Now().Unix()
+	return acs, secret
+}
+
+// HashAppClientSecret returns the hash of a secret that is stored instead of the secret
+func HashAppClientSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Matches reports whether the secret is the secret of the hash
+func (acs *AppClientSecret) Matches(secret string) bool {
+	hash := HashAppClientSecret(secret)
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(acs.SecretHash)) == 1
+}
+
+// AccessTokenRequest is a token request of the client credentials grant.
+// The client is authenticated with either HTTP Basic authentication or the form parameters
+type AccessTokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+}
+
+func (req *AccessTokenRequest) Validate() *ErrorResponse {
+	if req.GrantType != OAuthGrantTypeClientCredentials {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "grant_type",
+				Reason: "grant_type must be client_credentials.",
+			},
+		}
+		return NewErrorResponse("Failed to issue access token.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if req.ClientID == "" || req.ClientSecret == "" {
+		return NewErrorResponse("Client authentication failed.", http.StatusUnauthorized)
+	}
+
+	return nil
+}
+
+type AccessTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// OAuthError is an error response of the token endpoint in the format of RFC 6749
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func NewOAuthError(errRes *ErrorResponse) *OAuthError {
+	oe := &OAuthError{}
+	oe.ErrorDescription = errRes.Message
+
+	switch {
+	case errRes.Status == http.StatusUnauthorized:
+		oe.Error = OAuthErrorInvalidClient
+	case errRes.Status >= http.StatusInternalServerError:
+		oe.Error = OAuthErrorServerError
+	case len(errRes.InvalidParams) > 0 && errRes.InvalidParams[0].Name == "grant_type":
+		oe.Error = OAuthErrorUnsupportedGrantType
+		oe.ErrorDescription = errRes.InvalidParams[0].Reason
+	default:
+		oe.Error = OAuthErrorInvalidRequest
+	}
+
+	return oe
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"net/http"
+	"testing"
+)
+
+const (
+	TestModelAppClientSecretMatches  = "[model] AppClientSecret Matches test"
+	TestModelAccessTokenRequestValid = "[model] AccessTokenRequest Validate test"
+	TestModelNewOAuthError           = "[model] NewOAuthError test"
+	TestModelCreateAppClientReqValid = "[model] CreateAppClientRequest Validate test"
+)
+
+func TestAppClient(t *testing.T) {
+	t.Run(TestModelAppClientSecretMatches, func(t *testing.T) {
+		appClientSecret, secret := NewAppClientSecret("client-id-0001")
+		if appClientSecret.SecretHash == secret {
+			t.Fatalf("Failed to %s. Expected the secret not to be stored as it is", TestModelAppClientSecretMatches)
+		}
+		if !appClientSecret.Matches(secret) {
+			t.Fatalf("Failed to %s. Expected the secret to match", TestModelAppClientSecretMatches)
+		}
+		if appClientSecret.Matches(secret + "x") {
+			t.Fatalf("Failed to %s. Expected another secret not to match", TestModelAppClientSecretMatches)
+		}
+	})
+
+	t.Run(TestModelAccessTokenRequestValid, func(t *testing.T) {
+		req := &AccessTokenRequest{
+			GrantType:    OAuthGrantTypeClientCredentials,
+			ClientID:     "client-id-0001",
+			ClientSecret: "secret",
+		}
+		errRes := req.Validate()
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelAccessTokenRequestValid)
+		}
+
+		req.GrantType = "password"
+		errRes = req.Validate()
+		if errRes == nil || errRes.Status != http.StatusBadRequest {
+			t.Fatalf("Failed to %s. Expected status to be 400 because grant_type is not supported", TestModelAccessTokenRequestValid)
+		}
+
+		req.GrantType = OAuthGrantTypeClientCredentials
+		req.ClientSecret = ""
+		errRes = req.Validate()
+		if errRes == nil || errRes.Status != http.StatusUnauthorized {
+			t.Fatalf("Failed to %s. Expected status to be 401 because client_secret is empty", TestModelAccessTokenRequestValid)
+		}
+	})
+
+	t.Run(TestModelNewOAuthError, func(t *testing.T) {
+		req := &AccessTokenRequest{GrantType: "password"}
+		oe := NewOAuthError(req.Validate())
+		if oe.Error != OAuthErrorUnsupportedGrantType {
+			t.Fatalf("Failed to %s. Expected error to be %s, but it was %s", TestModelNewOAuthError, OAuthErrorUnsupportedGrantType, oe.Error)
+		}
+
+		oe = NewOAuthError(NewErrorResponse("Client authentication failed.", http.StatusUnauthorized))
+		if oe.Error != OAuthErrorInvalidClient {
+			t.Fatalf("Failed to %s. Expected error to be %s, but it was %s", TestModelNewOAuthError, OAuthErrorInvalidClient, oe.Error)
+		}
+
+		oe = NewOAuthError(NewErrorResponse("", http.StatusBadRequest))
+		if oe.Error != OAuthErrorInvalidRequest {
+			t.Fatalf("Failed to %s. Expected error to be %s, but it was %s", TestModelNewOAuthError, OAuthErrorInvalidRequest, oe.Error)
+		}
+	})
+
+	t.Run(TestModelCreateAppClientReqValid, func(t *testing.T) {
+		req := &CreateAppClientRequest{Name: "backend"}
+		errRes := req.Validate()
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelCreateAppClientReqValid)
+		}
+
+		req.Name = ""
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because name is empty", TestModelCreateAppClientReqValid)
+		}
+	})
+}
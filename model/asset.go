@@ -32,6 +32,7 @@ var (
 type Asset struct {
 	ID        uint64 `json:"-" db:"id"`
 	AssetID   string `json:"assetId" db:"asset_id,notnull"`
+	UserID    string `json:"userId" db:"user_id"`
 	Extension string `json:"extension" db:"extension,notnull"`
 	Mime      string `json:"mime" db:"mime,notnull"`
 	Size      int64  `json:"size" db:"size,notnull"`
@@ -48,6 +49,7 @@ func (a *Asset) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(&struct {
 		AssetID   string `json:"assetId,omitempty"`
+		UserID    string `json:"userId,omitempty"`
 		Extension string `json:"extension,omitempty"`
 		Mime      string `json:"mime,omitempty"`
 		Size      int64  `json:"size,omitempty"`
@@ -58,6 +60,7 @@ func (a *Asset) MarshalJSON() ([]byte, error) {
 		Modified  string `json:"modified,omitempty"`
 	}{
 		AssetID:   a.AssetID,
+		UserID:    a.UserID,
 		Extension: a.Extension,
 		Mime:      a.Mime,
 		Size:      a.Size,
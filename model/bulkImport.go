@@ -0,0 +1,439 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	// BulkImportBatchSize is number of rows inserted in a transaction
+	BulkImportBatchSize = 100
+	// BulkImportStaleSeconds is seconds after which a processing import without progress is regarded as stopped
+	BulkImportStaleSeconds = 10 * 60
+)
+
+// BulkImportKind is what a bulk import creates
+type BulkImportKind string
+
+const (
+	BulkImportKindUsers     BulkImportKind = "users"
+	BulkImportKindRooms     BulkImportKind = "rooms"
+	BulkImportKindRoomUsers BulkImportKind = "roomUsers"
+)
+
+func (bik BulkImportKind) IsValid() bool {
+	return bik == BulkImportKindUsers || bik == BulkImportKindRooms || bik == BulkImportKindRoomUsers
+}
+
+// BulkImportFormat is file format of a bulk import
+type BulkImportFormat string
+
+const (
+	BulkImportFormatJSONL BulkImportFormat = "jsonl"
+	BulkImportFormatCSV   BulkImportFormat = "csv"
+)
+
+func (bif BulkImportFormat) IsValid() bool {
+	return bif == BulkImportFormatJSONL || bif == BulkImportFormatCSV
+}
+
+// BulkImportStatus is status of bulk import
+type BulkImportStatus int
+
+const (
+	BulkImportStatusProcessing BulkImportStatus = iota + 1
+	BulkImportStatusCompleted
+	BulkImportStatusFailed
+)
+
+// BulkImportRowStatus is result of a row of bulk import
+type BulkImportRowStatus int
+
+const (
+	BulkImportRowStatusSucceeded BulkImportRowStatus = iota + 1
+	BulkImportRowStatusFailed
+)
+
+// bulkImportCSVJSONColumns are the csv columns written as json literals, such as numbers, booleans, objects and arrays.
+// The other columns are strings
+var bulkImportCSVJSONColumns = map[BulkImportKind]map[string]bool{
+	BulkImportKindUsers: map[string]bool{
+		"metaData":           true,
+		"publicProfileScope": true,
+		"canBlock":           true,
+		"roles":              true,
+		"blockUsers":         true,
+	},
+	BulkImportKindRooms: map[string]bool{
+		"metaData":   true,
+		"type":       true,
+		"canLeft":    true,
+		"speechMode": true,
+		"userIds":    true,
+		"joinPolicy": true,
+	},
+	BulkImportKindRoomUsers: map[string]bool{},
+}
+
+// BulkImport is a job creating users, rooms or room users from an uploaded file.
+// ProcessedRows is the number of rows already committed, from which a failed import resumes
+type BulkImport struct {
+	ID            uint64           `json:"-" db:"id"`
+	ImportID      string           `json:"importId" db:"import_id,notnull"`
+	Kind          BulkImportKind   `json:"kind" db:"kind,notnull"`
+	Format        BulkImportFormat `json:"format" db:"format,notnull"`
+	Filename      string           `json:"-" db:"filename"`
+	Status        BulkImportStatus `json:"status" db:"status,notnull"`
+	TotalRows     int64            `json:"totalRows" db:"total_rows,notnull"`
+	ProcessedRows int64            `json:"processedRows" db:"processed_rows,notnull"`
+	SucceededRows int64            `json:"succeededRows" db:"succeeded_rows,notnull"`
+	FailedRows    int64            `json:"failedRows" db:"failed_rows,notnull"`
+	Error         string           `json:"error" db:"error"`
+	ClientID      string           `json:"clientId" db:"client_id,notnull"`
+	Created       int64            `json:"created" db:"created,notnull"`
+	Modified      int64            `json:"modified" db:"modified,notnull"`
+}
+
+func (bi *BulkImport) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		ImportID      string           `json:"importId"`
+		Kind          BulkImportKind   `json:"kind"`
+		Format        BulkImportFormat `json:"format"`
+		Status        BulkImportStatus `json:"status"`
+		TotalRows     int64            `json:"totalRows"`
+		ProcessedRows int64            `json:"processedRows"`
+		SucceededRows int64            `json:"succeededRows"`
+		FailedRows    int64            `json:"failedRows"`
+		Error         string           `json:"error,omitempty"`
+		Created       string           `json:"created"`
+		Modified      string           `json:"modified"`
+	}{
+		ImportID:      bi.ImportID,
+		Kind:          bi.Kind,
+		Format:        bi.Format,
+		Status:        bi.Status,
+		TotalRows:     bi.TotalRows,
+		ProcessedRows: bi.ProcessedRows,
+		SucceededRows: bi.SucceededRows,
+		FailedRows:    bi.FailedRows,
+		Error:         bi.Error,
+		Created:       time.Unix(bi.Created, 0).In(l).Format(time.RFC3339),
+		Modified:      time.Unix(bi.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+// IsResumable reports whether the import can be started again from the rows not processed yet
+func (bi *BulkImport) IsResumable() bool {
+	if bi.Status == BulkImportStatusFailed {
+		return true
+	}
+	return bi.Status == BulkImportStatusProcessing && bi.Modified+BulkImportStaleSeconds < time.Now().Unix()
+}
+
+// Resume marks the import as processing again
+func (bi *BulkImport) Resume() {
+	bi.Status = BulkImportStatusProcessing
+	bi.Error = ""
+	bi.Modified = time.Now().Unix()
+}
+
+// Proceed counts the results of a committed batch
+func (bi *BulkImport) Proceed(results []*BulkImportRowResult) {
+	for _, result := range results {
+		if result.Status == BulkImportRowStatusSucceeded {
+			bi.SucceededRows++
+		} else {
+			bi.FailedRows++
+		}
+		if result.Row > bi.ProcessedRows {
+			bi.ProcessedRows = result.Row
+		}
+	}
+	bi.Modified = time.Now().Unix()
+}
+
+// Complete marks the import as completed
+func (bi *BulkImport) Complete() {
+	bi.Status = BulkImportStatusCompleted
+	bi.Modified = time.Now().Unix()
+}
+
+// Fail marks the import as failed. It can be resumed
+func (bi *BulkImport) Fail(err error) {
+	bi.Status = BulkImportStatusFailed
+	bi.Error = err.Error()
+	bi.Modified = time.Now().Unix()
+}
+
+// BulkImportRowResult is the result of a row of bulk import
+type BulkImportRowResult struct {
+	ID         uint64              `json:"-" db:"id"`
+	ImportID   string              `json:"importId" db:"import_id,notnull"`
+	Row        int64               `json:"row" db:"row_index,notnull"`
+	Status     BulkImportRowStatus `json:"status" db:"status,notnull"`
+	ResourceID string              `json:"resourceId,omitempty" db:"resource_id,notnull"`
+	Reason     string              `json:"reason,omitempty" db:"reason"`
+	Created    int64               `json:"-" db:"created,notnull"`
+}
+
+// BulkImportRow is a parsed row of bulk import. Only the request of the kind is set.
+// ParseError is set instead when the row can not be read
+type BulkImportRow struct {
+	Row        int64
+	User       *CreateUserRequest
+	Room       *CreateRoomRequest
+	RoomUser   *BulkImportRoomUser
+	ParseError string
+}
+
+// BulkImportRoomUser is a row adding a user to a room
+type BulkImportRoomUser struct {
+	RoomID string `json:"roomId"`
+	UserID string `json:"userId"`
+}
+
+func (biru *BulkImportRoomUser) Validate() *ErrorResponse {
+	if biru.RoomID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "roomId",
+				Reason: "roomId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to add room user.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if biru.UserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "userId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to add room user.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (biru *BulkImportRoomUser) GenerateRoomUser() *RoomUser {
+	ru := &RoomUser{}
+	ru.RoomID = biru.RoomID
+	ru.UserID = biru.UserID
+	ru.UnreadCount = int32(0)
+	ru.Display = true
+	ru.Joined = time.Now().Unix()
+	return ru
+}
+
+// bulkImportLine is a row of an uploaded file as a json object
+type bulkImportLine struct {
+	data []byte
+	err  error
+}
+
+// ParseBulkImportRows reads the rows of an uploaded file. Rows are numbered from 1, and the header of csv is not counted.
+// A row that can not be read is returned with ParseError so that it is reported instead of failing the whole import
+func ParseBulkImportRows(kind BulkImportKind, format BulkImportFormat, data []byte) ([]*BulkImportRow, error) {
+	var lines []*bulkImportLine
+	var err error
+	if format == BulkImportFormatCSV {
+		lines, err = convertBulkImportCSVToJSONL(kind, data)
+	} else {
+		lines, err = splitBulkImportJSONL(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*BulkImportRow, len(lines))
+	for i, line := range lines {
+		row := &BulkImportRow{Row: int64(i + 1)}
+		err = line.err
+		if err == nil {
+			switch kind {
+			case BulkImportKindUsers:
+				row.User = &CreateUserRequest{}
+				err = json.Unmarshal(line.data, row.User)
+			case BulkImportKindRooms:
+				row.Room = &CreateRoomRequest{}
+				err = json.Unmarshal(line.data, row.Room)
+			case BulkImportKindRoomUsers:
+				row.RoomUser = &BulkImportRoomUser{}
+				err = json.Unmarshal(line.data, row.RoomUser)
+			}
+		}
+		if err != nil {
+			row.User = nil
+			row.Room = nil
+			row.RoomUser = nil
+			row.ParseError = fmt.Sprintf("The row can not be read. %v", err)
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+func splitBulkImportJSONL(data []byte) ([]*bulkImportLine, error) {
+	lines := make([]*bulkImportLine, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		lines = append(lines, &bulkImportLine{data: append([]byte{}, line...)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// convertBulkImportCSVToJSONL converts each csv row to a json object keyed by the header.
+// Empty cells are left out
+func convertBulkImportCSVToJSONL(kind BulkImportKind, data []byte) ([]*bulkImportLine, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return []*bulkImportLine{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+	}
+
+	jsonColumns := bulkImportCSVJSONColumns[kind]
+	lines := make([]*bulkImportLine, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		object := make(map[string]json.RawMessage, len(header))
+		for i, value := range record {
+			if i >= len(header) || value == "" {
+				continue
+			}
+			if jsonColumns[header[i]] {
+				object[header[i]] = json.RawMessage(value)
+			} else {
+				quoted, _ := json.Marshal(value)
+				object[header[i]] = json.RawMessage(quoted)
+			}
+		}
+
+		line := &bulkImportLine{}
+		line.data, line.err = json.Marshal(object)
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+type CreateBulkImportRequest struct {
+	Kind     BulkImportKind   `json:"kind"`
+	Format   BulkImportFormat `json:"format"`
+	Data     []byte           `json:"-"`
+	ClientID string           `json:"-"`
+}
+
+func (cbir *CreateBulkImportRequest) Validate() *ErrorResponse {
+	if !cbir.Kind.IsValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "kind",
+				Reason: "kind is incorrect. Available values are users, rooms and roomUsers.",
+			},
+		}
+		return NewErrorResponse("Failed to create bulk import.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if !cbir.Format.IsValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "format",
+				Reason: "format is incorrect. Available values are jsonl and csv.",
+			},
+		}
+		return NewErrorResponse("Failed to create bulk import.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if len(cbir.Data) == 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "body",
+				Reason: "The file to import is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to create bulk import.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (cbir *CreateBulkImportRequest) GenerateBulkImport() *BulkImport {
+	bi := &BulkImport{}
+	bi.ImportID = utils.GenerateUUID()
+	bi.Kind = cbir.Kind
+	bi.Format = cbir.Format
+	bi.Status = BulkImportStatusProcessing
+	bi.ClientID = cbir.ClientID
+
+	nowTimestamp := time.Now().Unix()
+	bi.Created = nowTimestamp
+	bi.Modified = nowTimestamp
+
+	return bi
+}
+
+// BulkImportFilename returns the storage filename of the uploaded file of the import
+func BulkImportFilename(importID string, format BulkImportFormat) string {
+	return fmt.Sprintf("import-%s.%s", importID, format)
+}
+
+// BulkImportBatch is rows of a bulk import inserted in a transaction with their results
+type BulkImportBatch struct {
+	Users       []*User
+	BlockUsers  []*BlockUser
+	UserRoles   []*UserRole
+	Rooms       []*Room
+	DirectRooms []*DirectRoom
+	RoomUsers   []*RoomUser
+	Results     []*BulkImportRowResult
+}
+
+type RetrieveBulkImportRowResultsRequest struct {
+	ImportID   string `json:"importId"`
+	FailedOnly bool   `json:"failedOnly"`
+	Limit      int32  `json:"limit"`
+	Offset     int32  `json:"offset"`
+}
+
+type BulkImportRowResultsResponse struct {
+	Results []*BulkImportRowResult `json:"results"`
+	Limit   int32                  `json:"limit"`
+	Offset  int32                  `json:"offset"`
+}
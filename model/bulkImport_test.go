@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+)
+
+const (
+	TestModelParseBulkImportRowsJSONL = "[model] ParseBulkImportRows jsonl test"
+	TestModelParseBulkImportRowsCSV   = "[model] ParseBulkImportRows csv test"
+)
+
+func TestBulkImport(t *testing.T) {
+	t.Run(TestModelParseBulkImportRowsJSONL, func(t *testing.T) {
+		data := []byte(`{"roomId":"model-room-id-0001","userId":"model-user-id-0001"}
+
+{"roomId":"model-room-id-0001",
+{"roomId":"model-room-id-0002","userId":"model-user-id-0002"}
+`)
+		rows, err := ParseBulkImportRows(BulkImportKindRoomUsers, BulkImportFormatJSONL, data)
+		if err != nil {
+			t.Fatalf("Failed to %s. %v", TestModelParseBulkImportRowsJSONL, err)
+		}
+		if len(rows) != 3 {
+			t.Fatalf("Failed to %s. Expected rows count to be 3, but it was %d", TestModelParseBulkImportRowsJSONL, len(rows))
+		}
+		if rows[0].RoomUser == nil || rows[0].RoomUser.UserID != "model-user-id-0001" {
+			t.Fatalf("Failed to %s. Expected the first row to be read", TestModelParseBulkImportRowsJSONL)
+		}
+		if rows[1].ParseError == "" || rows[1].RoomUser != nil {
+			t.Fatalf("Failed to %s. Expected the second row to be a parse error", TestModelParseBulkImportRowsJSONL)
+		}
+		if rows[2].Row != 3 || rows[2].RoomUser == nil || rows[2].RoomUser.RoomID != "model-room-id-0002" {
+			t.Fatalf("Failed to %s. Expected the third row to be read", TestModelParseBulkImportRowsJSONL)
+		}
+	})
+
+	t.Run(TestModelParseBulkImportRowsCSV, func(t *testing.T) {
+		data := []byte(`userId,name,metaData
+model-user-id-0001,"Name, 1","{""key"":""value""}"
+model-user-id-0002,Name 2,
+model-user-id-0003,Name 3,{invalid
+`)
+		rows, err := ParseBulkImportRows(BulkImportKindUsers, BulkImportFormatCSV, data)
+		if err != nil {
+			t.Fatalf("Failed to %s. %v", TestModelParseBulkImportRowsCSV, err)
+		}
+		if len(rows) != 3 {
+			t.Fatalf("Failed to %s. Expected rows count to be 3, but it was %d", TestModelParseBulkImportRowsCSV, len(rows))
+		}
+		if rows[0].User == nil || *rows[0].User.UserID != "model-user-id-0001" || *rows[0].User.Name != "Name, 1" {
+			t.Fatalf("Failed to %s. Expected the first row to be read", TestModelParseBulkImportRowsCSV)
+		}
+		if rows[0].User.MetaData.String() != `{"key":"value"}` {
+			t.Fatalf("Failed to %s. Expected metaData to be read as json, but it was %s", TestModelParseBulkImportRowsCSV, rows[0].User.MetaData.String())
+		}
+		if rows[1].User == nil || rows[1].User.MetaData != nil {
+			t.Fatalf("Failed to %s. Expected the empty metaData of the second row to be left out", TestModelParseBulkImportRowsCSV)
+		}
+		if rows[2].ParseError == "" {
+			t.Fatalf("Failed to %s. Expected the third row to be a parse error", TestModelParseBulkImportRowsCSV)
+		}
+	})
+}
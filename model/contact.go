@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// ContactMode is how the contacts of a user are made up in the workspace
+type ContactMode string
+
+const (
+	// ContactModeImplicit makes the users sharing a room contacts
+	ContactModeImplicit ContactMode = "implicit"
+	// ContactModeExplicit makes only the users who accepted a friend request contacts
+	ContactModeExplicit ContactMode = "explicit"
+	// ContactModeBoth makes both of them contacts
+	ContactModeBoth ContactMode = "both"
+)
+
+func (cm ContactMode) IsValid() bool {
+	return cm == ContactModeImplicit || cm == ContactModeExplicit || cm == ContactModeBoth
+}
+
+// IncludesImplicit reports whether the users sharing a room are contacts
+func (cm ContactMode) IncludesImplicit() bool {
+	return cm == ContactModeImplicit || cm == ContactModeBoth
+}
+
+// IncludesExplicit reports whether friend requests are available
+func (cm ContactMode) IncludesExplicit() bool {
+	return cm == ContactModeExplicit || cm == ContactModeBoth
+}
+
+// ContactMode returns the contact mode of the workspace.
+// It falls back to implicit when the setting does not have a valid one
+func (s *Setting) ContactMode() ContactMode {
+	if s == nil || len(s.Values) == 0 {
+		return ContactModeImplicit
+	}
+
+	var values struct {
+		ContactMode ContactMode `json:"contactMode"`
+	}
+	err := json.Unmarshal(s.Values, &values)
+	if err != nil || !values.ContactMode.IsValid() {
+		return ContactModeImplicit
+	}
+
+	return values.ContactMode
+}
+
+type Contact struct {
+	UserID        string `json:"userId" db:"user_id,notnull"`
+	ContactUserID string `json:"contactUserId" db:"contact_user_id,notnull"`
+	Created       int64  `json:"created" db:"created,notnull"`
+}
+
+type DeleteContactRequest struct {
+	UserID        string `json:"userId"`
+	ContactUserID string `json:"contactUserId"`
+}
+
+func (dcr *DeleteContactRequest) Validate() *ErrorResponse {
+	if dcr.ContactUserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "contactUserId",
+				Reason: "contactUserId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to delete contact.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
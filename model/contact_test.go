@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+)
+
+const (
+	TestModelSettingContactMode            = "[model] Setting.ContactMode test"
+	TestModelFriendRequestIsRepliable      = "[model] FriendRequest.IsRepliableBy test"
+	TestModelFriendRequestGenerateContacts = "[model] FriendRequest.GenerateContacts test"
+)
+
+func TestContact(t *testing.T) {
+	t.Run(TestModelSettingContactMode, func(t *testing.T) {
+		var setting *Setting
+		if setting.ContactMode() != ContactModeImplicit {
+			t.Fatalf("Failed to %s. Expected contact mode of nil setting to be implicit, but it was %s", TestModelSettingContactMode, setting.ContactMode())
+		}
+
+		setting = &Setting{Values: []byte(`{"contactMode":"explicit"}`)}
+		if setting.ContactMode() != ContactModeExplicit {
+			t.Fatalf("Failed to %s. Expected contact mode to be explicit, but it was %s", TestModelSettingContactMode, setting.ContactMode())
+		}
+
+		setting = &Setting{Values: []byte(`{"contactMode":"unknown"}`)}
+		if setting.ContactMode() != ContactModeImplicit {
+			t.Fatalf("Failed to %s. Expected contact mode to be implicit, but it was %s", TestModelSettingContactMode, setting.ContactMode())
+		}
+
+		if !ContactModeBoth.IncludesImplicit() || !ContactModeBoth.IncludesExplicit() {
+			t.Fatalf("Failed to %s. Expected both to include implicit and explicit contacts", TestModelSettingContactMode)
+		}
+	})
+
+	t.Run(TestModelFriendRequestIsRepliable, func(t *testing.T) {
+		fr := &FriendRequest{
+			RequesterUserID: "model-user-id-0001",
+			AddresseeUserID: "model-user-id-0002",
+		}
+		if !fr.IsRepliableBy("model-user-id-0002", FriendRequestStatusAccepted) {
+			t.Fatalf("Failed to %s. Expected addressee to be able to accept, but it was not", TestModelFriendRequestIsRepliable)
+		}
+		if fr.IsRepliableBy("model-user-id-0001", FriendRequestStatusAccepted) {
+			t.Fatalf("Failed to %s. Expected requester not to be able to accept, but it was", TestModelFriendRequestIsRepliable)
+		}
+		if !fr.IsRepliableBy("model-user-id-0001", FriendRequestStatusCanceled) {
+			t.Fatalf("Failed to %s. Expected requester to be able to cancel, but it was not", TestModelFriendRequestIsRepliable)
+		}
+		if fr.IsRepliableBy("model-user-id-0002", FriendRequestStatusCanceled) {
+			t.Fatalf("Failed to %s. Expected addressee not to be able to cancel, but it was", TestModelFriendRequestIsRepliable)
+		}
+	})
+
+	t.Run(TestModelFriendRequestGenerateContacts, func(t *testing.T) {
+		fr := &FriendRequest{
+			RequesterUserID: "model-user-id-0001",
+			AddresseeUserID: "model-user-id-0002",
+		}
+		contacts := fr.GenerateContacts()
+		if len(contacts) != 2 {
+			t.Fatalf("Failed to %s. Expected contacts count to be 2, but it was %d", TestModelFriendRequestGenerateContacts, len(contacts))
+		}
+		if contacts[0].ContactUserID != contacts[1].UserID || contacts[1].ContactUserID != contacts[0].UserID {
+			t.Fatalf("Failed to %s. Expected contacts to be mutual, but they were not", TestModelFriendRequestGenerateContacts)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"net/http"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// DirectRoom maps a pair of users to their 1-on-1 room. The pair is stored in sorted order so that it is unique regardless of who created the room
+type DirectRoom struct {
+	ID           uint64 `json:"-" db:"id"`
+	RoomID       string `json:"roomId" db:"room_id,notnull"`
+	FirstUserID  string `json:"firstUserId" db:"first_user_id,notnull"`
+	SecondUserID string `json:"secondUserId" db:"second_user_id,notnull"`
+}
+
+// NewDirectRoom returns the direct room of the pair of users
+func NewDirectRoom(roomID, userID, otherUserID string) *DirectRoom {
+	dr := &DirectRoom{}
+	dr.RoomID = roomID
+	dr.FirstUserID, dr.SecondUserID = SortDirectRoomUserIDs(userID, otherUserID)
+	return dr
+}
+
+// SortDirectRoomUserIDs returns the pair of users in the order stored in a direct room
+func SortDirectRoomUserIDs(userID, otherUserID string) (string, string) {
+	if userID < otherUserID {
+		return userID, otherUserID
+	}
+	return otherUserID, userID
+}
+
+type GetOrCreateDirectRoomRequest struct {
+	UserID      string `json:"userId"`
+	OtherUserID string `json:"otherUserId"`
+}
+
+func (gcdrr *GetOrCreateDirectRoomRequest) Validate() *ErrorResponse {
+	if gcdrr.UserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "userId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to get or create direct message room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if gcdrr.OtherUserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "otherUserId",
+				Reason: "otherUserId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to get or create direct message room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if gcdrr.UserID == gcdrr.OtherUserID {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "otherUserId",
+				Reason: "otherUserId can not be own UserId.",
+			},
+		}
+		return NewErrorResponse("Failed to get or create direct message room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (gcdrr *GetOrCreateDirectRoomRequest) GenerateCreateRoomRequest() *CreateRoomRequest {
+	roomType := scpb.RoomType_OneOnOneRoom
+
+	req := &CreateRoomRequest{}
+	req.UserID = &gcdrr.UserID
+	req.Type = &roomType
+	req.UserIDs = []string{gcdrr.OtherUserID}
+	return req
+}
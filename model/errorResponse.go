@@ -7,6 +7,7 @@ import (
 type errorResponseOptions struct {
 	err           error
 	invalidParams []*scpb.InvalidParam
+	retryAfter    int64
 }
 
 type ErrorResponseOption func(*errorResponseOptions)
@@ -23,12 +24,21 @@ func WithInvalidParams(invalidParams []*scpb.InvalidParam) ErrorResponseOption {
 	}
 }
 
+// WithRetryAfter sets seconds that the client should wait before retrying the request
+func WithRetryAfter(retryAfter int64) ErrorResponseOption {
+	return func(ops *errorResponseOptions) {
+		ops.retryAfter = retryAfter
+	}
+}
+
 type ErrorResponse struct {
 	scpb.ErrorResponse
 	// Status is a HTTP status
 	Status int `json:"-"`
 	// Error is a error struct
 	Error error `json:"-"`
+	// RetryAfter is seconds that the client should wait before retrying the request
+	RetryAfter int64 `json:"-"`
 }
 
 // NewErrorResponse creates a new error response
@@ -43,5 +53,6 @@ func NewErrorResponse(message string, status int, opts ...ErrorResponseOption) *
 	errRes.Status = status
 	errRes.InvalidParams = opt.invalidParams
 	errRes.Error = opt.err
+	errRes.RetryAfter = opt.retryAfter
 	return errRes
 }
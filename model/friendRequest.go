@@ -0,0 +1,166 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// FriendRequestStatus is status of friend request
+type FriendRequestStatus int
+
+const (
+	FriendRequestStatusPending FriendRequestStatus = iota + 1
+	FriendRequestStatusAccepted
+	FriendRequestStatusRejected
+	FriendRequestStatusCanceled
+)
+
+// FriendRequestDirection selects the friend requests of a user by who sent them
+type FriendRequestDirection string
+
+const (
+	FriendRequestDirectionIncoming FriendRequestDirection = "incoming"
+	FriendRequestDirectionOutgoing FriendRequestDirection = "outgoing"
+)
+
+type FriendRequest struct {
+	ID              uint64              `json:"-" db:"id"`
+	FriendRequestID string              `json:"friendRequestId" db:"friend_request_id,notnull"`
+	RequesterUserID string              `json:"requesterUserId" db:"requester_user_id,notnull"`
+	AddresseeUserID string              `json:"addresseeUserId" db:"addressee_user_id,notnull"`
+	Message         string              `json:"message" db:"message"`
+	Status          FriendRequestStatus `json:"status" db:"status,notnull"`
+	Created         int64               `json:"created" db:"created,notnull"`
+	Modified        int64               `json:"modified" db:"modified,notnull"`
+	Deleted         int64               `json:"-" db:"deleted,notnull"`
+}
+
+func (fr *FriendRequest) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		FriendRequestID string              `json:"friendRequestId"`
+		RequesterUserID string              `json:"requesterUserId"`
+		AddresseeUserID string              `json:"addresseeUserId"`
+		Message         string              `json:"message"`
+		Status          FriendRequestStatus `json:"status"`
+		Created         string              `json:"created"`
+		Modified        string              `json:"modified"`
+	}{
+		FriendRequestID: fr.FriendRequestID,
+		RequesterUserID: fr.RequesterUserID,
+		AddresseeUserID: fr.AddresseeUserID,
+		Message:         fr.Message,
+		Status:          fr.Status,
+		Created:         time.Unix(fr.Created, 0).In(l).Format(time.RFC3339),
+		Modified:        time.Unix(fr.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+// Reply sets the reply of the addressee, or the cancellation of the requester
+func (fr *FriendRequest) Reply(status FriendRequestStatus) {
+	fr.Status = status
+	fr.Modified = time.Now().Unix()
+}
+
+// IsRepliableBy reports whether the user can give the status to the friend request.
+// Only the addressee accepts or rejects it, and only the requester cancels it
+func (fr *FriendRequest) IsRepliableBy(userID string, status FriendRequestStatus) bool {
+	if status == FriendRequestStatusCanceled {
+		return fr.RequesterUserID == userID
+	}
+	return fr.AddresseeUserID == userID
+}
+
+// GenerateContacts makes the contacts of both users of the accepted friend request
+func (fr *FriendRequest) GenerateContacts() []*Contact {
+	nowTimestamp := time.Now().Unix()
+	return []*Contact{
+		&Contact{
+			UserID:        fr.RequesterUserID,
+			ContactUserID: fr.AddresseeUserID,
+			Created:       nowTimestamp,
+		},
+		&Contact{
+			UserID:        fr.AddresseeUserID,
+			ContactUserID: fr.RequesterUserID,
+			Created:       nowTimestamp,
+		},
+	}
+}
+
+type CreateFriendRequestRequest struct {
+	UserID          string `json:"userId"`
+	AddresseeUserID string `json:"addresseeUserId"`
+	Message         string `json:"message,omitempty"`
+}
+
+func (cfrr *CreateFriendRequestRequest) Validate() *ErrorResponse {
+	if cfrr.AddresseeUserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "addresseeUserId",
+				Reason: "addresseeUserId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to create friend request.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if cfrr.AddresseeUserID == cfrr.UserID {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "addresseeUserId",
+				Reason: "addresseeUserId can not be own UserId.",
+			},
+		}
+		return NewErrorResponse("Failed to create friend request.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (cfrr *CreateFriendRequestRequest) GenerateFriendRequest() *FriendRequest {
+	nowTimestamp := time.Now().Unix()
+
+	fr := &FriendRequest{}
+	fr.FriendRequestID = utils.GenerateUUID()
+	fr.RequesterUserID = cfrr.UserID
+	fr.AddresseeUserID = cfrr.AddresseeUserID
+	fr.Message = cfrr.Message
+	fr.Status = FriendRequestStatusPending
+	fr.Created = nowTimestamp
+	fr.Modified = nowTimestamp
+	return fr
+}
+
+type RetrieveFriendRequestsRequest struct {
+	UserID    string                 `json:"userId"`
+	Direction FriendRequestDirection `json:"direction"`
+}
+
+func (rfrr *RetrieveFriendRequestsRequest) Validate() *ErrorResponse {
+	if rfrr.Direction != FriendRequestDirectionIncoming && rfrr.Direction != FriendRequestDirectionOutgoing {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "direction",
+				Reason: "direction is incorrect. Available values are incoming and outgoing.",
+			},
+		}
+		return NewErrorResponse("Failed to retrieve friend requests.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+type FriendRequestsResponse struct {
+	FriendRequests []*FriendRequest `json:"friendRequests"`
+}
+
+type ReplyFriendRequestRequest struct {
+	UserID          string              `json:"userId"`
+	FriendRequestID string              `json:"friendRequestId"`
+	Status          FriendRequestStatus `json:"-"`
+}
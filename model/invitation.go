@@ -0,0 +1,163 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	// InvitationExpiration is seconds until an invitation expires
+	InvitationExpiration = 7 * 24 * 60 * 60
+)
+
+// InvitationStatus is status of invitation
+type InvitationStatus int
+
+const (
+	InvitationStatusPending InvitationStatus = iota + 1
+	InvitationStatusAccepted
+	InvitationStatusDeclined
+)
+
+type Invitation struct {
+	ID            uint64           `json:"-" db:"id"`
+	InvitationID  string           `json:"invitationId" db:"invitation_id,notnull"`
+	RoomID        string           `json:"roomId" db:"room_id,notnull"`
+	InviterUserID string           `json:"inviterUserId" db:"inviter_user_id,notnull"`
+	InviteeUserID string           `json:"inviteeUserId" db:"invitee_user_id,notnull"`
+	Message       string           `json:"message" db:"message"`
+	Status        InvitationStatus `json:"status" db:"status,notnull"`
+	Expired       int64            `json:"expired" db:"expired,notnull"`
+	Created       int64            `json:"created" db:"created,notnull"`
+	Modified      int64            `json:"modified" db:"modified,notnull"`
+	Deleted       int64            `json:"-" db:"deleted,notnull"`
+}
+
+func (i *Invitation) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		InvitationID  string           `json:"invitationId"`
+		RoomID        string           `json:"roomId"`
+		InviterUserID string           `json:"inviterUserId"`
+		InviteeUserID string           `json:"inviteeUserId"`
+		Message       string           `json:"message"`
+		Status        InvitationStatus `json:"status"`
+		Expired       string           `json:"expired"`
+		Created       string           `json:"created"`
+		Modified      string           `json:"modified"`
+	}{
+		InvitationID:  i.InvitationID,
+		RoomID:        i.RoomID,
+		InviterUserID: i.InviterUserID,
+		InviteeUserID: i.InviteeUserID,
+		Message:       i.Message,
+		Status:        i.Status,
+		Expired:       time.Unix(i.Expired, 0).In(l).Format(time.RFC3339),
+		Created:       time.Unix(i.Created, 0).In(l).Format(time.RFC3339),
+		Modified:      time.Unix(i.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+// IsExpired reports whether the invitation has expired
+func (i *Invitation) IsExpired() bool {
+	return i.Expired < time.Now().Unix()
+}
+
+// Reply sets the reply of the invitee
+func (i *Invitation) Reply(status InvitationStatus) {
+	i.Status = status
+	i.Modified = time.Now().Unix()
+}
+
+type CreateInvitationsRequest struct {
+	RoomID        string   `json:"roomId"`
+	InviterUserID string   `json:"inviterUserId"`
+	UserIDs       []string `json:"userIds"`
+	Message       string   `json:"message,omitempty"`
+	Room          *Room    `json:"-"`
+}
+
+func (cir *CreateInvitationsRequest) Validate() *ErrorResponse {
+	if cir.InviterUserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "inviterUserId",
+				Reason: "inviterUserId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to create invitations.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if len(cir.UserIDs) == 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userIds",
+				Reason: "userIds is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to create invitations.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if cir.Room != nil && cir.Room.Type == scpb.RoomType_OneOnOneRoom {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "roomId",
+				Reason: "In case of 1-on-1 room type, users can not be invited.",
+			},
+		}
+		return NewErrorResponse("Failed to create invitations.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	for _, userID := range cir.UserIDs {
+		if userID == cir.InviterUserID {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "userIds",
+					Reason: "userIds can not include own UserId.",
+				},
+			}
+			return NewErrorResponse("Failed to create invitations.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+		}
+	}
+
+	return nil
+}
+
+func (cir *CreateInvitationsRequest) GenerateInvitations() []*Invitation {
+	userIDs := utils.RemoveDuplicateString(cir.UserIDs)
+	invitations := make([]*Invitation, len(userIDs))
+
+	nowTimestamp := time.Now().Unix()
+	for i, userID := range userIDs {
+		invitation := &Invitation{}
+		invitation.InvitationID = utils.GenerateUUID()
+		invitation.RoomID = cir.RoomID
+		invitation.InviterUserID = cir.InviterUserID
+		invitation.InviteeUserID = userID
+		invitation.Message = cir.Message
+		invitation.Status = InvitationStatusPending
+		invitation.Expired = nowTimestamp + InvitationExpiration
+		invitation.Created = nowTimestamp
+		invitation.Modified = nowTimestamp
+		invitations[i] = invitation
+	}
+	return invitations
+}
+
+type RetrieveUserInvitationsRequest struct {
+	UserID string `json:"userId"`
+}
+
+type InvitationsResponse struct {
+	Invitations []*Invitation `json:"invitations"`
+}
+
+type ReplyInvitationRequest struct {
+	UserID       string           `json:"userId"`
+	InvitationID string           `json:"invitationId"`
+	Status       InvitationStatus `json:"-"`
+}
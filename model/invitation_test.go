@@ -0,0 +1,77 @@
+package model
+
+import (
+	"testing"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	TestModelCreateInvitationsRequest = "[model] CreateInvitationsRequest test"
+)
+
+func TestInvitation(t *testing.T) {
+	t.Run(TestModelCreateInvitationsRequest, func(t *testing.T) {
+		req := &CreateInvitationsRequest{}
+		req.RoomID = "model-room-id-0001"
+		errRes := req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestModelCreateInvitationsRequest)
+		}
+		if errRes.InvalidParams[0].Name != "inviterUserId" {
+			t.Fatalf("Failed to %s. Expected errRes.InvalidParams[0].Name is \"inviterUserId\", but it was %s", TestModelCreateInvitationsRequest, errRes.InvalidParams[0].Name)
+		}
+
+		req.InviterUserID = "model-user-id-0001"
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestModelCreateInvitationsRequest)
+		}
+		if errRes.InvalidParams[0].Name != "userIds" {
+			t.Fatalf("Failed to %s. Expected errRes.InvalidParams[0].Name is \"userIds\", but it was %s", TestModelCreateInvitationsRequest, errRes.InvalidParams[0].Name)
+		}
+
+		room := &Room{}
+		room.RoomID = "model-room-id-0001"
+		room.Type = scpb.RoomType_OneOnOneRoom
+		req.Room = room
+		req.UserIDs = []string{"model-user-id-0002"}
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestModelCreateInvitationsRequest)
+		}
+		if errRes.InvalidParams[0].Name != "roomId" {
+			t.Fatalf("Failed to %s. Expected errRes.InvalidParams[0].Name is \"roomId\", but it was %s", TestModelCreateInvitationsRequest, errRes.InvalidParams[0].Name)
+		}
+
+		req.Room.Type = scpb.RoomType_PrivateRoom
+		req.UserIDs = []string{"model-user-id-0001", "model-user-id-0002"}
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestModelCreateInvitationsRequest)
+		}
+		if errRes.InvalidParams[0].Name != "userIds" {
+			t.Fatalf("Failed to %s. Expected errRes.InvalidParams[0].Name is \"userIds\", but it was %s", TestModelCreateInvitationsRequest, errRes.InvalidParams[0].Name)
+		}
+
+		req.UserIDs = []string{"model-user-id-0002", "model-user-id-0003", "model-user-id-0002"}
+		errRes = req.Validate()
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil. %s is invalid", TestModelCreateInvitationsRequest, errRes.InvalidParams[0].Name)
+		}
+
+		invitations := req.GenerateInvitations()
+		if len(invitations) != 2 {
+			t.Fatalf("Failed to %s. Expected invitations count to be 2, but it was %d", TestModelCreateInvitationsRequest, len(invitations))
+		}
+		if invitations[0].InviteeUserID != "model-user-id-0002" {
+			t.Fatalf("Failed to %s. Expected invitations[0].InviteeUserID to be \"model-user-id-0002\", but it was %s", TestModelCreateInvitationsRequest, invitations[0].InviteeUserID)
+		}
+		if invitations[0].Status != InvitationStatusPending {
+			t.Fatalf("Failed to %s. Expected invitations[0].Status to be %d, but it was %d", TestModelCreateInvitationsRequest, InvitationStatusPending, invitations[0].Status)
+		}
+		if invitations[0].IsExpired() {
+			t.Fatalf("Failed to %s. Expected invitations[0].IsExpired() to be false, but it was true", TestModelCreateInvitationsRequest)
+		}
+	})
+}
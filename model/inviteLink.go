@@ -0,0 +1,199 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// InviteLink is a shareable token that adds the redeeming user to a room
+type InviteLink struct {
+	ID            uint64         `json:"-" db:"id"`
+	Token         string         `json:"token" db:"token,notnull"`
+	RoomID        string         `json:"roomId" db:"room_id,notnull"`
+	CreatorUserID string         `json:"creatorUserId" db:"creator_user_id,notnull"`
+	MemberRole    RoomMemberRole `json:"memberRole" db:"member_role,notnull"`
+	MaxUses       int32          `json:"maxUses" db:"max_uses,notnull"`
+	Uses          int32          `json:"uses" db:"uses,notnull"`
+	Expired       int64          `json:"expired,omitempty" db:"expired,notnull"`
+	Revoked       int64          `json:"revoked,omitempty" db:"revoked,notnull"`
+	Created       int64          `json:"created" db:"created,notnull"`
+	Modified      int64          `json:"modified" db:"modified,notnull"`
+	Deleted       int64          `json:"-" db:"deleted,notnull"`
+}
+
+func (il *InviteLink) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	expired := ""
+	if il.Expired != 0 {
+		expired = time.Unix(il.Expired, 0).In(l).Format(time.RFC3339)
+	}
+	revoked := ""
+	if il.Revoked != 0 {
+		revoked = time.Unix(il.Revoked, 0).In(l).Format(time.RFC3339)
+	}
+	return json.Marshal(&struct {
+		Token         string         `json:"token"`
+		RoomID        string         `json:"roomId"`
+		CreatorUserID string         `json:"creatorUserId"`
+		MemberRole    RoomMemberRole `json:"memberRole"`
+		MaxUses       int32          `json:"maxUses"`
+		Uses          int32          `json:"uses"`
+		Expired       string         `json:"expired,omitempty"`
+		Revoked       string         `json:"revoked,omitempty"`
+		Created       string         `json:"created"`
+		Modified      string         `json:"modified"`
+	}{
+		Token:         il.Token,
+		RoomID:        il.RoomID,
+		CreatorUserID: il.CreatorUserID,
+		MemberRole:    il.MemberRole,
+		MaxUses:       il.MaxUses,
+		Uses:          il.Uses,
+		Expired:       expired,
+		Revoked:       revoked,
+		Created:       time.Unix(il.Created, 0).In(l).Format(time.RFC3339),
+		Modified:      time.Unix(il.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+// IsExpired reports whether the invite link has expired. A link without expiry never expires
+func (il *InviteLink) IsExpired() bool {
+	return il.Expired != 0 && il.Expired < time.Now().Unix()
+}
+
+// IsRevoked reports whether the invite link has been revoked
+func (il *InviteLink) IsRevoked() bool {
+	return il.Revoked != 0
+}
+
+// IsExhausted reports whether the invite link has reached its maximum number of uses
+func (il *InviteLink) IsExhausted() bool {
+	return il.MaxUses != 0 && il.Uses >= il.MaxUses
+}
+
+// Revoke revokes the invite link
+func (il *InviteLink) Revoke() {
+	nowTimestamp := time.Now().Unix()
+	il.Revoked = nowTimestamp
+	il.Modified = nowTimestamp
+}
+
+// InviteLinkUse records a user who joined a room through an invite link
+type InviteLinkUse struct {
+	ID      uint64 `json:"-" db:"id"`
+	Token   string `json:"token" db:"token,notnull"`
+	RoomID  string `json:"roomId" db:"room_id,notnull"`
+	UserID  string `json:"userId" db:"user_id,notnull"`
+	Created int64  `json:"created" db:"created,notnull"`
+}
+
+type CreateInviteLinkRequest struct {
+	RoomID        string          `json:"roomId"`
+	CreatorUserID string          `json:"creatorUserId"`
+	MemberRole    *RoomMemberRole `json:"memberRole,omitempty"`
+	MaxUses       int32           `json:"maxUses,omitempty"`
+	ExpiresIn     int64           `json:"expiresIn,omitempty"`
+}
+
+func (cilr *CreateInviteLinkRequest) Validate() *ErrorResponse {
+	if cilr.MaxUses < 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "maxUses",
+				Reason: "maxUses must be 0 or more. 0 means unlimited.",
+			},
+		}
+		return NewErrorResponse("Failed to create invite link.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if cilr.ExpiresIn < 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "expiresIn",
+				Reason: "expiresIn must be 0 or more. 0 means no expiry.",
+			},
+		}
+		return NewErrorResponse("Failed to create invite link.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if cilr.MemberRole != nil && !cilr.MemberRole.isValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "memberRole",
+				Reason: "memberRole is incorrect.",
+			},
+		}
+		return NewErrorResponse("Failed to create invite link.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if cilr.MemberRole != nil && *cilr.MemberRole == RoomMemberRoleOwner {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "memberRole",
+				Reason: "memberRole can not be owner. A room has only one owner.",
+			},
+		}
+		return NewErrorResponse("Failed to create invite link.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (cilr *CreateInviteLinkRequest) GenerateInviteLink() *InviteLink {
+	nowTimestamp := time.Now().Unix()
+
+	il := &InviteLink{}
+	il.Token = utils.GenerateClientID()
+	il.RoomID = cilr.RoomID
+	il.CreatorUserID = cilr.CreatorUserID
+	if cilr.MemberRole != nil {
+		il.MemberRole = *cilr.MemberRole
+	}
+	il.MaxUses = cilr.MaxUses
+	if cilr.ExpiresIn != 0 {
+		il.Expired = nowTimestamp + cilr.ExpiresIn
+	}
+	il.Created = nowTimestamp
+	il.Modified = nowTimestamp
+	return il
+}
+
+type RetrieveInviteLinksRequest struct {
+	RoomID string `json:"roomId"`
+}
+
+type InviteLinksResponse struct {
+	InviteLinks []*InviteLink `json:"inviteLinks"`
+}
+
+type RevokeInviteLinkRequest struct {
+	RoomID string `json:"roomId"`
+	Token  string `json:"token"`
+}
+
+type RedeemInviteLinkRequest struct {
+	Token  string `json:"token"`
+	UserID string `json:"userId"`
+}
+
+func (rilr *RedeemInviteLinkRequest) GenerateInviteLinkUse(roomID string) *InviteLinkUse {
+	ilu := &InviteLinkUse{}
+	ilu.Token = rilr.Token
+	ilu.RoomID = roomID
+	ilu.UserID = rilr.UserID
+	ilu.Created = time.Now().Unix()
+	return ilu
+}
+
+type RetrieveInviteLinkUsesRequest struct {
+	RoomID string `json:"roomId"`
+	Token  string `json:"token"`
+}
+
+type InviteLinkUsesResponse struct {
+	InviteLinkUses []*InviteLinkUse `json:"inviteLinkUses"`
+}
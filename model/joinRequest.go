@@ -0,0 +1,105 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// JoinRequestStatus is status of join request
+type JoinRequestStatus int
+
+const (
+	JoinRequestStatusPending JoinRequestStatus = iota + 1
+	JoinRequestStatusApproved
+	JoinRequestStatusRejected
+)
+
+type JoinRequest struct {
+	ID            uint64            `json:"-" db:"id"`
+	JoinRequestID string            `json:"joinRequestId" db:"join_request_id,notnull"`
+	RoomID        string            `json:"roomId" db:"room_id,notnull"`
+	UserID        string            `json:"userId" db:"user_id,notnull"`
+	Status        JoinRequestStatus `json:"status" db:"status,notnull"`
+	Created       int64             `json:"created" db:"created,notnull"`
+	Modified      int64             `json:"modified" db:"modified,notnull"`
+	Deleted       int64             `json:"-" db:"deleted,notnull"`
+}
+
+func (jr *JoinRequest) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		JoinRequestID string            `json:"joinRequestId"`
+		RoomID        string            `json:"roomId"`
+		UserID        string            `json:"userId"`
+		Status        JoinRequestStatus `json:"status"`
+		Created       string            `json:"created"`
+		Modified      string            `json:"modified"`
+	}{
+		JoinRequestID: jr.JoinRequestID,
+		RoomID:        jr.RoomID,
+		UserID:        jr.UserID,
+		Status:        jr.Status,
+		Created:       time.Unix(jr.Created, 0).In(l).Format(time.RFC3339),
+		Modified:      time.Unix(jr.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+// Reply sets the reply of the room administrator
+func (jr *JoinRequest) Reply(status JoinRequestStatus) {
+	jr.Status = status
+	jr.Modified = time.Now().Unix()
+}
+
+type JoinRoomRequest struct {
+	RoomID string `json:"roomId"`
+	UserID string `json:"userId"`
+}
+
+func (jrr *JoinRoomRequest) Validate() *ErrorResponse {
+	if jrr.UserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "userId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to join room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (jrr *JoinRoomRequest) GenerateJoinRequest() *JoinRequest {
+	nowTimestamp := time.Now().Unix()
+	jr := &JoinRequest{}
+	jr.JoinRequestID = utils.GenerateUUID()
+	jr.RoomID = jrr.RoomID
+	jr.UserID = jrr.UserID
+	jr.Status = JoinRequestStatusPending
+	jr.Created = nowTimestamp
+	jr.Modified = nowTimestamp
+	return jr
+}
+
+type LeaveRoomRequest struct {
+	RoomID string `json:"roomId"`
+	UserID string `json:"userId"`
+}
+
+type RetrieveJoinRequestsRequest struct {
+	RoomID string `json:"roomId"`
+}
+
+type JoinRequestsResponse struct {
+	JoinRequests []*JoinRequest `json:"joinRequests"`
+}
+
+type ReplyJoinRequestRequest struct {
+	RoomID        string            `json:"roomId"`
+	JoinRequestID string            `json:"joinRequestId"`
+	Status        JoinRequestStatus `json:"-"`
+}
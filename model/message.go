@@ -2,7 +2,9 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/swagchat/chat-api/config"
@@ -51,6 +53,34 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// Mentions reports whether the text message mentions the user as "@userId"
+func (m *Message) Mentions(userID string) bool {
+	if m.Type != MessageTypeText {
+		return false
+	}
+
+	var payload struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(m.Payload, &payload); err != nil {
+		return false
+	}
+
+	mention := fmt.Sprintf("@%s", userID)
+	text := payload.Text
+	for {
+		i := strings.Index(text, mention)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(mention):]
+		// "@user-1" does not mention "user-10"
+		if text == "" || !isValidID(text[:1]) {
+			return true
+		}
+	}
+}
+
 func (m *Message) ConvertToPbMessage() *scpb.Message {
 	pbMessage := &scpb.Message{}
 	pbMessage.MessageID = m.MessageID
@@ -137,6 +167,16 @@ func (m *SendMessageRequest) Validate() *ErrorResponse {
 		return NewErrorResponse("Failed to create a message.", http.StatusBadRequest, WithInvalidParams(invalidParams))
 	}
 
+	if *m.Type == MessageTypeUpdateRoomUser {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "type",
+				Reason: "updateRoomUser type is posted only by the server.",
+			},
+		}
+		return NewErrorResponse("Failed to create a message.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
 	if m.Payload == nil {
 		invalidParams := []*scpb.InvalidParam{
 			&scpb.InvalidParam{
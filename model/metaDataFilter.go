@@ -0,0 +1,157 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const maxMetaDataFilterValues = 100
+
+var metaDataKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// MetaDataFilterOperator is operator of a metaData filter expression
+type MetaDataFilterOperator string
+
+const (
+	MetaDataFilterOperatorEq     MetaDataFilterOperator = "eq"
+	MetaDataFilterOperatorIn     MetaDataFilterOperator = "in"
+	MetaDataFilterOperatorExists MetaDataFilterOperator = "exists"
+)
+
+func (mdfo MetaDataFilterOperator) IsValid() bool {
+	switch mdfo {
+	case MetaDataFilterOperatorEq, MetaDataFilterOperatorIn, MetaDataFilterOperatorExists:
+		return true
+	}
+	return false
+}
+
+// MetaDataFilter is a filter expression on a field of metaData.
+// Key is a dot separated path such as "order.storeId"
+type MetaDataFilter struct {
+	Key      string                 `json:"key"`
+	Operator MetaDataFilterOperator `json:"operator"`
+	Values   []string               `json:"values,omitempty"`
+	Exists   bool                   `json:"exists,omitempty"`
+}
+
+// Path returns the JSON path of the key
+func (mdf *MetaDataFilter) Path() string {
+	return fmt.Sprintf("$.%s", mdf.Key)
+}
+
+func (mdf *MetaDataFilter) Validate() *scpb.InvalidParam {
+	name := fmt.Sprintf("metaData[%s]", mdf.Key)
+
+	if !metaDataKeyRegexp.MatchString(mdf.Key) {
+		return &scpb.InvalidParam{
+			Name:   name,
+			Reason: "metaData key is invalid. Available characters are alphabets, numbers, underscores and dots as separators.",
+		}
+	}
+
+	if !mdf.Operator.IsValid() {
+		return &scpb.InvalidParam{
+			Name:   name,
+			Reason: "metaData operator is incorrect. Available operators are eq, in and exists.",
+		}
+	}
+
+	switch mdf.Operator {
+	case MetaDataFilterOperatorEq:
+		if len(mdf.Values) != 1 {
+			return &scpb.InvalidParam{
+				Name:   name,
+				Reason: "eq operator requires exactly one value.",
+			}
+		}
+	case MetaDataFilterOperatorIn:
+		if len(mdf.Values) == 0 || len(mdf.Values) > maxMetaDataFilterValues {
+			return &scpb.InvalidParam{
+				Name:   name,
+				Reason: fmt.Sprintf("in operator requires 1 to %d values.", maxMetaDataFilterValues),
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseMetaDataFilter parses a query parameter such as metaData[orderId]=1,
+// metaData[storeId][in]=a,b or metaData[orderId][exists]=true.
+// ok is false if the parameter is not a metaData filter
+func ParseMetaDataFilter(param, value string) (mdf *MetaDataFilter, ok bool, invalidParam *scpb.InvalidParam) {
+	if !strings.HasPrefix(param, "metaData[") || !strings.HasSuffix(param, "]") {
+		return nil, false, nil
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(param, "metaData["), "]"), "][")
+	mdf = &MetaDataFilter{
+		Key:      parts[0],
+		Operator: MetaDataFilterOperatorEq,
+	}
+	if len(parts) > 2 {
+		return nil, true, &scpb.InvalidParam{
+			Name:   param,
+			Reason: "metaData filter is incorrect.",
+		}
+	}
+	if len(parts) == 2 {
+		mdf.Operator = MetaDataFilterOperator(parts[1])
+	}
+
+	switch mdf.Operator {
+	case MetaDataFilterOperatorEq:
+		mdf.Values = []string{value}
+	case MetaDataFilterOperatorIn:
+		mdf.Values = strings.Split(value, ",")
+	case MetaDataFilterOperatorExists:
+		exists, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, true, &scpb.InvalidParam{
+				Name:   param,
+				Reason: "exists operator requires true or false.",
+			}
+		}
+		mdf.Exists = exists
+	}
+
+	if invalidParam := mdf.Validate(); invalidParam != nil {
+		return nil, true, invalidParam
+	}
+
+	return mdf, true, nil
+}
+
+// MetaDataFiltersFromPb makes the metaData filters of a gRPC request
+func MetaDataFiltersFromPb(pbFilters []*scpb.MetaDataFilter) []*MetaDataFilter {
+	if len(pbFilters) == 0 {
+		return nil
+	}
+
+	filters := make([]*MetaDataFilter, len(pbFilters))
+	for i, pbFilter := range pbFilters {
+		filters[i] = &MetaDataFilter{
+			Key:      pbFilter.Key,
+			Operator: MetaDataFilterOperator(pbFilter.Operator),
+			Values:   pbFilter.Values,
+			Exists:   pbFilter.Exists,
+		}
+	}
+	return filters
+}
+
+func validateMetaDataFilters(filters []*MetaDataFilter, message string) *ErrorResponse {
+	for _, mdf := range filters {
+		if invalidParam := mdf.Validate(); invalidParam != nil {
+			return NewErrorResponse(message, http.StatusBadRequest, WithInvalidParams([]*scpb.InvalidParam{invalidParam}))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+)
+
+const (
+	TestModelParseMetaDataFilter = "[model] ParseMetaDataFilter test"
+)
+
+func TestMetaDataFilter(t *testing.T) {
+	t.Run(TestModelParseMetaDataFilter, func(t *testing.T) {
+		mdf, ok, invalidParam := ParseMetaDataFilter("limit", "10")
+		if ok {
+			t.Fatalf("Failed to %s. Expected ok to be false, but it was true", TestModelParseMetaDataFilter)
+		}
+
+		mdf, ok, invalidParam = ParseMetaDataFilter("metaData[order.storeId]", "store-0001")
+		if !ok || invalidParam != nil {
+			t.Fatalf("Failed to %s. Expected eq filter to be valid, but it was invalid", TestModelParseMetaDataFilter)
+		}
+		if mdf.Operator != MetaDataFilterOperatorEq {
+			t.Fatalf("Failed to %s. Expected mdf.Operator to be \"eq\", but it was %s", TestModelParseMetaDataFilter, mdf.Operator)
+		}
+		if mdf.Path() != "$.order.storeId" {
+			t.Fatalf("Failed to %s. Expected mdf.Path() to be \"$.order.storeId\", but it was %s", TestModelParseMetaDataFilter, mdf.Path())
+		}
+
+		mdf, ok, invalidParam = ParseMetaDataFilter("metaData[orderId][in]", "a,b,c")
+		if !ok || invalidParam != nil {
+			t.Fatalf("Failed to %s. Expected in filter to be valid, but it was invalid", TestModelParseMetaDataFilter)
+		}
+		if len(mdf.Values) != 3 {
+			t.Fatalf("Failed to %s. Expected mdf.Values count to be 3, but it was %d", TestModelParseMetaDataFilter, len(mdf.Values))
+		}
+
+		mdf, ok, invalidParam = ParseMetaDataFilter("metaData[orderId][exists]", "false")
+		if !ok || invalidParam != nil {
+			t.Fatalf("Failed to %s. Expected exists filter to be valid, but it was invalid", TestModelParseMetaDataFilter)
+		}
+		if mdf.Exists {
+			t.Fatalf("Failed to %s. Expected mdf.Exists to be false, but it was true", TestModelParseMetaDataFilter)
+		}
+
+		_, ok, invalidParam = ParseMetaDataFilter("metaData[orderId][exists]", "yes")
+		if !ok || invalidParam == nil {
+			t.Fatalf("Failed to %s. Expected invalidParam to be not nil, but it was nil", TestModelParseMetaDataFilter)
+		}
+
+		_, ok, invalidParam = ParseMetaDataFilter("metaData[orderId][like]", "a")
+		if !ok || invalidParam == nil {
+			t.Fatalf("Failed to %s. Expected invalidParam to be not nil, but it was nil", TestModelParseMetaDataFilter)
+		}
+
+		_, ok, invalidParam = ParseMetaDataFilter("metaData[order') OR 1=1 --]", "a")
+		if !ok || invalidParam == nil {
+			t.Fatalf("Failed to %s. Expected invalidParam to be not nil, but it was nil", TestModelParseMetaDataFilter)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// ModerationAction is an action taken by a room moderator against a member
+type ModerationAction string
+
+const (
+	ModerationActionKick   ModerationAction = "kick"
+	ModerationActionBan    ModerationAction = "ban"
+	ModerationActionUnban  ModerationAction = "unban"
+	ModerationActionMute   ModerationAction = "mute"
+	ModerationActionUnmute ModerationAction = "unmute"
+)
+
+// RoomSanctionType is type of a sanction that stays in effect on a user of a room
+type RoomSanctionType int
+
+const (
+	RoomSanctionTypeBan RoomSanctionType = iota + 1
+	RoomSanctionTypeMute
+)
+
+// RoomSanction is a ban or a mute of a user in a room
+type RoomSanction struct {
+	ID              uint64           `json:"-" db:"id"`
+	RoomID          string           `json:"roomId" db:"room_id,notnull"`
+	UserID          string           `json:"userId" db:"user_id,notnull"`
+	Type            RoomSanctionType `json:"type" db:"type,notnull"`
+	ModeratorUserID string           `json:"moderatorUserId" db:"moderator_user_id,notnull"`
+	Reason          string           `json:"reason" db:"reason"`
+	Expired         int64            `json:"expired" db:"expired,notnull"`
+	Created         int64            `json:"created" db:"created,notnull"`
+	Deleted         int64            `json:"-" db:"deleted,notnull"`
+}
+
+// IsActive reports whether the sanction is still in effect. A sanction without expiry lasts until it is lifted
+func (rs *RoomSanction) IsActive() bool {
+	return rs.Deleted == 0 && (rs.Expired == 0 || rs.Expired > time.Now().Unix())
+}
+
+// ModerationLog is an entry of the moderation audit trail of a room
+type ModerationLog struct {
+	ID              uint64           `json:"-" db:"id"`
+	LogID           string           `json:"logId" db:"log_id,notnull"`
+	RoomID          string           `json:"roomId" db:"room_id,notnull"`
+	Action          ModerationAction `json:"action" db:"action,notnull"`
+	ModeratorUserID string           `json:"moderatorUserId" db:"moderator_user_id,notnull"`
+	TargetUserID    string           `json:"targetUserId" db:"target_user_id,notnull"`
+	Reason          string           `json:"reason" db:"reason"`
+	Expired         int64            `json:"expired" db:"expired,notnull"`
+	Created         int64            `json:"created" db:"created,notnull"`
+}
+
+func (ml *ModerationLog) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	expired := ""
+	if ml.Expired != 0 {
+		expired = time.Unix(ml.Expired, 0).In(l).Format(time.RFC3339)
+	}
+	return json.Marshal(&struct {
+		LogID           string           `json:"logId"`
+		RoomID          string           `json:"roomId"`
+		Action          ModerationAction `json:"action"`
+		ModeratorUserID string           `json:"moderatorUserId"`
+		TargetUserID    string           `json:"targetUserId"`
+		Reason          string           `json:"reason,omitempty"`
+		Expired         string           `json:"expired,omitempty"`
+		Created         string           `json:"created"`
+	}{
+		LogID:           ml.LogID,
+		RoomID:          ml.RoomID,
+		Action:          ml.Action,
+		ModeratorUserID: ml.ModeratorUserID,
+		TargetUserID:    ml.TargetUserID,
+		Reason:          ml.Reason,
+		Expired:         expired,
+		Created:         time.Unix(ml.Created, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+// PayloadModeration is payload of the system message posted for a moderation action
+type PayloadModeration struct {
+	Action          ModerationAction `json:"action"`
+	UserID          string           `json:"userId"`
+	ModeratorUserID string           `json:"moderatorUserId"`
+	Reason          string           `json:"reason,omitempty"`
+	Expired         int64            `json:"expired,omitempty"`
+}
+
+type ModerateRoomUserRequest struct {
+	RoomID          string           `json:"roomId"`
+	UserID          string           `json:"userId"`
+	ModeratorUserID string           `json:"moderatorUserId"`
+	Reason          string           `json:"reason,omitempty"`
+	Duration        int64            `json:"duration,omitempty"`
+	Action          ModerationAction `json:"-"`
+}
+
+func (mrur *ModerateRoomUserRequest) Validate() *ErrorResponse {
+	errMessage := "Failed to " + string(mrur.Action) + " room user."
+
+	if mrur.UserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "userId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse(errMessage, http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if mrur.UserID == mrur.ModeratorUserID {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "userId can not be own UserId.",
+			},
+		}
+		return NewErrorResponse(errMessage, http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if mrur.Duration < 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "duration",
+				Reason: "duration must be 0 or more. 0 means no expiry.",
+			},
+		}
+		return NewErrorResponse(errMessage, http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if mrur.Duration != 0 && mrur.Action != ModerationActionBan && mrur.Action != ModerationActionMute {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "duration",
+				Reason: "duration is available only for ban and mute.",
+			},
+		}
+		return NewErrorResponse(errMessage, http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (mrur *ModerateRoomUserRequest) expired() int64 {
+	if mrur.Duration == 0 {
+		return 0
+	}
+	return time.Now().Unix() + mrur.Duration
+}
+
+func (mrur *ModerateRoomUserRequest) GenerateRoomSanction(sanctionType RoomSanctionType) *RoomSanction {
+	rs := &RoomSanction{}
+	rs.RoomID = mrur.RoomID
+	rs.UserID = mrur.UserID
+	rs.Type = sanctionType
+	rs.ModeratorUserID = mrur.ModeratorUserID
+	rs.Reason = mrur.Reason
+	rs.Expired = mrur.expired()
+	rs.Created = time.Now().Unix()
+	return rs
+}
+
+func (mrur *ModerateRoomUserRequest) GenerateModerationLog(expired int64) *ModerationLog {
+	ml := &ModerationLog{}
+	ml.LogID = utils.GenerateUUID()
+	ml.RoomID = mrur.RoomID
+	ml.Action = mrur.Action
+	ml.ModeratorUserID = mrur.ModeratorUserID
+	ml.TargetUserID = mrur.UserID
+	ml.Reason = mrur.Reason
+	ml.Expired = expired
+	ml.Created = time.Now().Unix()
+	return ml
+}
+
+type RetrieveModerationLogsRequest struct {
+	RoomID string `json:"roomId"`
+	UserID string `json:"userId,omitempty"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+type ModerationLogsResponse struct {
+	ModerationLogs []*ModerationLog `json:"moderationLogs"`
+	Limit          int32            `json:"limit"`
+	Offset         int32            `json:"offset"`
+}
@@ -0,0 +1,179 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// RoomNotificationLevel is which messages of a room are pushed to a member
+type RoomNotificationLevel int32
+
+const (
+	RoomNotificationLevelAll RoomNotificationLevel = iota
+	RoomNotificationLevelMentionsOnly
+	RoomNotificationLevelNone
+)
+
+func (rnl RoomNotificationLevel) isValid() bool {
+	return rnl >= RoomNotificationLevelAll && rnl <= RoomNotificationLevelNone
+}
+
+// IsMuted reports whether the room user is muted at the time
+func (ru *RoomUser) IsMuted(t time.Time) bool {
+	return ru.MutedUntil > t.Unix()
+}
+
+// IsNotificationRestricted reports whether the room user receives fewer pushes than the default
+func (ru *RoomUser) IsNotificationRestricted(t time.Time) bool {
+	return ru.NotificationLevel != RoomNotificationLevelAll || ru.IsMuted(t)
+}
+
+// ShouldNotify reports whether the message is pushed to the room user at the time
+func (ru *RoomUser) ShouldNotify(message *Message, t time.Time) bool {
+	if ru.IsMuted(t) {
+		return false
+	}
+
+	switch ru.NotificationLevel {
+	case RoomNotificationLevelNone:
+		return false
+	case RoomNotificationLevelMentionsOnly:
+		return message.Mentions(ru.UserID)
+	}
+
+	return true
+}
+
+// IsDoNotDisturb reports whether the time is in the do-not-disturb schedule of the user
+func (u *User) IsDoNotDisturb(t time.Time) bool {
+	if u.DndStart == "" || u.DndEnd == "" {
+		return false
+	}
+
+	start, err := parseClock(u.DndStart)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(u.DndEnd)
+	if err != nil {
+		return false
+	}
+
+	l, err := time.LoadLocation(u.DndTimezone)
+	if err != nil {
+		l = time.UTC
+	}
+	lt := t.In(l)
+	now := lt.Hour()*60 + lt.Minute()
+
+	if start <= end {
+		return start <= now && now < end
+	}
+	// The schedule crosses midnight
+	return start <= now || now < end
+}
+
+// parseClock parses "HH:MM" into minutes of the day
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+type UpdateRoomUserNotificationSettingRequest struct {
+	RoomID            string                 `json:"roomId"`
+	UserID            string                 `json:"userId"`
+	NotificationLevel *RoomNotificationLevel `json:"notificationLevel,omitempty"`
+	MutedUntil        *int64                 `json:"mutedUntil,omitempty"`
+}
+
+func (urunsr *UpdateRoomUserNotificationSettingRequest) Validate() *ErrorResponse {
+	if urunsr.NotificationLevel != nil && !urunsr.NotificationLevel.isValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "notificationLevel",
+				Reason: "notificationLevel is incorrect.",
+			},
+		}
+		return NewErrorResponse("Failed to update notification setting.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if urunsr.MutedUntil != nil && *urunsr.MutedUntil < 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "mutedUntil",
+				Reason: "mutedUntil must be a unix timestamp, or 0 to unmute.",
+			},
+		}
+		return NewErrorResponse("Failed to update notification setting.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (ru *RoomUser) UpdateNotificationSetting(req *UpdateRoomUserNotificationSettingRequest) {
+	if req.NotificationLevel != nil {
+		ru.NotificationLevel = *req.NotificationLevel
+	}
+
+	if req.MutedUntil != nil {
+		ru.MutedUntil = *req.MutedUntil
+	}
+}
+
+type UpdateUserDoNotDisturbRequest struct {
+	UserID   string `json:"userId"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone"`
+}
+
+func (uudndr *UpdateUserDoNotDisturbRequest) Validate() *ErrorResponse {
+	if uudndr.Start == "" && uudndr.End == "" {
+		return nil
+	}
+
+	if _, err := parseClock(uudndr.Start); err != nil {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "start",
+				Reason: "start must be HH:MM.",
+			},
+		}
+		return NewErrorResponse("Failed to update do-not-disturb.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if _, err := parseClock(uudndr.End); err != nil {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "end",
+				Reason: "end must be HH:MM.",
+			},
+		}
+		return NewErrorResponse("Failed to update do-not-disturb.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if _, err := time.LoadLocation(uudndr.Timezone); err != nil {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "timezone",
+				Reason: "timezone must be an IANA time zone name such as Asia/Tokyo.",
+			},
+		}
+		return NewErrorResponse("Failed to update do-not-disturb.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (u *User) UpdateDoNotDisturb(req *UpdateUserDoNotDisturbRequest) {
+	u.DndStart = req.Start
+	u.DndEnd = req.End
+	u.DndTimezone = req.Timezone
+	u.ModifiedTimestamp = time.Now().Unix()
+}
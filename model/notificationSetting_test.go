@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	TestModelRoomUserShouldNotify = "[model] RoomUser ShouldNotify test"
+	TestModelUserIsDoNotDisturb   = "[model] User IsDoNotDisturb test"
+)
+
+func TestNotificationSetting(t *testing.T) {
+	t.Run(TestModelRoomUserShouldNotify, func(t *testing.T) {
+		now := time.Now()
+		message := &Message{}
+		message.Type = MessageTypeText
+		message.Payload = []byte(`{"text":"hello @model-user-id-0001"}`)
+
+		ru := &RoomUser{}
+		ru.UserID = "model-user-id-0001"
+		if !ru.ShouldNotify(message, now) {
+			t.Fatalf("Failed to %s. Expected ShouldNotify to be true, but it was false", TestModelRoomUserShouldNotify)
+		}
+
+		ru.NotificationLevel = RoomNotificationLevelMentionsOnly
+		if !ru.ShouldNotify(message, now) {
+			t.Fatalf("Failed to %s. Expected ShouldNotify with mention to be true, but it was false", TestModelRoomUserShouldNotify)
+		}
+
+		ru.UserID = "model-user-id-000"
+		if ru.ShouldNotify(message, now) {
+			t.Fatalf("Failed to %s. Expected ShouldNotify without mention to be false, but it was true", TestModelRoomUserShouldNotify)
+		}
+
+		ru.UserID = "model-user-id-0001"
+		ru.NotificationLevel = RoomNotificationLevelAll
+		ru.MutedUntil = now.Unix() + 60
+		if ru.ShouldNotify(message, now) {
+			t.Fatalf("Failed to %s. Expected ShouldNotify while muted to be false, but it was true", TestModelRoomUserShouldNotify)
+		}
+	})
+
+	t.Run(TestModelUserIsDoNotDisturb, func(t *testing.T) {
+		u := &User{}
+		at := time.Date(2018, 1, 1, 23, 30, 0, 0, time.UTC)
+		if u.IsDoNotDisturb(at) {
+			t.Fatalf("Failed to %s. Expected IsDoNotDisturb without schedule to be false, but it was true", TestModelUserIsDoNotDisturb)
+		}
+
+		u.DndStart = "22:00"
+		u.DndEnd = "07:00"
+		u.DndTimezone = "UTC"
+		if !u.IsDoNotDisturb(at) {
+			t.Fatalf("Failed to %s. Expected IsDoNotDisturb at 23:30 to be true, but it was false", TestModelUserIsDoNotDisturb)
+		}
+
+		at = time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+		if u.IsDoNotDisturb(at) {
+			t.Fatalf("Failed to %s. Expected IsDoNotDisturb at 12:00 to be false, but it was true", TestModelUserIsDoNotDisturb)
+		}
+
+		u.DndTimezone = "Asia/Tokyo"
+		at = time.Date(2018, 1, 1, 14, 0, 0, 0, time.UTC)
+		if !u.IsDoNotDisturb(at) {
+			t.Fatalf("Failed to %s. Expected IsDoNotDisturb at 23:00 JST to be true, but it was false", TestModelUserIsDoNotDisturb)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	// NotificationTemplateMessageTypeDefault is the message type of the template used for message types without their own template
+	NotificationTemplateMessageTypeDefault = "default"
+
+	// NotificationTemplateFallbackLang is the language used when neither the recipient's nor the default language has a template
+	NotificationTemplateFallbackLang = "en"
+
+	NotificationTemplatePlaceholderSenderName = "{{senderName}}"
+	NotificationTemplatePlaceholderRoomName   = "{{roomName}}"
+	NotificationTemplatePlaceholderText       = "{{text}}"
+
+	notificationTemplateBodyMaxLength = 512
+	notificationTextMaxLength         = 100
+)
+
+var (
+	notificationTemplateMessageTypeRegexp = regexp.MustCompile(`^[0-9A-Za-z._-]{1,64}$`)
+	notificationTemplateLangRegexp        = regexp.MustCompile(`^[a-z]{2,3}(-[0-9a-z]{2,8})*$`)
+)
+
+// builtInNotificationTemplates are used when the workspace does not configure a template
+var builtInNotificationTemplates = map[string]map[string]string{
+	"en": map[string]string{
+		MessageTypeText:                        "{{senderName}}: {{text}}",
+		MessageTypeImage:                       "{{senderName}} sent an image",
+		MessageTypeFile:                        "{{senderName}} sent a file",
+		NotificationTemplateMessageTypeDefault: "{{senderName}} sent a message",
+	},
+	"ja": map[string]string{
+		MessageTypeText:                        "{{senderName}}: {{text}}",
+		MessageTypeImage:                       "{{senderName}}さんが画像を送信しました",
+		MessageTypeFile:                        "{{senderName}}さんがファイルを送信しました",
+		NotificationTemplateMessageTypeDefault: "{{senderName}}さんがメッセージを送信しました",
+	},
+}
+
+// NotificationTemplate is the push text of a message type in a language, configured per workspace
+type NotificationTemplate struct {
+	ID          uint64 `json:"-" db:"id"`
+	MessageType string `json:"messageType" db:"message_type,notnull"`
+	Lang        string `json:"lang" db:"lang,notnull"`
+	Body        string `json:"body" db:"body,notnull"`
+	Created     int64  `json:"created" db:"created,notnull"`
+	Modified    int64  `json:"modified" db:"modified,notnull"`
+}
+
+func (nt *NotificationTemplate) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		MessageType string `json:"messageType"`
+		Lang        string `json:"lang"`
+		Body        string `json:"body"`
+		Created     string `json:"created"`
+		Modified    string `json:"modified"`
+	}{
+		MessageType: nt.MessageType,
+		Lang:        nt.Lang,
+		Body:        nt.Body,
+		Created:     time.Unix(nt.Created, 0).In(l).Format(time.RFC3339),
+		Modified:    time.Unix(nt.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+type NotificationTemplatesResponse struct {
+	NotificationTemplates []*NotificationTemplate `json:"notificationTemplates"`
+}
+
+type PutNotificationTemplateRequest struct {
+	MessageType string `json:"-"`
+	Lang        string `json:"-"`
+	Body        string `json:"body"`
+}
+
+func (req *PutNotificationTemplateRequest) Validate() *ErrorResponse {
+	errRes := validateNotificationTemplateKey(req.MessageType, req.Lang, "Failed to put notification template.")
+	if errRes != nil {
+		return errRes
+	}
+
+	if req.Body == "" || utf8.RuneCountInString(req.Body) > notificationTemplateBodyMaxLength {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "body",
+				Reason: "body is required, and must be 512 characters or less.",
+			},
+		}
+		return NewErrorResponse("Failed to put notification template.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (req *PutNotificationTemplateRequest) GenerateNotificationTemplate() *NotificationTemplate {
+	nowTimestamp := time.Now().Unix()
+
+	nt := &NotificationTemplate{}
+	nt.MessageType = req.MessageType
+	nt.Lang = NormalizeLang(req.Lang)
+	nt.Body = req.Body
+	nt.Created = nowTimestamp
+	nt.Modified = nowTimestamp
+	return nt
+}
+
+type DeleteNotificationTemplateRequest struct {
+	MessageType string
+	Lang        string
+}
+
+func (req *DeleteNotificationTemplateRequest) Validate() *ErrorResponse {
+	return validateNotificationTemplateKey(req.MessageType, req.Lang, "Failed to delete notification template.")
+}
+
+func validateNotificationTemplateKey(messageType, lang, message string) *ErrorResponse {
+	if !notificationTemplateMessageTypeRegexp.MatchString(messageType) {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "messageType",
+				Reason: "messageType is invalid. Available characters are alphabets, numbers, dots, underscores and hyphens.",
+			},
+		}
+		return NewErrorResponse(message, http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if !notificationTemplateLangRegexp.MatchString(NormalizeLang(lang)) {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "lang",
+				Reason: "lang is invalid. Set a language tag such as en or ja-JP.",
+			},
+		}
+		return NewErrorResponse(message, http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+// NormalizeLang lowercases the language tag and unifies the separator, for example "ja_JP" to "ja-jp"
+func NormalizeLang(lang string) string {
+	return strings.Replace(strings.ToLower(strings.TrimSpace(lang)), "_", "-", -1)
+}
+
+// NotificationTemplateSet resolves the push text of a message from the templates of a workspace,
+// falling back to the built-in templates
+type NotificationTemplateSet struct {
+	defaultLang string
+	templates   map[string]map[string]string
+}
+
+// NewNotificationTemplateSet returns the template set of the workspace templates and the default language of the workspace
+func NewNotificationTemplateSet(templates []*NotificationTemplate, defaultLang string) *NotificationTemplateSet {
+	nts := &NotificationTemplateSet{
+		defaultLang: NormalizeLang(defaultLang),
+		templates:   make(map[string]map[string]string),
+	}
+	for _, nt := range templates {
+		lang := NormalizeLang(nt.Lang)
+		if _, ok := nts.templates[lang]; !ok {
+			nts.templates[lang] = make(map[string]string)
+		}
+		nts.templates[lang][nt.MessageType] = nt.Body
+	}
+	return nts
+}
+
+// Render returns the push text of the message in the language.
+// The language falls back to its primary subtag, the default language and NotificationTemplateFallbackLang in order.
+// In each language the template of the message type is preferred to the default template,
+// and the workspace template is preferred to the built-in template.
+func (nts *NotificationTemplateSet) Render(lang string, message *Message, senderName, roomName string) string {
+	body := nts.lookup(lang, message.Type)
+
+	replacer := strings.NewReplacer(
+		NotificationTemplatePlaceholderSenderName, senderName,
+		NotificationTemplatePlaceholderRoomName, roomName,
+		NotificationTemplatePlaceholderText, notificationText(message),
+	)
+	return replacer.Replace(body)
+}
+
+func (nts *NotificationTemplateSet) lookup(lang, messageType string) string {
+	for _, l := range nts.langCandidates(lang) {
+		for _, mt := range []string{messageType, NotificationTemplateMessageTypeDefault} {
+			if body, ok := nts.templates[l][mt]; ok {
+				return body
+			}
+			if body, ok := builtInNotificationTemplates[l][mt]; ok {
+				return body
+			}
+		}
+	}
+
+	return builtInNotificationTemplates[NotificationTemplateFallbackLang][NotificationTemplateMessageTypeDefault]
+}
+
+func (nts *NotificationTemplateSet) langCandidates(lang string) []string {
+	candidates := make([]string, 0, 5)
+	for _, l := range []string{NormalizeLang(lang), nts.defaultLang, NotificationTemplateFallbackLang} {
+		if l == "" {
+			continue
+		}
+		candidates = append(candidates, l)
+		if i := strings.Index(l, "-"); i > 0 {
+			candidates = append(candidates, l[:i])
+		}
+	}
+	return candidates
+}
+
+// notificationText returns the text of a text message shortened for a push
+func notificationText(message *Message) string {
+	if message.Type != MessageTypeText {
+		return ""
+	}
+
+	var payload PayloadText
+	if err := json.Unmarshal(message.Payload, &payload); err != nil {
+		return ""
+	}
+
+	text := strings.TrimSpace(payload.Text)
+	if utf8.RuneCountInString(text) > notificationTextMaxLength {
+		text = string([]rune(text)[:notificationTextMaxLength]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"testing"
+)
+
+const (
+	TestModelNotificationTemplateRender       = "[model] NotificationTemplateSet Render test"
+	TestModelPutNotificationTemplateReqValid  = "[model] PutNotificationTemplateRequest Validate test"
+	TestModelNotificationTemplateNormalizeLng = "[model] NormalizeLang test"
+)
+
+func TestNotificationTemplate(t *testing.T) {
+	t.Run(TestModelNotificationTemplateRender, func(t *testing.T) {
+		templates := []*NotificationTemplate{
+			&NotificationTemplate{MessageType: MessageTypeText, Lang: "ja", Body: "[{{roomName}}] {{senderName}}: {{text}}"},
+			&NotificationTemplate{MessageType: "sticker", Lang: "en", Body: "{{senderName}} sent a sticker"},
+			&NotificationTemplate{MessageType: NotificationTemplateMessageTypeDefault, Lang: "fr", Body: "{{senderName}} a envoyé un message"},
+		}
+		nts := NewNotificationTemplateSet(templates, "fr")
+
+		message := &Message{}
+		message.Type = MessageTypeText
+		message.Payload = JSONText(`{"text":"hello"}`)
+
+		text := nts.Render("ja_JP", message, "Alice", "Sales")
+		if text != "[Sales] Alice: hello" {
+			t.Fatalf("Failed to %s. Expected text to be [Sales] Alice: hello, but it was %s", TestModelNotificationTemplateRender, text)
+		}
+
+		message.Type = MessageTypeImage
+		text = nts.Render("ja", message, "Alice", "Sales")
+		if text != "Aliceさんが画像を送信しました" {
+			t.Fatalf("Failed to %s. Expected the built-in ja template, but it was %s", TestModelNotificationTemplateRender, text)
+		}
+
+		message.Type = "sticker"
+		text = nts.Render("en-US", message, "Alice", "Sales")
+		if text != "Alice sent a sticker" {
+			t.Fatalf("Failed to %s. Expected text to be Alice sent a sticker, but it was %s", TestModelNotificationTemplateRender, text)
+		}
+
+		text = nts.Render("de", message, "Alice", "Sales")
+		if text != "Alice a envoyé un message" {
+			t.Fatalf("Failed to %s. Expected the template of the default language, but it was %s", TestModelNotificationTemplateRender, text)
+		}
+
+		text = NewNotificationTemplateSet(nil, "").Render("", message, "Alice", "Sales")
+		if text != "Alice sent a message" {
+			t.Fatalf("Failed to %s. Expected the built-in en default template, but it was %s", TestModelNotificationTemplateRender, text)
+		}
+	})
+
+	t.Run(TestModelPutNotificationTemplateReqValid, func(t *testing.T) {
+		req := &PutNotificationTemplateRequest{
+			MessageType: "sticker",
+			Lang:        "ja_JP",
+			Body:        "{{senderName}}さんがスタンプを送信しました",
+		}
+		errRes := req.Validate()
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelPutNotificationTemplateReqValid)
+		}
+		if req.GenerateNotificationTemplate().Lang != "ja-jp" {
+			t.Fatalf("Failed to %s. Expected lang to be ja-jp", TestModelPutNotificationTemplateReqValid)
+		}
+
+		req.MessageType = "sti cker"
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because messageType is invalid", TestModelPutNotificationTemplateReqValid)
+		}
+
+		req.MessageType = "sticker"
+		req.Lang = "japanese"
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because lang is invalid", TestModelPutNotificationTemplateReqValid)
+		}
+
+		req.Lang = "ja"
+		req.Body = ""
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because body is empty", TestModelPutNotificationTemplateReqValid)
+		}
+	})
+
+	t.Run(TestModelNotificationTemplateNormalizeLng, func(t *testing.T) {
+		if NormalizeLang(" zh_Hant_TW ") != "zh-hant-tw" {
+			t.Fatalf("Failed to %s. Expected zh-hant-tw, but it was %s", TestModelNotificationTemplateNormalizeLng, NormalizeLang(" zh_Hant_TW "))
+		}
+	})
+}
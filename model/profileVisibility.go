@@ -0,0 +1,100 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// PublicProfileScopeContacts shows the profile only to the contacts of the user
+const PublicProfileScopeContacts = scpb.PublicProfileScope_Self + 1
+
+// IsValidPublicProfileScope reports whether the scope is one of the supported tiers
+func IsValidPublicProfileScope(scope scpb.PublicProfileScope) bool {
+	return scope == scpb.PublicProfileScope_All || scope == scpb.PublicProfileScope_Self || scope == PublicProfileScopeContacts
+}
+
+func validatePublicProfileScope(scope *scpb.PublicProfileScope, message string) *ErrorResponse {
+	if scope == nil || IsValidPublicProfileScope(*scope) {
+		return nil
+	}
+
+	invalidParams := []*scpb.InvalidParam{
+		&scpb.InvalidParam{
+			Name:   "publicProfileScope",
+			Reason: fmt.Sprintf("publicProfileScope is incorrect. Available values are %d (all), %d (self) and %d (contacts).", scpb.PublicProfileScope_All, scpb.PublicProfileScope_Self, PublicProfileScopeContacts),
+		},
+	}
+	return NewErrorResponse(message, http.StatusBadRequest, WithInvalidParams(invalidParams))
+}
+
+// ProfileRelationship is the relationship of the viewer to the owner of a profile.
+// A closer relationship has a greater value
+type ProfileRelationship int
+
+const (
+	ProfileRelationshipAnyone ProfileRelationship = iota
+	ProfileRelationshipSharedRoom
+	ProfileRelationshipContact
+	ProfileRelationshipSelf
+)
+
+// CanSeeProfile reports whether the viewer in the relationship can see a profile in the scope
+func (pr ProfileRelationship) CanSeeProfile(scope scpb.PublicProfileScope) bool {
+	switch scope {
+	case scpb.PublicProfileScope_All:
+		return true
+	case PublicProfileScopeContacts:
+		return pr >= ProfileRelationshipContact
+	default:
+		return pr == ProfileRelationshipSelf
+	}
+}
+
+// ApplyProfileVisibility strips the fields the viewer in the relationship is not allowed to see.
+// Private fields are left only to the user, and a hidden profile keeps the name,
+// and the picture for the users sharing a room
+func (u *User) ApplyProfileVisibility(pr ProfileRelationship) {
+	if pr == ProfileRelationshipSelf {
+		return
+	}
+
+	u.UnreadCount = 0
+	u.AccessToken = ""
+	u.LastAccessRoomID = ""
+	u.BlockUsers = nil
+	u.Devices = nil
+	u.Roles = nil
+	u.DndStart = ""
+	u.DndEnd = ""
+	u.DndTimezone = ""
+	u.StatusReason = ""
+	u.StatusExpired = 0
+
+	if pr.CanSeeProfile(u.PublicProfileScope) {
+		return
+	}
+
+	u.InformationURL = ""
+	u.MetaData = []byte("{}")
+	u.Lang = ""
+	u.LastAccessedTimestamp = 0
+	if pr < ProfileRelationshipSharedRoom {
+		u.PictureURL = ""
+	}
+}
+
+// ApplyProfileVisibility strips the fields the viewer in the relationship is not allowed to see
+func (mu *MiniUser) ApplyProfileVisibility(pr ProfileRelationship) {
+	if pr.CanSeeProfile(mu.PublicProfileScope) {
+		return
+	}
+
+	mu.InformationURL = ""
+	mu.MetaData = []byte("{}")
+	mu.LastAccessedTimestamp = 0
+	if pr < ProfileRelationshipSharedRoom {
+		mu.PictureURL = ""
+	}
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"testing"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	TestModelCanSeeProfile          = "[model] ProfileRelationship.CanSeeProfile test"
+	TestModelApplyProfileVisibility = "[model] User.ApplyProfileVisibility test"
+)
+
+func TestProfileVisibility(t *testing.T) {
+	t.Run(TestModelCanSeeProfile, func(t *testing.T) {
+		if !ProfileRelationshipAnyone.CanSeeProfile(scpb.PublicProfileScope_All) {
+			t.Fatalf("Failed to %s. Expected anyone to see the profile of scope all, but it was not", TestModelCanSeeProfile)
+		}
+		if ProfileRelationshipSharedRoom.CanSeeProfile(PublicProfileScopeContacts) {
+			t.Fatalf("Failed to %s. Expected a room mate not to see the profile of scope contacts, but it was", TestModelCanSeeProfile)
+		}
+		if !ProfileRelationshipContact.CanSeeProfile(PublicProfileScopeContacts) {
+			t.Fatalf("Failed to %s. Expected a contact to see the profile of scope contacts, but it was not", TestModelCanSeeProfile)
+		}
+		if ProfileRelationshipContact.CanSeeProfile(scpb.PublicProfileScope_Self) {
+			t.Fatalf("Failed to %s. Expected a contact not to see the profile of scope self, but it was", TestModelCanSeeProfile)
+		}
+		if !ProfileRelationshipSelf.CanSeeProfile(scpb.PublicProfileScope_Self) {
+			t.Fatalf("Failed to %s. Expected the user to see own profile, but it was not", TestModelCanSeeProfile)
+		}
+	})
+
+	t.Run(TestModelApplyProfileVisibility, func(t *testing.T) {
+		newUser := func() *User {
+			u := &User{}
+			u.UserID = "model-user-id-0001"
+			u.Name = "name"
+			u.PictureURL = "http://example.com/picture.png"
+			u.InformationURL = "http://example.com"
+			u.MetaData = []byte(`{"key":"value"}`)
+			u.AccessToken = "token"
+			u.UnreadCount = 1
+			u.PublicProfileScope = PublicProfileScopeContacts
+			return u
+		}
+
+		u := newUser()
+		u.ApplyProfileVisibility(ProfileRelationshipSelf)
+		if u.AccessToken != "token" || u.UnreadCount != 1 {
+			t.Fatalf("Failed to %s. Expected private fields to be left to the user, but they were stripped", TestModelApplyProfileVisibility)
+		}
+
+		u = newUser()
+		u.ApplyProfileVisibility(ProfileRelationshipContact)
+		if u.AccessToken != "" || u.UnreadCount != 0 {
+			t.Fatalf("Failed to %s. Expected private fields to be stripped, but they were not", TestModelApplyProfileVisibility)
+		}
+		if u.InformationURL == "" {
+			t.Fatalf("Failed to %s. Expected informationUrl to be visible to a contact, but it was stripped", TestModelApplyProfileVisibility)
+		}
+
+		u = newUser()
+		u.ApplyProfileVisibility(ProfileRelationshipSharedRoom)
+		if u.InformationURL != "" || string(u.MetaData) != "{}" {
+			t.Fatalf("Failed to %s. Expected profile fields to be stripped, but they were not", TestModelApplyProfileVisibility)
+		}
+		if u.Name == "" || u.PictureURL == "" {
+			t.Fatalf("Failed to %s. Expected name and pictureUrl to be visible to a room mate, but they were stripped", TestModelApplyProfileVisibility)
+		}
+
+		u = newUser()
+		u.ApplyProfileVisibility(ProfileRelationshipAnyone)
+		if u.Name == "" || u.PictureURL != "" {
+			t.Fatalf("Failed to %s. Expected only name to be visible, but it was not", TestModelApplyProfileVisibility)
+		}
+	})
+}
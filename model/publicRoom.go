@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PublicRoomsSort is sort order of the public room directory
+type PublicRoomsSort int
+
+const (
+	PublicRoomsSortActivity PublicRoomsSort = iota
+	PublicRoomsSortMemberCount
+)
+
+type PublicRoom struct {
+	RoomID             string         `json:"roomId" db:"room_id"`
+	UserID             string         `json:"userId" db:"user_id"`
+	Name               string         `json:"name" db:"name"`
+	PictureURL         string         `json:"pictureUrl,omitempty" db:"picture_url"`
+	InformationURL     string         `json:"informationUrl,omitempty" db:"information_url"`
+	MetaData           JSONText       `json:"metaData" db:"meta_data"`
+	JoinPolicy         RoomJoinPolicy `json:"joinPolicy" db:"join_policy"`
+	MemberCount        int64          `json:"memberCount" db:"member_count"`
+	LastMessageUpdated int64          `json:"lastMessageUpdated" db:"last_message_updated"`
+	Created            int64          `json:"created" db:"created"`
+}
+
+func (pr *PublicRoom) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	lmu := ""
+	if pr.LastMessageUpdated != 0 {
+		lmu = time.Unix(pr.LastMessageUpdated, 0).In(l).Format(time.RFC3339)
+	}
+	return json.Marshal(&struct {
+		RoomID             string         `json:"roomId"`
+		UserID             string         `json:"userId"`
+		Name               string         `json:"name"`
+		PictureURL         string         `json:"pictureUrl,omitempty"`
+		InformationURL     string         `json:"informationUrl,omitempty"`
+		MetaData           JSONText       `json:"metaData"`
+		JoinPolicy         RoomJoinPolicy `json:"joinPolicy"`
+		MemberCount        int64          `json:"memberCount"`
+		LastMessageUpdated string         `json:"lastMessageUpdated"`
+		Created            string         `json:"created"`
+	}{
+		RoomID:             pr.RoomID,
+		UserID:             pr.UserID,
+		Name:               pr.Name,
+		PictureURL:         pr.PictureURL,
+		InformationURL:     pr.InformationURL,
+		MetaData:           pr.MetaData,
+		JoinPolicy:         pr.JoinPolicy,
+		MemberCount:        pr.MemberCount,
+		LastMessageUpdated: lmu,
+		Created:            time.Unix(pr.Created, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+type RetrievePublicRoomsRequest struct {
+	Limit  int32           `json:"limit"`
+	Offset int32           `json:"offset"`
+	Name   string          `json:"name,omitempty"`
+	Tags   []string        `json:"tags,omitempty"`
+	Sort   PublicRoomsSort `json:"sort"`
+}
+
+type PublicRoomsResponse struct {
+	Rooms    []*PublicRoom `json:"rooms"`
+	AllCount int64         `json:"allCount"`
+	Limit    int32         `json:"limit"`
+	Offset   int32         `json:"offset"`
+}
@@ -0,0 +1,11 @@
+package model
+
+// RateLimit is the shared state of a rate limit key among API instances
+type RateLimit struct {
+	ID uint64 `json:"-" db:"id"`
+	// LimitKey identifies whose requests are counted, such as a user in a room
+	LimitKey string `json:"limitKey" db:"limit_key,notnull"`
+	// Tat is the theoretical arrival time of the next request in unix nanoseconds
+	Tat      int64 `json:"tat" db:"tat,notnull"`
+	Modified int64 `json:"modified" db:"modified,notnull"`
+}
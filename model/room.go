@@ -1,6 +1,7 @@
 package model
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
@@ -11,10 +12,31 @@ import (
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
 
+// RoomJoinPolicy is how users join a room
+type RoomJoinPolicy int
+
+const (
+	RoomJoinPolicyDirectAdd RoomJoinPolicy = iota
+	RoomJoinPolicyInviteOnly
+	RoomJoinPolicyApproval
+)
+
+func (jp RoomJoinPolicy) isValid() bool {
+	return jp == RoomJoinPolicyDirectAdd || jp == RoomJoinPolicyInviteOnly || jp == RoomJoinPolicyApproval
+}
+
+// maxRateLimitSeconds is the longest slow mode interval and burst window of a room
+const maxRateLimitSeconds = 21600
+
 type Room struct {
 	scpb.Room
-	MetaData JSONText    `db:"meta_data"`
-	Users    []*MiniUser `db:"-"`
+	MetaData         JSONText       `db:"meta_data"`
+	JoinPolicy       RoomJoinPolicy `db:"join_policy,notnull"`
+	SlowModeInterval int32          `db:"slow_mode_interval,notnull"`
+	BurstLimit       int32          `db:"burst_limit,notnull"`
+	BurstWindow      int32          `db:"burst_window,notnull"`
+	Archived         int64          `db:"archived,notnull"`
+	Users            []*MiniUser    `db:"-"`
 }
 
 func (r *Room) MarshalJSON() ([]byte, error) {
@@ -23,6 +45,10 @@ func (r *Room) MarshalJSON() ([]byte, error) {
 	if r.LastMessageUpdatedTimestamp != 0 {
 		lmu = time.Unix(r.LastMessageUpdatedTimestamp, 0).In(l).Format(time.RFC3339)
 	}
+	archived := ""
+	if r.Archived != 0 {
+		archived = time.Unix(r.Archived, 0).In(l).Format(time.RFC3339)
+	}
 	return json.Marshal(&struct {
 		RoomID                string          `json:"roomId"`
 		UserID                string          `json:"userId"`
@@ -33,11 +59,16 @@ func (r *Room) MarshalJSON() ([]byte, error) {
 		CanLeft               bool            `json:"canLeft"`
 		SpeechMode            scpb.SpeechMode `json:"speechMode"`
 		MetaData              JSONText        `json:"metaData"`
+		JoinPolicy            RoomJoinPolicy  `json:"joinPolicy"`
+		SlowModeInterval      int32           `json:"slowModeInterval"`
+		BurstLimit            int32           `json:"burstLimit"`
+		BurstWindow           int32           `json:"burstWindow"`
 		AvailableMessageTypes string          `json:"availableMessageTypes"`
 		LastMessage           string          `json:"lastMessage"`
 		LastMessageUpdated    string          `json:"lastMessageUpdated"`
 		MessageCount          int64           `json:"messageCount"`
 		NotificationTopicID   string          `json:"notificationTopicId"`
+		Archived              string          `json:"archived,omitempty"`
 		Created               string          `json:"created"`
 		Modified              string          `json:"modified"`
 		Users                 []*MiniUser     `json:"users,omitempty"`
@@ -51,10 +82,15 @@ func (r *Room) MarshalJSON() ([]byte, error) {
 		CanLeft:               r.CanLeft,
 		SpeechMode:            r.SpeechMode,
 		MetaData:              r.MetaData,
+		JoinPolicy:            r.JoinPolicy,
+		SlowModeInterval:      r.SlowModeInterval,
+		BurstLimit:            r.BurstLimit,
+		BurstWindow:           r.BurstWindow,
 		AvailableMessageTypes: r.AvailableMessageTypes,
 		LastMessage:           r.LastMessage,
 		LastMessageUpdated:    lmu,
 		MessageCount:          r.MessageCount,
+		Archived:              archived,
 		Created:               time.Unix(r.CreatedTimestamp, 0).In(l).Format(time.RFC3339),
 		Modified:              time.Unix(r.ModifiedTimestamp, 0).In(l).Format(time.RFC3339),
 		Users:                 r.Users,
@@ -74,6 +110,47 @@ func (r *Room) ConvertToPbRoom() *scpb.Room {
 	return pbRoom
 }
 
+// IsRateLimited reports whether sending messages to the room is limited by slow mode or burst limit
+func (r *Room) IsRateLimited() bool {
+	return r.SlowModeInterval > 0 || r.BurstLimit > 0
+}
+
+// IsArchived reports whether the room is archived
+func (r *Room) IsArchived() bool {
+	return r.Archived != 0
+}
+
+// Archive archives the room. An archived room is read-only
+func (r *Room) Archive() {
+	nowTimestamp := time.Now().Unix()
+	r.Archived = nowTimestamp
+	r.ModifiedTimestamp = nowTimestamp
+}
+
+// Unarchive restores the archived room
+func (r *Room) Unarchive() {
+	r.Archived = 0
+	r.ModifiedTimestamp = time.Now().Unix()
+}
+
+// NonMemberUserIDs returns the user IDs which are not members of the room yet, without duplicates.
+// The room must be selected with its users
+func (r *Room) NonMemberUserIDs(userIDs []string) []string {
+	memberUserIDs := make(map[string]bool)
+	for _, user := range r.Users {
+		memberUserIDs[user.UserID] = true
+	}
+
+	nonMemberUserIDs := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !memberUserIDs[userID] {
+			nonMemberUserIDs = append(nonMemberUserIDs, userID)
+			memberUserIDs[userID] = true
+		}
+	}
+	return nonMemberUserIDs
+}
+
 func (r *Room) UpdateRoom(req *UpdateRoomRequest) {
 	// TODO
 	if req.Name != nil {
@@ -104,16 +181,33 @@ func (r *Room) UpdateRoom(req *UpdateRoomRequest) {
 		r.MetaData = req.MetaData
 	}
 
+	if req.JoinPolicy != nil {
+		r.JoinPolicy = *req.JoinPolicy
+	}
+
 	if req.AvailableMessageTypes != nil {
 		r.AvailableMessageTypes = *req.AvailableMessageTypes
 	}
 
+	if req.SlowModeInterval != nil {
+		r.SlowModeInterval = *req.SlowModeInterval
+	}
+
+	if req.BurstLimit != nil {
+		r.BurstLimit = *req.BurstLimit
+	}
+
+	if req.BurstWindow != nil {
+		r.BurstWindow = *req.BurstWindow
+	}
+
 	nowTimestamp := time.Now().Unix()
 	r.ModifiedTimestamp = nowTimestamp
 }
 
 type MiniUser struct {
 	scpb.MiniUser
+	PublicProfileScope scpb.PublicProfileScope `json:"-" db:"public_profile_scope"`
 }
 
 func (ufr *MiniUser) MarshalJSON() ([]byte, error) {
@@ -145,7 +239,8 @@ func (ufr *MiniUser) MarshalJSON() ([]byte, error) {
 
 type CreateRoomRequest struct {
 	scpb.CreateRoomRequest
-	MetaData JSONText `json:"metaData,omitempty" db:"meta_data"`
+	MetaData   JSONText        `json:"metaData,omitempty" db:"meta_data"`
+	JoinPolicy *RoomJoinPolicy `json:"joinPolicy,omitempty"`
 }
 
 func (r *CreateRoomRequest) Validate() *ErrorResponse {
@@ -220,6 +315,16 @@ func (r *CreateRoomRequest) Validate() *ErrorResponse {
 		return NewErrorResponse("Failed to create room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
 	}
 
+	if r.JoinPolicy != nil && !r.JoinPolicy.isValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "joinPolicy",
+				Reason: "joinPolicy is incorrect.",
+			},
+		}
+		return NewErrorResponse("Failed to create room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
 	// if r.SpeechMode != nil && !(*r.SpeechMode > 0 && *r.SpeechMode < SpeechModeEnd) {
 	// 	return &ProblemDetail{
 	// 		Message: "Invalid params",
@@ -289,6 +394,12 @@ func (crr *CreateRoomRequest) GenerateRoom() *Room {
 		r.MetaData = crr.MetaData
 	}
 
+	if crr.JoinPolicy == nil {
+		r.JoinPolicy = RoomJoinPolicyDirectAdd
+	} else {
+		r.JoinPolicy = *crr.JoinPolicy
+	}
+
 	if crr.AvailableMessageTypes == nil {
 		r.AvailableMessageTypes = ""
 	} else {
@@ -304,12 +415,15 @@ func (crr *CreateRoomRequest) GenerateRoom() *Room {
 }
 
 func (crr *CreateRoomRequest) GenerateRoomUsers() []*RoomUser {
+	nowTimestamp := time.Now().Unix()
 	rus := make([]*RoomUser, len(crr.UserIDs)+1)
 	me := &RoomUser{}
 	me.RoomID = *crr.RoomID
 	me.UserID = *crr.UserID
 	me.UnreadCount = int32(0)
 	me.Display = true
+	me.MemberRole = RoomMemberRoleOwner
+	me.Joined = nowTimestamp
 
 	rus[0] = me
 	for i := 0; i < len(crr.UserIDs); i++ {
@@ -318,13 +432,21 @@ func (crr *CreateRoomRequest) GenerateRoomUsers() []*RoomUser {
 		ru.UserID = crr.UserIDs[i]
 		ru.UnreadCount = int32(0)
 		ru.Display = true
+		ru.Joined = nowTimestamp
 		rus[i+1] = ru
 	}
 	return rus
 }
 
+// RetrieveRoomsRequest is a request of rooms
 type RetrieveRoomsRequest struct {
 	scpb.RetrieveRoomsRequest
+	Archived        *bool             `json:"archived,omitempty"`
+	MetaDataFilters []*MetaDataFilter `json:"metaDataFilters,omitempty"`
+}
+
+func (rrr *RetrieveRoomsRequest) Validate() *ErrorResponse {
+	return validateMetaDataFilters(rrr.MetaDataFilters, "Failed to get rooms.")
 }
 
 type RoomsResponse struct {
@@ -358,7 +480,11 @@ type RetrieveRoomRequest struct {
 
 type UpdateRoomRequest struct {
 	scpb.UpdateRoomRequest
-	MetaData JSONText `json:"metaData,omitempty" db:"meta_data"`
+	MetaData         JSONText        `json:"metaData,omitempty" db:"meta_data"`
+	JoinPolicy       *RoomJoinPolicy `json:"joinPolicy,omitempty"`
+	SlowModeInterval *int32          `json:"slowModeInterval,omitempty"`
+	BurstLimit       *int32          `json:"burstLimit,omitempty"`
+	BurstWindow      *int32          `json:"burstWindow,omitempty"`
 }
 
 func (uur *UpdateRoomRequest) Validate(room *Room) *ErrorResponse {
@@ -393,16 +519,100 @@ func (uur *UpdateRoomRequest) Validate(room *Room) *ErrorResponse {
 		return NewErrorResponse("Failed to update room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
 	}
 
+	if uur.JoinPolicy != nil && !uur.JoinPolicy.isValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "joinPolicy",
+				Reason: "joinPolicy is incorrect.",
+			},
+		}
+		return NewErrorResponse("Failed to update room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if uur.SlowModeInterval != nil && (*uur.SlowModeInterval < 0 || *uur.SlowModeInterval > maxRateLimitSeconds) {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "slowModeInterval",
+				Reason: fmt.Sprintf("slowModeInterval must be between 0 and %d seconds. 0 turns slow mode off.", maxRateLimitSeconds),
+			},
+		}
+		return NewErrorResponse("Failed to update room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if uur.BurstLimit != nil && *uur.BurstLimit < 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "burstLimit",
+				Reason: "burstLimit must be 0 or more. 0 turns burst limit off.",
+			},
+		}
+		return NewErrorResponse("Failed to update room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if uur.BurstWindow != nil && (*uur.BurstWindow < 0 || *uur.BurstWindow > maxRateLimitSeconds) {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "burstWindow",
+				Reason: fmt.Sprintf("burstWindow must be between 0 and %d seconds.", maxRateLimitSeconds),
+			},
+		}
+		return NewErrorResponse("Failed to update room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	burstLimit := room.BurstLimit
+	if uur.BurstLimit != nil {
+		burstLimit = *uur.BurstLimit
+	}
+	burstWindow := room.BurstWindow
+	if uur.BurstWindow != nil {
+		burstWindow = *uur.BurstWindow
+	}
+	if burstLimit > 0 && burstWindow == 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "burstWindow",
+				Reason: "burstWindow is required when burstLimit is set.",
+			},
+		}
+		return NewErrorResponse("Failed to update room.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
 	return nil
 }
 
+// RequiredPermissions returns the room permissions that the update requires
+func (uur *UpdateRoomRequest) RequiredPermissions(room *Room) []RoomPermission {
+	permissions := make([]RoomPermission, 0)
+
+	if uur.Name != nil && *uur.Name != room.Name {
+		permissions = append(permissions, RoomPermissionRenameRoom)
+	}
+
+	if uur.PictureURL != nil || uur.InformationURL != nil || uur.Type != nil ||
+		uur.CanLeft != nil || uur.SpeechMode != nil || uur.MetaData != nil ||
+		uur.JoinPolicy != nil || uur.AvailableMessageTypes != nil ||
+		uur.SlowModeInterval != nil || uur.BurstLimit != nil || uur.BurstWindow != nil {
+		permissions = append(permissions, RoomPermissionChangeSettings)
+	}
+
+	// userIds only adds members
+	if len(uur.UserIDs) > 0 {
+		permissions = append(permissions, RoomPermissionAddMembers)
+	}
+
+	return permissions
+}
+
 func (uur *UpdateRoomRequest) GenerateRoomUsers(room *Room) []*RoomUser {
+	nowTimestamp := time.Now().Unix()
 	rus := make([]*RoomUser, len(uur.UserIDs)+1)
 	me := &RoomUser{}
 	me.RoomID = room.RoomID
 	me.UserID = room.UserID
 	me.UnreadCount = int32(0)
 	me.Display = true
+	me.MemberRole = RoomMemberRoleOwner
+	me.Joined = nowTimestamp
 
 	rus[0] = me
 	for i := 0; i < len(uur.UserIDs); i++ {
@@ -411,11 +621,17 @@ func (uur *UpdateRoomRequest) GenerateRoomUsers(room *Room) []*RoomUser {
 		ru.UserID = uur.UserIDs[i]
 		ru.UnreadCount = int32(0)
 		ru.Display = true
+		ru.Joined = nowTimestamp
 		rus[i+1] = ru
 	}
 	return rus
 }
 
+type ArchiveRoomRequest struct {
+	RoomID   string `json:"roomId"`
+	Archived bool   `json:"-"`
+}
+
 type DeleteRoomRequest struct {
 	scpb.DeleteRoomRequest
 }
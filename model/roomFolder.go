@@ -0,0 +1,215 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// MiniRoomsSort is sort order of the room list of a user
+type MiniRoomsSort string
+
+const (
+	MiniRoomsSortLastMessage MiniRoomsSort = "lastMessage"
+	MiniRoomsSortUnreadFirst MiniRoomsSort = "unreadFirst"
+	MiniRoomsSortManual      MiniRoomsSort = "manual"
+)
+
+func (mrs MiniRoomsSort) IsValid() bool {
+	switch mrs {
+	case MiniRoomsSortLastMessage, MiniRoomsSortUnreadFirst, MiniRoomsSortManual:
+		return true
+	}
+	return false
+}
+
+// RoomFolder is a personal folder that groups rooms in the room list of a user
+type RoomFolder struct {
+	ID       uint64 `json:"-" db:"id"`
+	FolderID string `json:"folderId" db:"folder_id,notnull"`
+	UserID   string `json:"userId" db:"user_id,notnull"`
+	Name     string `json:"name" db:"name,notnull"`
+	Position int32  `json:"position" db:"position,notnull"`
+	Created  int64  `json:"created" db:"created,notnull"`
+	Modified int64  `json:"modified" db:"modified,notnull"`
+	Deleted  int64  `json:"-" db:"deleted,notnull"`
+}
+
+func (rf *RoomFolder) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		FolderID string `json:"folderId"`
+		UserID   string `json:"userId"`
+		Name     string `json:"name"`
+		Position int32  `json:"position"`
+		Created  string `json:"created"`
+		Modified string `json:"modified"`
+	}{
+		FolderID: rf.FolderID,
+		UserID:   rf.UserID,
+		Name:     rf.Name,
+		Position: rf.Position,
+		Created:  time.Unix(rf.Created, 0).In(l).Format(time.RFC3339),
+		Modified: time.Unix(rf.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+func (rf *RoomFolder) ConvertToPbRoomFolder() *scpb.RoomFolder {
+	return &scpb.RoomFolder{
+		FolderID: rf.FolderID,
+		UserID:   rf.UserID,
+		Name:     rf.Name,
+		Position: rf.Position,
+		Created:  rf.Created,
+		Modified: rf.Modified,
+	}
+}
+
+func (rf *RoomFolder) UpdateRoomFolder(req *UpdateRoomFolderRequest) {
+	if req.Name != nil {
+		rf.Name = *req.Name
+	}
+
+	if req.Position != nil {
+		rf.Position = *req.Position
+	}
+
+	rf.Modified = time.Now().Unix()
+}
+
+type CreateRoomFolderRequest struct {
+	UserID   string `json:"userId"`
+	Name     string `json:"name"`
+	Position int32  `json:"position,omitempty"`
+}
+
+func (crfr *CreateRoomFolderRequest) Validate() *ErrorResponse {
+	if crfr.Name == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "name",
+				Reason: "name is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to create room folder.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if len(crfr.Name) > 64 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "name",
+				Reason: "name is invalid. A string up to 64 symbols long.",
+			},
+		}
+		return NewErrorResponse("Failed to create room folder.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (crfr *CreateRoomFolderRequest) GenerateRoomFolder() *RoomFolder {
+	nowTimestamp := time.Now().Unix()
+
+	rf := &RoomFolder{}
+	rf.FolderID = utils.GenerateUUID()
+	rf.UserID = crfr.UserID
+	rf.Name = crfr.Name
+	rf.Position = crfr.Position
+	rf.Created = nowTimestamp
+	rf.Modified = nowTimestamp
+	return rf
+}
+
+type RetrieveRoomFoldersRequest struct {
+	UserID string `json:"userId"`
+}
+
+type RoomFoldersResponse struct {
+	RoomFolders []*RoomFolder `json:"roomFolders"`
+}
+
+func (rfr *RoomFoldersResponse) ConvertToPbRoomFolders() *scpb.RoomFoldersResponse {
+	roomFolders := make([]*scpb.RoomFolder, len(rfr.RoomFolders))
+	for i, rf := range rfr.RoomFolders {
+		roomFolders[i] = rf.ConvertToPbRoomFolder()
+	}
+
+	res := &scpb.RoomFoldersResponse{}
+	res.RoomFolders = roomFolders
+	return res
+}
+
+type UpdateRoomFolderRequest struct {
+	UserID   string  `json:"userId"`
+	FolderID string  `json:"folderId"`
+	Name     *string `json:"name,omitempty"`
+	Position *int32  `json:"position,omitempty"`
+}
+
+func (urfr *UpdateRoomFolderRequest) Validate() *ErrorResponse {
+	if urfr.Name != nil && *urfr.Name == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "name",
+				Reason: "name can not be empty.",
+			},
+		}
+		return NewErrorResponse("Failed to update room folder.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if urfr.Name != nil && len(*urfr.Name) > 64 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "name",
+				Reason: "name is invalid. A string up to 64 symbols long.",
+			},
+		}
+		return NewErrorResponse("Failed to update room folder.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+type DeleteRoomFolderRequest struct {
+	UserID   string `json:"userId"`
+	FolderID string `json:"folderId"`
+}
+
+type UpdateRoomUserPreferenceRequest struct {
+	RoomID   string  `json:"roomId"`
+	UserID   string  `json:"userId"`
+	Favorite *bool   `json:"favorite,omitempty"`
+	FolderID *string `json:"folderId,omitempty"`
+	Position *int32  `json:"position,omitempty"`
+}
+
+func (uruprr *UpdateRoomUserPreferenceRequest) Validate() *ErrorResponse {
+	if uruprr.Favorite == nil && uruprr.FolderID == nil && uruprr.Position == nil {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "favorite",
+				Reason: "One of favorite, folderId or position is required.",
+			},
+		}
+		return NewErrorResponse("Failed to update room preference.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (ru *RoomUser) UpdatePreference(req *UpdateRoomUserPreferenceRequest) {
+	if req.Favorite != nil {
+		ru.Favorite = *req.Favorite
+	}
+
+	if req.FolderID != nil {
+		ru.FolderID = *req.FolderID
+	}
+
+	if req.Position != nil {
+		ru.Position = *req.Position
+	}
+}
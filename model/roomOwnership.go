@@ -0,0 +1,70 @@
+package model
+
+import (
+	"net/http"
+	"time"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// RoomOwnershipTransferReason is the cause of a change of the room owner
+type RoomOwnershipTransferReason string
+
+const (
+	RoomOwnershipTransferReasonTransfer     RoomOwnershipTransferReason = "transfer"
+	RoomOwnershipTransferReasonOwnerLeft    RoomOwnershipTransferReason = "ownerLeft"
+	RoomOwnershipTransferReasonOwnerDeleted RoomOwnershipTransferReason = "ownerDeleted"
+)
+
+// PayloadOwnershipTransfer is payload of the system message posted when the room owner changes
+type PayloadOwnershipTransfer struct {
+	Reason              RoomOwnershipTransferReason `json:"reason"`
+	PreviousOwnerUserID string                      `json:"previousOwnerUserId"`
+	OwnerUserID         string                      `json:"ownerUserId"`
+}
+
+type TransferRoomOwnershipRequest struct {
+	RoomID string `json:"roomId"`
+	UserID string `json:"userId"`
+}
+
+func (tror *TransferRoomOwnershipRequest) Validate() *ErrorResponse {
+	if tror.UserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "userId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to transfer room ownership.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+// TransferOwnership changes the owner of the room
+func (r *Room) TransferOwnership(userID string) {
+	r.UserID = userID
+	r.ModifiedTimestamp = time.Now().Unix()
+}
+
+// ElectRoomOwnerSuccessor picks the member who takes over the room from the leaving owner.
+// The highest role wins and the longest-standing member breaks a tie. Members who joined
+// before the joined column was added have 0, so the user ID breaks the remaining ties
+func ElectRoomOwnerSuccessor(roomUsers []*RoomUser, ownerUserID string) *RoomUser {
+	var successor *RoomUser
+	for _, ru := range roomUsers {
+		if ru.UserID == ownerUserID {
+			continue
+		}
+
+		if successor == nil ||
+			ru.MemberRole > successor.MemberRole ||
+			(ru.MemberRole == successor.MemberRole && ru.Joined < successor.Joined) ||
+			(ru.MemberRole == successor.MemberRole && ru.Joined == successor.Joined && ru.UserID < successor.UserID) {
+			successor = ru
+		}
+	}
+
+	return successor
+}
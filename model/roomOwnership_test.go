@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+)
+
+const (
+	TestModelElectRoomOwnerSuccessor = "[model] ElectRoomOwnerSuccessor test"
+)
+
+func TestRoomOwnership(t *testing.T) {
+	t.Run(TestModelElectRoomOwnerSuccessor, func(t *testing.T) {
+		owner := &RoomUser{MemberRole: RoomMemberRoleOwner, Joined: 1}
+		owner.UserID = "model-user-id-0001"
+		member := &RoomUser{MemberRole: RoomMemberRoleMember, Joined: 2}
+		member.UserID = "model-user-id-0002"
+		newAdmin := &RoomUser{MemberRole: RoomMemberRoleAdmin, Joined: 4}
+		newAdmin.UserID = "model-user-id-0003"
+		oldAdmin := &RoomUser{MemberRole: RoomMemberRoleAdmin, Joined: 3}
+		oldAdmin.UserID = "model-user-id-0004"
+
+		successor := ElectRoomOwnerSuccessor([]*RoomUser{owner}, owner.UserID)
+		if successor != nil {
+			t.Fatalf("Failed to %s. Expected successor to be nil, but it was %s", TestModelElectRoomOwnerSuccessor, successor.UserID)
+		}
+
+		successor = ElectRoomOwnerSuccessor([]*RoomUser{owner, member}, owner.UserID)
+		if successor == nil || successor.UserID != member.UserID {
+			t.Fatalf("Failed to %s. Expected successor to be %s", TestModelElectRoomOwnerSuccessor, member.UserID)
+		}
+
+		successor = ElectRoomOwnerSuccessor([]*RoomUser{owner, member, newAdmin, oldAdmin}, owner.UserID)
+		if successor == nil || successor.UserID != oldAdmin.UserID {
+			t.Fatalf("Failed to %s. Expected successor to be %s", TestModelElectRoomOwnerSuccessor, oldAdmin.UserID)
+		}
+
+		// Members migrated from older versions have no joined timestamp
+		migratedMember1 := &RoomUser{MemberRole: RoomMemberRoleMember}
+		migratedMember1.UserID = "model-user-id-0005"
+		migratedMember2 := &RoomUser{MemberRole: RoomMemberRoleMember}
+		migratedMember2.UserID = "model-user-id-0006"
+		for _, roomUsers := range [][]*RoomUser{
+			[]*RoomUser{owner, migratedMember1, migratedMember2},
+			[]*RoomUser{owner, migratedMember2, migratedMember1},
+		} {
+			successor = ElectRoomOwnerSuccessor(roomUsers, owner.UserID)
+			if successor == nil || successor.UserID != migratedMember1.UserID {
+				t.Fatalf("Failed to %s. Expected successor to be %s", TestModelElectRoomOwnerSuccessor, migratedMember1.UserID)
+			}
+		}
+	})
+}
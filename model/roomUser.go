@@ -2,17 +2,89 @@ package model
 
 import (
 	"net/http"
+	"time"
 
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
 
+// RoomMemberRole is role of a member in a room
+type RoomMemberRole int32
+
+const (
+	RoomMemberRoleMember RoomMemberRole = iota
+	RoomMemberRoleModerator
+	RoomMemberRoleAdmin
+	RoomMemberRoleOwner
+)
+
+func (rmr RoomMemberRole) isValid() bool {
+	return rmr >= RoomMemberRoleMember && rmr <= RoomMemberRoleOwner
+}
+
+// Can reports whether the role is granted the permission
+func (rmr RoomMemberRole) Can(permission RoomPermission) bool {
+	required, ok := roomPermissionMatrix[permission]
+	if !ok {
+		return false
+	}
+	return rmr >= required
+}
+
+// RoomPermission is an operation in a room that requires a member role
+type RoomPermission int
+
+const (
+	RoomPermissionRenameRoom RoomPermission = iota + 1
+	RoomPermissionChangeSettings
+	RoomPermissionAddMembers
+	RoomPermissionRemoveMembers
+	RoomPermissionModerateMembers
+	RoomPermissionBypassRateLimits
+	RoomPermissionDeleteOthersMessages
+	RoomPermissionPinMessages
+	RoomPermissionChangeMemberRoles
+	RoomPermissionArchiveRoom
+	RoomPermissionManageInviteLinks
+	RoomPermissionDeleteRoom
+	RoomPermissionTransferOwnership
+)
+
+// roomPermissionMatrix is the lowest role granted each permission
+var roomPermissionMatrix = map[RoomPermission]RoomMemberRole{
+	RoomPermissionRenameRoom:           RoomMemberRoleAdmin,
+	RoomPermissionChangeSettings:       RoomMemberRoleAdmin,
+	RoomPermissionAddMembers:           RoomMemberRoleModerator,
+	RoomPermissionRemoveMembers:        RoomMemberRoleModerator,
+	RoomPermissionModerateMembers:      RoomMemberRoleModerator,
+	RoomPermissionBypassRateLimits:     RoomMemberRoleModerator,
+	RoomPermissionDeleteOthersMessages: RoomMemberRoleModerator,
+	RoomPermissionPinMessages:          RoomMemberRoleModerator,
+	RoomPermissionChangeMemberRoles:    RoomMemberRoleAdmin,
+	RoomPermissionArchiveRoom:          RoomMemberRoleAdmin,
+	RoomPermissionManageInviteLinks:    RoomMemberRoleAdmin,
+	RoomPermissionDeleteRoom:           RoomMemberRoleOwner,
+	RoomPermissionTransferOwnership:    RoomMemberRoleOwner,
+}
+
 type RoomUser struct {
 	scpb.RoomUser
+	MentionCount      int32                 `json:"mentionCount" db:"mention_count,notnull"`
+	MemberRole        RoomMemberRole        `json:"memberRole" db:"member_role,notnull"`
+	NotificationLevel RoomNotificationLevel `json:"notificationLevel" db:"notification_level,notnull"`
+	MutedUntil        int64                 `json:"mutedUntil" db:"muted_until,notnull"`
+	Favorite          bool                  `json:"favorite" db:"favorite,notnull"`
+	FolderID          string                `json:"folderId,omitempty" db:"folder_id,notnull"`
+	Position          int32                 `json:"position" db:"position,notnull"`
+	Joined            int64                 `json:"joined" db:"joined,notnull"`
 }
 
 func (ru *RoomUser) UpdateRoomUser(req *UpdateRoomUserRequest) {
 	if req.UnreadCount != nil {
 		ru.UnreadCount = *req.UnreadCount
+		// Mentions are read together with the messages
+		if ru.UnreadCount == 0 {
+			ru.MentionCount = 0
+		}
 	}
 
 	if req.Display != nil {
@@ -72,6 +144,7 @@ func (crur *AddRoomUsersRequest) Validate() *ErrorResponse {
 }
 
 func (crur *AddRoomUsersRequest) GenerateRoomUsers() []*RoomUser {
+	nowTimestamp := time.Now().Unix()
 	roomUsers := make([]*RoomUser, len(crur.UserIDs))
 	for i, userID := range crur.UserIDs {
 		ru := &RoomUser{}
@@ -79,6 +152,7 @@ func (crur *AddRoomUsersRequest) GenerateRoomUsers() []*RoomUser {
 		ru.UserID = userID
 		ru.UnreadCount = int32(0)
 		ru.Display = crur.Display
+		ru.Joined = nowTimestamp
 		roomUsers[i] = ru
 	}
 	return roomUsers
@@ -176,3 +250,43 @@ func (drur *DeleteRoomUsersRequest) Validate() *ErrorResponse {
 
 	return nil
 }
+
+type UpdateRoomUserRoleRequest struct {
+	RoomID     string          `json:"roomId"`
+	UserID     string          `json:"userId"`
+	MemberRole *RoomMemberRole `json:"memberRole"`
+}
+
+func (urrr *UpdateRoomUserRoleRequest) Validate() *ErrorResponse {
+	if urrr.MemberRole == nil {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "memberRole",
+				Reason: "memberRole is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to update room user role.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if !urrr.MemberRole.isValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "memberRole",
+				Reason: "memberRole is incorrect.",
+			},
+		}
+		return NewErrorResponse("Failed to update room user role.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if *urrr.MemberRole == RoomMemberRoleOwner {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "memberRole",
+				Reason: "memberRole can not be owner. A room has only one owner.",
+			},
+		}
+		return NewErrorResponse("Failed to update room user role.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
@@ -7,11 +7,13 @@ import (
 )
 
 const (
-	TestModelRoomUser               = "[model] UpdateRoomUser test"
-	TestModelAddRoomUsersRequest    = "[model] AddRoomUsersRequest test"
-	TestModelRoomUsersResponse      = "[model] RoomUsersResponse test"
-	TestModelRoomUserIdsResponse    = "[model] RoomUserIdsResponse test"
-	TestModelDeleteRoomUsersRequest = "[model] DeleteRoomUsersRequest test"
+	TestModelRoomUser                  = "[model] UpdateRoomUser test"
+	TestModelAddRoomUsersRequest       = "[model] AddRoomUsersRequest test"
+	TestModelRoomUsersResponse         = "[model] RoomUsersResponse test"
+	TestModelRoomUserIdsResponse       = "[model] RoomUserIdsResponse test"
+	TestModelDeleteRoomUsersRequest    = "[model] DeleteRoomUsersRequest test"
+	TestModelRoomMemberRole            = "[model] RoomMemberRole test"
+	TestModelUpdateRoomUserRoleRequest = "[model] UpdateRoomUserRoleRequest test"
 )
 
 func TestRoomUser(t *testing.T) {
@@ -225,4 +227,51 @@ func TestRoomUser(t *testing.T) {
 			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil. %s is invalid", TestModelDeleteRoomUsersRequest, errRes.InvalidParams[0].Name)
 		}
 	})
+
+	t.Run(TestModelRoomMemberRole, func(t *testing.T) {
+		if RoomMemberRoleMember.Can(RoomPermissionAddMembers) {
+			t.Fatalf("Failed to %s. Expected member not to be able to add members, but it was able", TestModelRoomMemberRole)
+		}
+		if !RoomMemberRoleModerator.Can(RoomPermissionAddMembers) {
+			t.Fatalf("Failed to %s. Expected moderator to be able to add members, but it was not able", TestModelRoomMemberRole)
+		}
+		if RoomMemberRoleModerator.Can(RoomPermissionRenameRoom) {
+			t.Fatalf("Failed to %s. Expected moderator not to be able to rename room, but it was able", TestModelRoomMemberRole)
+		}
+		if !RoomMemberRoleAdmin.Can(RoomPermissionChangeMemberRoles) {
+			t.Fatalf("Failed to %s. Expected admin to be able to change member roles, but it was not able", TestModelRoomMemberRole)
+		}
+		if RoomMemberRoleAdmin.Can(RoomPermissionDeleteRoom) {
+			t.Fatalf("Failed to %s. Expected admin not to be able to delete room, but it was able", TestModelRoomMemberRole)
+		}
+		if !RoomMemberRoleOwner.Can(RoomPermissionDeleteRoom) {
+			t.Fatalf("Failed to %s. Expected owner to be able to delete room, but it was not able", TestModelRoomMemberRole)
+		}
+	})
+
+	t.Run(TestModelUpdateRoomUserRoleRequest, func(t *testing.T) {
+		req := &UpdateRoomUserRoleRequest{}
+		req.RoomID = "model-room-id-0001"
+		req.UserID = "model-user-id-0001"
+		errRes := req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestModelUpdateRoomUserRoleRequest)
+		}
+		if errRes.InvalidParams[0].Name != "memberRole" {
+			t.Fatalf("Failed to %s. Expected errRes.InvalidParams[0].Name is \"memberRole\", but it was %s", TestModelUpdateRoomUserRoleRequest, errRes.InvalidParams[0].Name)
+		}
+
+		role := RoomMemberRoleOwner
+		req.MemberRole = &role
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestModelUpdateRoomUserRoleRequest)
+		}
+
+		role = RoomMemberRoleModerator
+		errRes = req.Validate()
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelUpdateRoomUserRoleRequest)
+		}
+	})
 }
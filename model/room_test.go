@@ -0,0 +1,39 @@
+package model
+
+import (
+	"net/http"
+	"testing"
+)
+
+const (
+	TestModelUpdateRoomRequestValidateBurstLimit = "[model] UpdateRoomRequest Validate burst limit test"
+)
+
+func TestUpdateRoomRequest(t *testing.T) {
+	t.Run(TestModelUpdateRoomRequestValidateBurstLimit, func(t *testing.T) {
+		burstLimit := int32(5)
+		burstWindow := int32(10)
+		noBurstWindow := int32(0)
+		room := &Room{}
+
+		req := &UpdateRoomRequest{BurstLimit: &burstLimit}
+		errRes := req.Validate(room)
+		if errRes == nil || errRes.Status != http.StatusBadRequest {
+			t.Fatalf("Failed to %s. Expected status to be 400 because burstWindow is not set", TestModelUpdateRoomRequestValidateBurstLimit)
+		}
+
+		req = &UpdateRoomRequest{BurstLimit: &burstLimit, BurstWindow: &burstWindow}
+		errRes = req.Validate(room)
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil [%s]", TestModelUpdateRoomRequestValidateBurstLimit, errRes.Message)
+		}
+
+		room.BurstLimit = burstLimit
+		room.BurstWindow = burstWindow
+		req = &UpdateRoomRequest{BurstWindow: &noBurstWindow}
+		errRes = req.Validate(room)
+		if errRes == nil || errRes.Status != http.StatusBadRequest {
+			t.Fatalf("Failed to %s. Expected status to be 400 because burstWindow is cleared while burstLimit is set", TestModelUpdateRoomRequestValidateBurstLimit)
+		}
+	})
+}
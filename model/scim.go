@@ -0,0 +1,884 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	ScimSchemaUser          = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup         = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaUserExtension = "urn:swagchat:params:scim:schemas:extension:2.0:User"
+	ScimSchemaListResponse  = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp       = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError         = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+const (
+	// ScimMetaDataKey is the key of metaData that keeps the SCIM attributes users and rooms do not have.
+	// Only the users and rooms that have this key are SCIM resources
+	ScimMetaDataKey = "scim"
+
+	// ScimMaxCount is the largest page size of a SCIM list
+	ScimMaxCount = 100
+)
+
+var (
+	scimFilterRegexp       = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9_.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+	scimMemberFilterRegexp = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*\]$`)
+)
+
+// ScimToken is a bearer token with which an identity provider calls the SCIM endpoints as an app client.
+// Only the hash of the token is stored
+type ScimToken struct {
+	ID        uint64 `json:"-" db:"id"`
+	TokenID   string `json:"tokenId" db:"token_id,notnull"`
+	ClientID  string `json:"clientId" db:"client_id,notnull"`
+	TokenHash string `json:"-" db:"token_hash,notnull"`
+	Token     string `json:"token,omitempty" db:"-"`
+	Created   int64  `json:"created" db:"created,notnull"`
+	Deleted   int64  `json:"-" db:"deleted,notnull"`
+}
+
+func (st *ScimToken) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		TokenID  string `json:"tokenId"`
+		ClientID string `json:"clientId"`
+		Token    string `json:"token,omitempty"`
+		Created  string `json:"created"`
+	}{
+		TokenID:  st.TokenID,
+		ClientID: st.ClientID,
+		Token:    st.Token,
+		Created:  time.Unix(st.Created, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+// NewScimToken issues a token of the app client. The token itself is returned only once
+func NewScimToken(clientID string) *ScimToken {
+	token := utils.GenerateClientID() + utils.GenerateClientID()
+
+	st := &ScimToken{}
+	st.TokenID = utils.GenerateUUID()
+	st.ClientID = clientID
+	st.TokenHash = HashScimToken(token)
+	st.Token = token
+	st.Created = time.Now().Unix()
+	return st
+}
+
+// HashScimToken returns the hash of a token that is stored instead of the token
+func HashScimToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type DeleteScimTokenRequest struct {
+	TokenID  string `json:"tokenId"`
+	ClientID string `json:"-"`
+}
+
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created"`
+	LastModified string `json:"lastModified"`
+}
+
+func newScimMeta(resourceType string, created, modified int64) *ScimMeta {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return &ScimMeta{
+		ResourceType: resourceType,
+		Created:      time.Unix(created, 0).In(l).Format(time.RFC3339),
+		LastModified: time.Unix(modified, 0).In(l).Format(time.RFC3339),
+	}
+}
+
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimUserExtension is the extension schema of the user attributes that SCIM does not define
+type ScimUserExtension struct {
+	MetaData JSONText `json:"metaData,omitempty"`
+}
+
+// scimUserMetaData is the SCIM attributes kept in metaData of a user
+type scimUserMetaData struct {
+	UserName   string `json:"userName"`
+	ExternalID string `json:"externalId,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimUser is a user resource of SCIM.
+// active is false only while the user is deactivated, because suspensions are not managed by identity providers
+type ScimUser struct {
+	Schemas           []string           `json:"schemas"`
+	ID                string             `json:"id,omitempty"`
+	ExternalID        string             `json:"externalId,omitempty"`
+	UserName          string             `json:"userName"`
+	Name              *ScimName          `json:"name,omitempty"`
+	DisplayName       string             `json:"displayName,omitempty"`
+	PreferredLanguage string             `json:"preferredLanguage,omitempty"`
+	Active            *bool              `json:"active,omitempty"`
+	Extension         *ScimUserExtension `json:"urn:swagchat:params:scim:schemas:extension:2.0:User,omitempty"`
+	Meta              *ScimMeta          `json:"meta,omitempty"`
+}
+
+func NewScimUser(user *User) *ScimUser {
+	var sumd scimUserMetaData
+	metaData := splitScimMetaData(user.MetaData, &sumd)
+	active := user.EffectiveStatus() != UserStatusDeactivated
+
+	su := &ScimUser{}
+	su.Schemas = []string{ScimSchemaUser, ScimSchemaUserExtension}
+	su.ID = user.UserID
+	su.ExternalID = sumd.ExternalID
+	su.UserName = sumd.UserName
+	su.Name = &ScimName{
+		Formatted:  user.Name,
+		GivenName:  sumd.GivenName,
+		FamilyName: sumd.FamilyName,
+	}
+	su.DisplayName = user.Name
+	su.PreferredLanguage = user.Lang
+	su.Active = &active
+	su.Extension = &ScimUserExtension{MetaData: metaData}
+	su.Meta = newScimMeta("User", user.CreatedTimestamp, user.ModifiedTimestamp)
+	return su
+}
+
+func (su *ScimUser) Validate() *ErrorResponse {
+	if su.UserName == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userName",
+				Reason: "userName is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to provision user.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if su.Extension != nil && su.Extension.MetaData != nil && !isJSON(su.Extension.MetaData.String()) {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   fmt.Sprintf("%s:metaData", ScimSchemaUserExtension),
+				Reason: "metaData is not json format.",
+			},
+		}
+		return NewErrorResponse("Failed to provision user.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+// userName returns the name of the user. It falls back on the parts of the name and then userName
+func (su *ScimUser) userName() string {
+	if su.DisplayName != "" {
+		return su.DisplayName
+	}
+	if su.Name != nil {
+		if su.Name.Formatted != "" {
+			return su.Name.Formatted
+		}
+		if name := strings.TrimSpace(fmt.Sprintf("%s %s", su.Name.GivenName, su.Name.FamilyName)); name != "" {
+			return name
+		}
+	}
+	return su.UserName
+}
+
+// generateMetaData makes metaData of the user.
+// metaData of the extension replaces the current one, which is kept if the extension is omitted
+func (su *ScimUser) generateMetaData(current JSONText) JSONText {
+	metaData := current
+	if su.Extension != nil && su.Extension.MetaData != nil {
+		metaData = su.Extension.MetaData
+	}
+
+	sumd := &scimUserMetaData{
+		UserName:   su.UserName,
+		ExternalID: su.ExternalID,
+	}
+	if su.Name != nil {
+		sumd.GivenName = su.Name.GivenName
+		sumd.FamilyName = su.Name.FamilyName
+	}
+	return mergeScimMetaData(metaData, sumd)
+}
+
+// Status returns the user status that active of the resource means
+func (su *ScimUser) Status() UserStatus {
+	if su.Active != nil && !*su.Active {
+		return UserStatusDeactivated
+	}
+	return UserStatusActive
+}
+
+func (su *ScimUser) GenerateCreateUserRequest() *CreateUserRequest {
+	name := su.userName()
+	lang := su.PreferredLanguage
+
+	cur := &CreateUserRequest{}
+	cur.Name = &name
+	cur.Lang = &lang
+	cur.MetaData = su.generateMetaData(nil)
+	return cur
+}
+
+func (su *ScimUser) GenerateUpdateUserRequest(user *User) *UpdateUserRequest {
+	name := su.userName()
+	lang := su.PreferredLanguage
+
+	uur := &UpdateUserRequest{}
+	uur.UserID = user.UserID
+	uur.Name = &name
+	uur.Lang = &lang
+	uur.MetaData = su.generateMetaData(user.MetaData)
+	return uur
+}
+
+// ApplyPatch applies the operations of a PATCH request to the resource
+func (su *ScimUser) ApplyPatch(req *ScimPatchRequest) *ErrorResponse {
+	for _, operation := range req.Operations {
+		attributes, errRes := operation.attributes()
+		if errRes != nil {
+			return errRes
+		}
+
+		for _, attribute := range attributes {
+			if operation.operator() == scimPatchOpRemove {
+				attribute.value = nil
+			}
+
+			errRes = su.setAttribute(attribute.path, attribute.value)
+			if errRes != nil {
+				return errRes
+			}
+		}
+	}
+
+	return nil
+}
+
+// setAttribute sets a value to the attribute of the path. A nil value clears the attribute
+func (su *ScimUser) setAttribute(path string, value json.RawMessage) *ErrorResponse {
+	var err error
+	switch strings.ToLower(path) {
+	case "username":
+		su.UserName, err = unmarshalScimString(value)
+	case "externalid":
+		su.ExternalID, err = unmarshalScimString(value)
+	case "displayname":
+		su.DisplayName, err = unmarshalScimString(value)
+	case "preferredlanguage":
+		su.PreferredLanguage, err = unmarshalScimString(value)
+	case "active":
+		su.Active = nil
+		if value != nil {
+			var active bool
+			active, err = unmarshalScimBool(value)
+			su.Active = &active
+		}
+	case "name":
+		su.Name = &ScimName{}
+		if value != nil {
+			err = json.Unmarshal(value, su.Name)
+		}
+	case "name.formatted", "name.givenname", "name.familyname":
+		if su.Name == nil {
+			su.Name = &ScimName{}
+		}
+		var s string
+		s, err = unmarshalScimString(value)
+		switch strings.ToLower(path) {
+		case "name.formatted":
+			su.Name.Formatted = s
+		case "name.givenname":
+			su.Name.GivenName = s
+		case "name.familyname":
+			su.Name.FamilyName = s
+		}
+	case strings.ToLower(ScimSchemaUserExtension):
+		su.Extension = &ScimUserExtension{MetaData: JSONText("{}")}
+		if value != nil {
+			err = json.Unmarshal(value, su.Extension)
+		}
+	case strings.ToLower(fmt.Sprintf("%s:metaData", ScimSchemaUserExtension)):
+		su.Extension = &ScimUserExtension{MetaData: JSONText("{}")}
+		if value != nil {
+			su.Extension.MetaData = JSONText(value)
+		}
+	default:
+		return scimPathErrorResponse("Failed to patch user.", path)
+	}
+
+	if err != nil {
+		return scimValueErrorResponse("Failed to patch user.", path)
+	}
+	return nil
+}
+
+// scimGroupMetaData is the SCIM attributes kept in metaData of a room
+type scimGroupMetaData struct {
+	DisplayName string `json:"displayName"`
+	ExternalID  string `json:"externalId,omitempty"`
+}
+
+type ScimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// ScimGroup is a group resource of SCIM. A group is a private room whose members are managed by the identity provider.
+// The room has no owner
+type ScimGroup struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []*ScimMember `json:"members"`
+	Meta        *ScimMeta     `json:"meta,omitempty"`
+}
+
+func NewScimGroup(room *Room) *ScimGroup {
+	var sgmd scimGroupMetaData
+	splitScimMetaData(room.MetaData, &sgmd)
+
+	sg := &ScimGroup{}
+	sg.Schemas = []string{ScimSchemaGroup}
+	sg.ID = room.RoomID
+	sg.ExternalID = sgmd.ExternalID
+	sg.DisplayName = sgmd.DisplayName
+	sg.Members = make([]*ScimMember, 0, len(room.Users))
+	for _, user := range room.Users {
+		sg.Members = append(sg.Members, &ScimMember{
+			Value:   user.UserID,
+			Display: user.Name,
+		})
+	}
+	sg.Meta = newScimMeta("Group", room.CreatedTimestamp, room.ModifiedTimestamp)
+	return sg
+}
+
+func (sg *ScimGroup) Validate() *ErrorResponse {
+	if sg.DisplayName == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "displayName",
+				Reason: "displayName is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to provision group.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	for _, member := range sg.Members {
+		if member == nil || !isValidID(member.Value) {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "members",
+					Reason: "value of members must be the id of a user.",
+				},
+			}
+			return NewErrorResponse("Failed to provision group.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+		}
+	}
+
+	return nil
+}
+
+// MemberUserIDs returns the user ids of the members without duplicates
+func (sg *ScimGroup) MemberUserIDs() []string {
+	userIDs := make([]string, len(sg.Members))
+	for i, member := range sg.Members {
+		userIDs[i] = member.Value
+	}
+	return utils.RemoveDuplicateString(userIDs)
+}
+
+func (sg *ScimGroup) generateMetaData(current JSONText) JSONText {
+	return mergeScimMetaData(current, &scimGroupMetaData{
+		DisplayName: sg.DisplayName,
+		ExternalID:  sg.ExternalID,
+	})
+}
+
+func (sg *ScimGroup) GenerateRoom() *Room {
+	r := &Room{}
+	r.RoomID = utils.GenerateUUID()
+	r.Name = sg.DisplayName
+	r.Type = scpb.RoomType_PrivateRoom
+	r.CanLeft = false
+	r.SpeechMode = scpb.SpeechMode_SpeechModeNone
+	r.MetaData = sg.generateMetaData(nil)
+	r.JoinPolicy = RoomJoinPolicyDirectAdd
+
+	nowTimestamp := time.Now().Unix()
+	r.LastMessageUpdatedTimestamp = nowTimestamp
+	r.CreatedTimestamp = nowTimestamp
+	r.ModifiedTimestamp = nowTimestamp
+	return r
+}
+
+// UpdateRoom sets the attributes of the resource to the room. Members are not changed
+func (sg *ScimGroup) UpdateRoom(room *Room) {
+	room.Name = sg.DisplayName
+	room.MetaData = sg.generateMetaData(room.MetaData)
+	room.ModifiedTimestamp = time.Now().Unix()
+}
+
+// ApplyPatch applies the operations of a PATCH request to the resource
+func (sg *ScimGroup) ApplyPatch(req *ScimPatchRequest) *ErrorResponse {
+	for _, operation := range req.Operations {
+		if matches := scimMemberFilterRegexp.FindStringSubmatch(operation.Path); matches != nil {
+			if operation.operator() != scimPatchOpRemove {
+				return scimPathErrorResponse("Failed to patch group.", operation.Path)
+			}
+			var userID string
+			if err := json.Unmarshal([]byte(matches[1]), &userID); err != nil {
+				return scimPathErrorResponse("Failed to patch group.", operation.Path)
+			}
+			sg.removeMembers([]*ScimMember{&ScimMember{Value: userID}})
+			continue
+		}
+
+		attributes, errRes := operation.attributes()
+		if errRes != nil {
+			return errRes
+		}
+
+		for _, attribute := range attributes {
+			errRes = sg.applyAttribute(operation.operator(), attribute.path, attribute.value)
+			if errRes != nil {
+				return errRes
+			}
+		}
+	}
+
+	return nil
+}
+
+func (sg *ScimGroup) applyAttribute(op, path string, value json.RawMessage) *ErrorResponse {
+	var err error
+	switch strings.ToLower(path) {
+	case "displayname":
+		if op == scimPatchOpRemove {
+			value = nil
+		}
+		sg.DisplayName, err = unmarshalScimString(value)
+	case "externalid":
+		if op == scimPatchOpRemove {
+			value = nil
+		}
+		sg.ExternalID, err = unmarshalScimString(value)
+	case "members":
+		var members []*ScimMember
+		if value != nil {
+			err = json.Unmarshal(value, &members)
+		}
+		if err != nil {
+			break
+		}
+		switch op {
+		case scimPatchOpAdd:
+			sg.Members = append(sg.Members, members...)
+		case scimPatchOpReplace:
+			sg.Members = members
+		case scimPatchOpRemove:
+			if value == nil {
+				sg.Members = nil
+			} else {
+				sg.removeMembers(members)
+			}
+		}
+	default:
+		return scimPathErrorResponse("Failed to patch group.", path)
+	}
+
+	if err != nil {
+		return scimValueErrorResponse("Failed to patch group.", path)
+	}
+	return nil
+}
+
+func (sg *ScimGroup) removeMembers(members []*ScimMember) {
+	removed := make(map[string]bool, len(members))
+	for _, member := range members {
+		if member != nil {
+			removed[member.Value] = true
+		}
+	}
+
+	remaining := make([]*ScimMember, 0, len(sg.Members))
+	for _, member := range sg.Members {
+		if !removed[member.Value] {
+			remaining = append(remaining, member)
+		}
+	}
+	sg.Members = remaining
+}
+
+const (
+	scimPatchOpAdd     = "add"
+	scimPatchOpReplace = "replace"
+	scimPatchOpRemove  = "remove"
+)
+
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// operator returns op in lower case. Some identity providers capitalize it
+func (spo *ScimPatchOperation) operator() string {
+	return strings.ToLower(spo.Op)
+}
+
+type scimPatchAttribute struct {
+	path  string
+	value json.RawMessage
+}
+
+// attributes returns the attributes the operation changes.
+// An operation without path changes each attribute of the value
+func (spo *ScimPatchOperation) attributes() ([]*scimPatchAttribute, *ErrorResponse) {
+	value := spo.Value
+	if string(value) == "null" {
+		value = nil
+	}
+
+	if spo.Path != "" {
+		return []*scimPatchAttribute{&scimPatchAttribute{path: spo.Path, value: value}}, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(value, &values); err != nil || spo.operator() == scimPatchOpRemove {
+		return nil, scimPathErrorResponse("Failed to patch.", "")
+	}
+
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	attributes := make([]*scimPatchAttribute, len(paths))
+	for i, path := range paths {
+		attributes[i] = &scimPatchAttribute{path: path, value: values[path]}
+	}
+	return attributes, nil
+}
+
+type ScimPatchRequest struct {
+	ID         string                `json:"-"`
+	Schemas    []string              `json:"schemas"`
+	Operations []*ScimPatchOperation `json:"Operations"`
+}
+
+func (spr *ScimPatchRequest) Validate() *ErrorResponse {
+	if len(spr.Operations) == 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "Operations",
+				Reason: "Operations is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to patch.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	for _, operation := range spr.Operations {
+		switch operation.operator() {
+		case scimPatchOpAdd, scimPatchOpReplace:
+			if len(operation.Value) == 0 {
+				invalidParams := []*scpb.InvalidParam{
+					&scpb.InvalidParam{
+						Name:   "Operations",
+						Reason: fmt.Sprintf("%s operation requires value.", operation.Op),
+					},
+				}
+				return NewErrorResponse("Failed to patch.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+			}
+		case scimPatchOpRemove:
+		default:
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "Operations",
+					Reason: "op is incorrect. Available operations are add, replace and remove.",
+				},
+			}
+			return NewErrorResponse("Failed to patch.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+		}
+	}
+
+	return nil
+}
+
+// ScimFilter is a filter of SCIM lists. Only the eq operator is supported
+type ScimFilter struct {
+	Attribute string
+	Value     string
+}
+
+// ParseScimFilter parses a filter such as userName eq "bjensen@example.com"
+func ParseScimFilter(filter string) (*ScimFilter, *ErrorResponse) {
+	matches := scimFilterRegexp.FindStringSubmatch(filter)
+	if matches == nil {
+		return nil, scimFilterErrorResponse("filter is incorrect. Only \"attribute eq \\\"value\\\"\" is supported.")
+	}
+
+	sf := &ScimFilter{}
+	sf.Attribute = matches[1]
+	if err := json.Unmarshal([]byte(matches[2]), &sf.Value); err != nil {
+		return nil, scimFilterErrorResponse("value of filter is incorrect.")
+	}
+	return sf, nil
+}
+
+// IsID reports whether the filter selects a resource by its id
+func (sf *ScimFilter) IsID() bool {
+	return strings.ToLower(sf.Attribute) == "id"
+}
+
+type RetrieveScimResourcesRequest struct {
+	Filter     *ScimFilter
+	StartIndex int32
+	Count      int32
+}
+
+func (rsrr *RetrieveScimResourcesRequest) Validate() *ErrorResponse {
+	if rsrr.StartIndex < 1 {
+		rsrr.StartIndex = 1
+	}
+	if rsrr.Count < 0 {
+		rsrr.Count = 0
+	}
+	if rsrr.Count > ScimMaxCount {
+		rsrr.Count = ScimMaxCount
+	}
+	return nil
+}
+
+func (rsrr *RetrieveScimResourcesRequest) Limit() int32 {
+	return rsrr.Count
+}
+
+func (rsrr *RetrieveScimResourcesRequest) Offset() int32 {
+	return rsrr.StartIndex - 1
+}
+
+// UserMetaDataFilters makes the metaData filters that select the users of the request
+func (rsrr *RetrieveScimResourcesRequest) UserMetaDataFilters() ([]*MetaDataFilter, *ErrorResponse) {
+	return rsrr.metaDataFilters(map[string]string{
+		"username":   "userName",
+		"externalid": "externalId",
+	})
+}
+
+// GroupMetaDataFilters makes the metaData filters that select the rooms of the request
+func (rsrr *RetrieveScimResourcesRequest) GroupMetaDataFilters() ([]*MetaDataFilter, *ErrorResponse) {
+	return rsrr.metaDataFilters(map[string]string{
+		"displayname": "displayName",
+		"externalid":  "externalId",
+	})
+}
+
+func (rsrr *RetrieveScimResourcesRequest) metaDataFilters(attributes map[string]string) ([]*MetaDataFilter, *ErrorResponse) {
+	filters := []*MetaDataFilter{
+		&MetaDataFilter{
+			Key:      ScimMetaDataKey,
+			Operator: MetaDataFilterOperatorExists,
+			Exists:   true,
+		},
+	}
+
+	if rsrr.Filter == nil {
+		return filters, nil
+	}
+
+	key, ok := attributes[strings.ToLower(rsrr.Filter.Attribute)]
+	if !ok {
+		return nil, scimFilterErrorResponse(fmt.Sprintf("%s can not be filtered.", rsrr.Filter.Attribute))
+	}
+
+	filters = append(filters, &MetaDataFilter{
+		Key:      fmt.Sprintf("%s.%s", ScimMetaDataKey, key),
+		Operator: MetaDataFilterOperatorEq,
+		Values:   []string{rsrr.Filter.Value},
+	})
+	return filters, nil
+}
+
+type RetrieveScimResourceRequest struct {
+	ID string `json:"id"`
+}
+
+type DeleteScimResourceRequest struct {
+	ID string `json:"id"`
+}
+
+type ScimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int32       `json:"startIndex"`
+	ItemsPerPage int32       `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+func NewScimListResponse(resources interface{}, itemsPerPage int, totalResults int64, startIndex int32) *ScimListResponse {
+	return &ScimListResponse{
+		Schemas:      []string{ScimSchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: int32(itemsPerPage),
+		Resources:    resources,
+	}
+}
+
+// ScimError is an error response of SCIM
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func NewScimError(errRes *ErrorResponse) *ScimError {
+	se := &ScimError{}
+	se.Schemas = []string{ScimSchemaError}
+	se.Status = strconv.Itoa(errRes.Status)
+	se.Detail = errRes.Message
+
+	if len(errRes.InvalidParams) > 0 {
+		invalidParam := errRes.InvalidParams[0]
+		se.Detail = strings.TrimSpace(fmt.Sprintf("%s %s", errRes.Message, invalidParam.Reason))
+		switch invalidParam.Name {
+		case "filter":
+			se.ScimType = "invalidFilter"
+		case "path":
+			se.ScimType = "invalidPath"
+		default:
+			se.ScimType = "invalidValue"
+		}
+	}
+
+	if errRes.Status == http.StatusConflict {
+		se.ScimType = "uniqueness"
+	}
+
+	return se
+}
+
+func scimFilterErrorResponse(reason string) *ErrorResponse {
+	invalidParams := []*scpb.InvalidParam{
+		&scpb.InvalidParam{
+			Name:   "filter",
+			Reason: reason,
+		},
+	}
+	return NewErrorResponse("Failed to filter.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+}
+
+func scimPathErrorResponse(message, path string) *ErrorResponse {
+	reason := fmt.Sprintf("%s is not supported.", path)
+	if path == "" {
+		reason = "An operation without path requires an object value."
+	}
+	invalidParams := []*scpb.InvalidParam{
+		&scpb.InvalidParam{
+			Name:   "path",
+			Reason: reason,
+		},
+	}
+	return NewErrorResponse(message, http.StatusBadRequest, WithInvalidParams(invalidParams))
+}
+
+func scimValueErrorResponse(message, path string) *ErrorResponse {
+	invalidParams := []*scpb.InvalidParam{
+		&scpb.InvalidParam{
+			Name:   path,
+			Reason: fmt.Sprintf("value of %s is incorrect.", path),
+		},
+	}
+	return NewErrorResponse(message, http.StatusBadRequest, WithInvalidParams(invalidParams))
+}
+
+// IsScimResource reports whether metaData belongs to a user or a room provisioned over SCIM
+func IsScimResource(metaData JSONText) bool {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(metaData, &values); err != nil {
+		return false
+	}
+	_, ok := values[ScimMetaDataKey]
+	return ok
+}
+
+// splitScimMetaData unmarshals the SCIM attributes of metaData to v and returns the rest of metaData
+func splitScimMetaData(metaData JSONText, v interface{}) JSONText {
+	values := make(map[string]json.RawMessage)
+	json.Unmarshal(metaData, &values)
+
+	if scim, ok := values[ScimMetaDataKey]; ok {
+		json.Unmarshal(scim, v)
+		delete(values, ScimMetaDataKey)
+	}
+
+	rest, err := json.Marshal(values)
+	if err != nil {
+		return JSONText("{}")
+	}
+	return JSONText(rest)
+}
+
+// mergeScimMetaData sets v to metaData as the SCIM attributes
+func mergeScimMetaData(metaData JSONText, v interface{}) JSONText {
+	values := make(map[string]interface{})
+	json.Unmarshal(metaData, &values)
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	values[ScimMetaDataKey] = v
+
+	merged, err := json.Marshal(values)
+	if err != nil {
+		return JSONText("{}")
+	}
+	return JSONText(merged)
+}
+
+func unmarshalScimString(value json.RawMessage) (string, error) {
+	var s string
+	if value == nil {
+		return s, nil
+	}
+	err := json.Unmarshal(value, &s)
+	return s, err
+}
+
+// unmarshalScimBool unmarshals a boolean. Some identity providers send it as a string
+func unmarshalScimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(s)
+}
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const (
+	TestModelParseScimFilter       = "[model] ParseScimFilter test"
+	TestModelScimUserApplyPatch    = "[model] ScimUser ApplyPatch test"
+	TestModelScimGroupApplyPatch   = "[model] ScimGroup ApplyPatch test"
+	TestModelScimUserMetaData      = "[model] ScimUser metaData test"
+	TestModelScimPatchRequestValid = "[model] ScimPatchRequest Validate test"
+)
+
+func TestScim(t *testing.T) {
+	t.Run(TestModelParseScimFilter, func(t *testing.T) {
+		filter, errRes := ParseScimFilter(`userName Eq "bjensen@example.com"`)
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelParseScimFilter)
+		}
+		if filter.Attribute != "userName" || filter.Value != "bjensen@example.com" {
+			t.Fatalf("Failed to %s. Expected filter to be userName and bjensen@example.com, but it was %s and %s", TestModelParseScimFilter, filter.Attribute, filter.Value)
+		}
+
+		filter, errRes = ParseScimFilter(`displayName eq "say \"hi\""`)
+		if errRes != nil || filter.Value != `say "hi"` {
+			t.Fatalf("Failed to %s. Expected escaped quotes to be unescaped", TestModelParseScimFilter)
+		}
+
+		_, errRes = ParseScimFilter(`userName sw "b"`)
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because sw is not supported", TestModelParseScimFilter)
+		}
+		if NewScimError(errRes).ScimType != "invalidFilter" {
+			t.Fatalf("Failed to %s. Expected scimType to be invalidFilter, but it was %s", TestModelParseScimFilter, NewScimError(errRes).ScimType)
+		}
+
+		req := &RetrieveScimResourcesRequest{Filter: &ScimFilter{Attribute: "nickName", Value: "b"}}
+		_, errRes = req.UserMetaDataFilters()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because nickName can not be filtered", TestModelParseScimFilter)
+		}
+	})
+
+	t.Run(TestModelScimUserMetaData, func(t *testing.T) {
+		su := &ScimUser{
+			UserName:   "bjensen@example.com",
+			ExternalID: "701984",
+			Name:       &ScimName{GivenName: "Barbara", FamilyName: "Jensen"},
+			Extension:  &ScimUserExtension{MetaData: JSONText(`{"team":"sales"}`)},
+		}
+		cur := su.GenerateCreateUserRequest()
+		if *cur.Name != "Barbara Jensen" {
+			t.Fatalf("Failed to %s. Expected name to be Barbara Jensen, but it was %s", TestModelScimUserMetaData, *cur.Name)
+		}
+		if !IsScimResource(cur.MetaData) {
+			t.Fatalf("Failed to %s. Expected metaData to have the scim key", TestModelScimUserMetaData)
+		}
+
+		user := &User{}
+		user.UserID = "user-id"
+		user.Name = *cur.Name
+		user.MetaData = cur.MetaData
+		user.Status = UserStatusSuspended
+
+		scimUser := NewScimUser(user)
+		if scimUser.UserName != su.UserName || scimUser.ExternalID != su.ExternalID || scimUser.Name.GivenName != "Barbara" {
+			t.Fatalf("Failed to %s. Expected the attributes to be restored from metaData", TestModelScimUserMetaData)
+		}
+		if scimUser.Extension.MetaData.String() != `{"team":"sales"}` {
+			t.Fatalf("Failed to %s. Expected metaData to be {\"team\":\"sales\"}, but it was %s", TestModelScimUserMetaData, scimUser.Extension.MetaData.String())
+		}
+		if !*scimUser.Active {
+			t.Fatalf("Failed to %s. Expected a suspended user to be active", TestModelScimUserMetaData)
+		}
+
+		su.Extension = nil
+		uur := su.GenerateUpdateUserRequest(user)
+		var metaData map[string]interface{}
+		json.Unmarshal(uur.MetaData, &metaData)
+		if metaData["team"] != "sales" {
+			t.Fatalf("Failed to %s. Expected metaData to be kept when the extension is omitted", TestModelScimUserMetaData)
+		}
+	})
+
+	t.Run(TestModelScimUserApplyPatch, func(t *testing.T) {
+		su := &ScimUser{UserName: "bjensen@example.com", DisplayName: "Babs"}
+		req := &ScimPatchRequest{}
+		json.Unmarshal([]byte(`{"Operations":[
+			{"op":"Replace","path":"active","value":"False"},
+			{"op":"replace","value":{"displayName":"Barbara","name.familyName":"Jensen"}},
+			{"op":"remove","path":"externalId"}
+		]}`), req)
+
+		errRes := req.Validate()
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelScimUserApplyPatch)
+		}
+
+		errRes = su.ApplyPatch(req)
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelScimUserApplyPatch)
+		}
+		if su.Status() != UserStatusDeactivated {
+			t.Fatalf("Failed to %s. Expected status to be %d, but it was %d", TestModelScimUserApplyPatch, UserStatusDeactivated, su.Status())
+		}
+		if su.DisplayName != "Barbara" || su.Name.FamilyName != "Jensen" {
+			t.Fatalf("Failed to %s. Expected displayName and familyName to be replaced", TestModelScimUserApplyPatch)
+		}
+
+		req = &ScimPatchRequest{Operations: []*ScimPatchOperation{&ScimPatchOperation{Op: "replace", Path: "nickName", Value: json.RawMessage(`"b"`)}}}
+		errRes = su.ApplyPatch(req)
+		if errRes == nil || NewScimError(errRes).ScimType != "invalidPath" {
+			t.Fatalf("Failed to %s. Expected an invalidPath error", TestModelScimUserApplyPatch)
+		}
+	})
+
+	t.Run(TestModelScimGroupApplyPatch, func(t *testing.T) {
+		sg := &ScimGroup{
+			DisplayName: "Sales",
+			Members:     []*ScimMember{&ScimMember{Value: "user-a"}, &ScimMember{Value: "user-b"}},
+		}
+		req := &ScimPatchRequest{}
+		json.Unmarshal([]byte(`{"Operations":[
+			{"op":"add","path":"members","value":[{"value":"user-c"},{"value":"user-a"}]},
+			{"op":"remove","path":"members[value eq \"user-b\"]"},
+			{"op":"replace","path":"displayName","value":"Sales Team"}
+		]}`), req)
+
+		errRes := sg.ApplyPatch(req)
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelScimGroupApplyPatch)
+		}
+
+		userIDs := sg.MemberUserIDs()
+		if len(userIDs) != 2 || userIDs[0] != "user-a" || userIDs[1] != "user-c" {
+			t.Fatalf("Failed to %s. Expected members to be [user-a user-c], but it was %v", TestModelScimGroupApplyPatch, userIDs)
+		}
+		if sg.DisplayName != "Sales Team" {
+			t.Fatalf("Failed to %s. Expected displayName to be Sales Team, but it was %s", TestModelScimGroupApplyPatch, sg.DisplayName)
+		}
+
+		req = &ScimPatchRequest{Operations: []*ScimPatchOperation{&ScimPatchOperation{Op: "remove", Path: "members"}}}
+		sg.ApplyPatch(req)
+		if len(sg.MemberUserIDs()) != 0 {
+			t.Fatalf("Failed to %s. Expected all members to be removed", TestModelScimGroupApplyPatch)
+		}
+	})
+
+	t.Run(TestModelScimPatchRequestValid, func(t *testing.T) {
+		req := &ScimPatchRequest{}
+		if req.Validate() == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because Operations is empty", TestModelScimPatchRequestValid)
+		}
+
+		req.Operations = []*ScimPatchOperation{&ScimPatchOperation{Op: "move", Path: "userName"}}
+		if req.Validate() == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because move is not an operation", TestModelScimPatchRequestValid)
+		}
+
+		req.Operations = []*ScimPatchOperation{&ScimPatchOperation{Op: "add", Path: "userName"}}
+		if req.Validate() == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because add requires value", TestModelScimPatchRequestValid)
+		}
+	})
+}
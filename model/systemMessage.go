@@ -0,0 +1,122 @@
+package model
+
+import (
+	"bytes"
+)
+
+// RoomChangeAction is the kind of change recorded by a system message
+type RoomChangeAction string
+
+const (
+	RoomChangeActionJoin           RoomChangeAction = "join"
+	RoomChangeActionAdd            RoomChangeAction = "add"
+	RoomChangeActionLeave          RoomChangeAction = "leave"
+	RoomChangeActionRemove         RoomChangeAction = "remove"
+	RoomChangeActionRename         RoomChangeAction = "rename"
+	RoomChangeActionChangePicture  RoomChangeAction = "changePicture"
+	RoomChangeActionChangeSettings RoomChangeAction = "changeSettings"
+)
+
+// PayloadRoomChange is payload of the system message posted for a membership or room change.
+// Clients render a localized string from the action and its parameters
+type PayloadRoomChange struct {
+	Action       RoomChangeAction `json:"action"`
+	ActorUserID  string           `json:"actorUserId,omitempty"`
+	UserIDs      []string         `json:"userIds,omitempty"`
+	Name         string           `json:"name,omitempty"`
+	PreviousName string           `json:"previousName,omitempty"`
+	PictureURL   string           `json:"pictureUrl,omitempty"`
+	Settings     []string         `json:"settings,omitempty"`
+}
+
+// IsSystemMessage reports whether the message is generated by the server
+func (m *Message) IsSystemMessage() bool {
+	return m.Type == MessageTypeUpdateRoomUser
+}
+
+// NewMembershipChange makes the payload of users joining or leaving the room.
+// A change made by one of the users themselves is a join or a leave, otherwise an add or a remove
+func NewMembershipChange(joined bool, actorUserID string, userIDs []string) *PayloadRoomChange {
+	self := actorUserID != "" && len(userIDs) == 1 && userIDs[0] == actorUserID
+
+	p := &PayloadRoomChange{
+		ActorUserID: actorUserID,
+		UserIDs:     userIDs,
+	}
+	switch {
+	case joined && self:
+		p.Action = RoomChangeActionJoin
+	case joined:
+		p.Action = RoomChangeActionAdd
+	case self:
+		p.Action = RoomChangeActionLeave
+	default:
+		p.Action = RoomChangeActionRemove
+	}
+
+	return p
+}
+
+// GenerateRoomChanges makes the payloads of the changes the request makes to the room.
+// It must be called before the request is applied to the room
+func (uur *UpdateRoomRequest) GenerateRoomChanges(room *Room, actorUserID string) []*PayloadRoomChange {
+	changes := make([]*PayloadRoomChange, 0)
+
+	if uur.Name != nil && *uur.Name != room.Name {
+		changes = append(changes, &PayloadRoomChange{
+			Action:       RoomChangeActionRename,
+			ActorUserID:  actorUserID,
+			Name:         *uur.Name,
+			PreviousName: room.Name,
+		})
+	}
+
+	if uur.PictureURL != nil && *uur.PictureURL != room.PictureURL {
+		changes = append(changes, &PayloadRoomChange{
+			Action:      RoomChangeActionChangePicture,
+			ActorUserID: actorUserID,
+			PictureURL:  *uur.PictureURL,
+		})
+	}
+
+	settings := make([]string, 0)
+	if uur.InformationURL != nil && *uur.InformationURL != room.InformationURL {
+		settings = append(settings, "informationUrl")
+	}
+	if uur.Type != nil && *uur.Type != room.Type {
+		settings = append(settings, "type")
+	}
+	if uur.CanLeft != nil && *uur.CanLeft != room.CanLeft {
+		settings = append(settings, "canLeft")
+	}
+	if uur.SpeechMode != nil && *uur.SpeechMode != room.SpeechMode {
+		settings = append(settings, "speechMode")
+	}
+	if uur.MetaData != nil && !bytes.Equal(uur.MetaData, room.MetaData) {
+		settings = append(settings, "metaData")
+	}
+	if uur.JoinPolicy != nil && *uur.JoinPolicy != room.JoinPolicy {
+		settings = append(settings, "joinPolicy")
+	}
+	if uur.AvailableMessageTypes != nil && *uur.AvailableMessageTypes != room.AvailableMessageTypes {
+		settings = append(settings, "availableMessageTypes")
+	}
+	if uur.SlowModeInterval != nil && *uur.SlowModeInterval != room.SlowModeInterval {
+		settings = append(settings, "slowModeInterval")
+	}
+	if uur.BurstLimit != nil && *uur.BurstLimit != room.BurstLimit {
+		settings = append(settings, "burstLimit")
+	}
+	if uur.BurstWindow != nil && *uur.BurstWindow != room.BurstWindow {
+		settings = append(settings, "burstWindow")
+	}
+	if len(settings) > 0 {
+		changes = append(changes, &PayloadRoomChange{
+			Action:      RoomChangeActionChangeSettings,
+			ActorUserID: actorUserID,
+			Settings:    settings,
+		})
+	}
+
+	return changes
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"testing"
+)
+
+const (
+	TestModelNewMembershipChange    = "[model] NewMembershipChange test"
+	TestModelGenerateRoomChanges    = "[model] UpdateRoomRequest GenerateRoomChanges test"
+	TestModelMessageIsSystemMessage = "[model] Message IsSystemMessage test"
+)
+
+func TestSystemMessage(t *testing.T) {
+	t.Run(TestModelNewMembershipChange, func(t *testing.T) {
+		p := NewMembershipChange(true, "model-user-id-0001", []string{"model-user-id-0001"})
+		if p.Action != RoomChangeActionJoin {
+			t.Fatalf("Failed to %s. Expected p.Action to be \"join\", but it was %s", TestModelNewMembershipChange, p.Action)
+		}
+
+		p = NewMembershipChange(true, "model-user-id-0001", []string{"model-user-id-0002"})
+		if p.Action != RoomChangeActionAdd {
+			t.Fatalf("Failed to %s. Expected p.Action to be \"add\", but it was %s", TestModelNewMembershipChange, p.Action)
+		}
+
+		p = NewMembershipChange(false, "model-user-id-0001", []string{"model-user-id-0001"})
+		if p.Action != RoomChangeActionLeave {
+			t.Fatalf("Failed to %s. Expected p.Action to be \"leave\", but it was %s", TestModelNewMembershipChange, p.Action)
+		}
+
+		p = NewMembershipChange(false, "", []string{"model-user-id-0001"})
+		if p.Action != RoomChangeActionRemove {
+			t.Fatalf("Failed to %s. Expected p.Action to be \"remove\", but it was %s", TestModelNewMembershipChange, p.Action)
+		}
+	})
+
+	t.Run(TestModelGenerateRoomChanges, func(t *testing.T) {
+		room := &Room{}
+		room.Name = "name"
+		room.PictureURL = "http://example.com/picture.png"
+
+		name := "name-update"
+		pictureURL := "http://example.com/picture.png"
+		slowModeInterval := int32(10)
+		req := &UpdateRoomRequest{}
+		req.Name = &name
+		req.PictureURL = &pictureURL
+		req.SlowModeInterval = &slowModeInterval
+
+		changes := req.GenerateRoomChanges(room, "model-user-id-0001")
+		if len(changes) != 2 {
+			t.Fatalf("Failed to %s. Expected changes count to be 2, but it was %d", TestModelGenerateRoomChanges, len(changes))
+		}
+		if changes[0].Action != RoomChangeActionRename || changes[0].PreviousName != "name" {
+			t.Fatalf("Failed to %s. Expected changes[0] to be a rename from \"name\"", TestModelGenerateRoomChanges)
+		}
+		if changes[1].Action != RoomChangeActionChangeSettings || changes[1].Settings[0] != "slowModeInterval" {
+			t.Fatalf("Failed to %s. Expected changes[1] to be a change of slowModeInterval", TestModelGenerateRoomChanges)
+		}
+	})
+
+	t.Run(TestModelMessageIsSystemMessage, func(t *testing.T) {
+		m := &Message{}
+		m.Type = MessageTypeUpdateRoomUser
+		if !m.IsSystemMessage() {
+			t.Fatalf("Failed to %s. Expected IsSystemMessage to be true, but it was false", TestModelMessageIsSystemMessage)
+		}
+
+		m.Type = MessageTypeText
+		if m.IsSystemMessage() {
+			t.Fatalf("Failed to %s. Expected IsSystemMessage to be false, but it was true", TestModelMessageIsSystemMessage)
+		}
+	})
+}
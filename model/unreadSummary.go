@@ -0,0 +1,76 @@
+package model
+
+import (
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// RoomUnreadCount is the unread and mention counts of a room for a user
+type RoomUnreadCount struct {
+	RoomID       string `json:"roomId" db:"room_id"`
+	UnreadCount  int64  `json:"unreadCount" db:"unread_count"`
+	MentionCount int64  `json:"mentionCount" db:"mention_count"`
+}
+
+// UserUnreadCount is the total unread count of a user across the rooms
+type UserUnreadCount struct {
+	UserID      string `db:"user_id"`
+	UnreadCount int64  `db:"unread_count"`
+}
+
+// UnreadSummary is the unread counts of a user across the rooms
+type UnreadSummary struct {
+	UserID          string             `json:"userId"`
+	UnreadCount     int64              `json:"unreadCount"`
+	MentionCount    int64              `json:"mentionCount"`
+	UnreadRoomCount int64              `json:"unreadRoomCount"`
+	Rooms           []*RoomUnreadCount `json:"rooms"`
+}
+
+// NewUnreadSummary totals the counts of the rooms that have unreads or mentions
+func NewUnreadSummary(userID string, roomUnreadCounts []*RoomUnreadCount) *UnreadSummary {
+	us := &UnreadSummary{
+		UserID: userID,
+		Rooms:  make([]*RoomUnreadCount, 0, len(roomUnreadCounts)),
+	}
+	for _, ruc := range roomUnreadCounts {
+		if ruc.UnreadCount == 0 && ruc.MentionCount == 0 {
+			continue
+		}
+
+		us.UnreadCount += ruc.UnreadCount
+		us.MentionCount += ruc.MentionCount
+		if ruc.UnreadCount > 0 {
+			us.UnreadRoomCount++
+		}
+		us.Rooms = append(us.Rooms, ruc)
+	}
+	return us
+}
+
+func (us *UnreadSummary) ConvertToPbUnreadSummary() *scpb.UnreadSummary {
+	rooms := make([]*scpb.RoomUnreadCount, len(us.Rooms))
+	for i, ruc := range us.Rooms {
+		rooms[i] = &scpb.RoomUnreadCount{
+			RoomID:       ruc.RoomID,
+			UnreadCount:  ruc.UnreadCount,
+			MentionCount: ruc.MentionCount,
+		}
+	}
+
+	return &scpb.UnreadSummary{
+		UserID:          us.UserID,
+		UnreadCount:     us.UnreadCount,
+		MentionCount:    us.MentionCount,
+		UnreadRoomCount: us.UnreadRoomCount,
+		Rooms:           rooms,
+	}
+}
+
+// Badge returns the number shown on the app icon
+func (us *UnreadSummary) Badge() int {
+	return int(us.UnreadCount)
+}
+
+type RetrieveUnreadSummaryRequest struct {
+	UserID string
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+)
+
+const (
+	TestModelNewUnreadSummary = "[model] NewUnreadSummary test"
+)
+
+func TestUnreadSummary(t *testing.T) {
+	t.Run(TestModelNewUnreadSummary, func(t *testing.T) {
+		roomUnreadCounts := []*RoomUnreadCount{
+			&RoomUnreadCount{RoomID: "room-a", UnreadCount: 3, MentionCount: 1},
+			&RoomUnreadCount{RoomID: "room-b", UnreadCount: 0, MentionCount: 0},
+			&RoomUnreadCount{RoomID: "room-c", UnreadCount: 5, MentionCount: 0},
+			&RoomUnreadCount{RoomID: "room-d", UnreadCount: 0, MentionCount: 2},
+		}
+		us := NewUnreadSummary("user-id", roomUnreadCounts)
+		if us.UnreadCount != 8 {
+			t.Fatalf("Failed to %s. Expected unreadCount to be 8, but it was %d", TestModelNewUnreadSummary, us.UnreadCount)
+		}
+		if us.MentionCount != 3 {
+			t.Fatalf("Failed to %s. Expected mentionCount to be 3, but it was %d", TestModelNewUnreadSummary, us.MentionCount)
+		}
+		if us.UnreadRoomCount != 2 {
+			t.Fatalf("Failed to %s. Expected unreadRoomCount to be 2, but it was %d", TestModelNewUnreadSummary, us.UnreadRoomCount)
+		}
+		if len(us.Rooms) != 3 {
+			t.Fatalf("Failed to %s. Expected rooms count to be 3, but it was %d", TestModelNewUnreadSummary, len(us.Rooms))
+		}
+		if us.Badge() != 8 {
+			t.Fatalf("Failed to %s. Expected badge to be 8, but it was %d", TestModelNewUnreadSummary, us.Badge())
+		}
+
+		us = NewUnreadSummary("user-id", nil)
+		if us.Rooms == nil || us.Badge() != 0 {
+			t.Fatalf("Failed to %s. Expected an empty summary", TestModelNewUnreadSummary)
+		}
+	})
+}
@@ -11,12 +11,24 @@ import (
 
 type User struct {
 	scpb.User
-	MetaData JSONText  `db:"meta_data"`
-	Devices  []*Device `db:"-"`
+	MetaData    JSONText  `db:"meta_data"`
+	DndStart    string    `db:"dnd_start"`
+	DndEnd      string    `db:"dnd_end"`
+	DndTimezone string    `db:"dnd_timezone"`
+	Devices     []*Device `db:"-"`
+
+	Status         UserStatus `db:"status,notnull"`
+	StatusReason   string     `db:"status_reason"`
+	StatusExpired  int64      `db:"status_expired,notnull"`
+	MessagesHidden bool       `db:"messages_hidden,notnull"`
 }
 
 func (u *User) MarshalJSON() ([]byte, error) {
 	l, _ := time.LoadLocation("Etc/GMT")
+	statusExpired := ""
+	if u.StatusExpired != 0 {
+		statusExpired = time.Unix(u.StatusExpired, 0).In(l).Format(time.RFC3339)
+	}
 
 	return json.Marshal(&struct {
 		UserID             string                  `json:"userId"`
@@ -36,6 +48,12 @@ func (u *User) MarshalJSON() ([]byte, error) {
 		BlockUsers         []string                `json:"blockUsers,omitempty"`
 		Devices            []*Device               `json:"devices,omitempty"`
 		Roles              []int32                 `json:"roles,omitempty"`
+		DndStart           string                  `json:"dndStart,omitempty"`
+		DndEnd             string                  `json:"dndEnd,omitempty"`
+		DndTimezone        string                  `json:"dndTimezone,omitempty"`
+		Status             UserStatus              `json:"status"`
+		StatusReason       string                  `json:"statusReason,omitempty"`
+		StatusExpired      string                  `json:"statusExpired,omitempty"`
 	}{
 		UserID:             u.UserID,
 		Name:               u.Name,
@@ -54,6 +72,12 @@ func (u *User) MarshalJSON() ([]byte, error) {
 		BlockUsers:         u.BlockUsers,
 		Devices:            u.Devices,
 		Roles:              u.Roles,
+		DndStart:           u.DndStart,
+		DndEnd:             u.DndEnd,
+		DndTimezone:        u.DndTimezone,
+		Status:             u.EffectiveStatus(),
+		StatusReason:       u.StatusReason,
+		StatusExpired:      statusExpired,
 	})
 }
 
@@ -140,8 +164,12 @@ func (u *User) UpdateUser(req *UpdateUserRequest) {
 
 type MiniRoom struct {
 	scpb.MiniRoom
-	MetaData JSONText    `json:"metaData" db:"meta_data"`
-	Users    []*MiniUser `json:"users,omitempty" db:"-"`
+	MetaData   JSONText    `json:"metaData" db:"meta_data"`
+	Archived   int64       `json:"archived" db:"archived"`
+	RuFavorite bool        `json:"ruFavorite" db:"ru_favorite"`
+	RuFolderID string      `json:"ruFolderId" db:"ru_folder_id"`
+	RuPosition int32       `json:"ruPosition" db:"ru_position"`
+	Users      []*MiniUser `json:"users,omitempty" db:"-"`
 }
 
 func (rfu *MiniRoom) MarshalJSON() ([]byte, error) {
@@ -150,6 +178,10 @@ func (rfu *MiniRoom) MarshalJSON() ([]byte, error) {
 	if rfu.LastMessageUpdatedTimestamp != 0 {
 		lmu = time.Unix(rfu.LastMessageUpdatedTimestamp, 0).In(l).Format(time.RFC3339)
 	}
+	archived := ""
+	if rfu.Archived != 0 {
+		archived = time.Unix(rfu.Archived, 0).In(l).Format(time.RFC3339)
+	}
 	return json.Marshal(&struct {
 		RoomID             string        `json:"roomId"`
 		UserID             string        `json:"userId"`
@@ -161,10 +193,14 @@ func (rfu *MiniRoom) MarshalJSON() ([]byte, error) {
 		LastMessage        string        `json:"lastMessage"`
 		LastMessageUpdated string        `json:"lastMessageUpdated"`
 		CanLeft            bool          `json:"canLeft,omitempty"`
+		Archived           string        `json:"archived,omitempty"`
 		Created            string        `json:"created"`
 		Modified           string        `json:"modified"`
 		Users              []*MiniUser   `json:"users"`
 		RuUnreadCount      int64         `json:"ruUnreadCount"`
+		RuFavorite         bool          `json:"ruFavorite"`
+		RuFolderID         string        `json:"ruFolderId,omitempty"`
+		RuPosition         int32         `json:"ruPosition"`
 	}{
 		RoomID:             rfu.RoomID,
 		UserID:             rfu.UserID,
@@ -176,10 +212,14 @@ func (rfu *MiniRoom) MarshalJSON() ([]byte, error) {
 		LastMessage:        rfu.LastMessage,
 		LastMessageUpdated: lmu,
 		CanLeft:            rfu.CanLeft,
+		Archived:           archived,
 		Created:            time.Unix(rfu.CreatedTimestamp, 0).In(l).Format(time.RFC3339),
 		Modified:           time.Unix(rfu.ModifiedTimestamp, 0).In(l).Format(time.RFC3339),
 		Users:              rfu.Users,
 		RuUnreadCount:      rfu.RuUnreadCount,
+		RuFavorite:         rfu.RuFavorite,
+		RuFolderID:         rfu.RuFolderID,
+		RuPosition:         rfu.RuPosition,
 	})
 }
 
@@ -245,7 +285,7 @@ func (cur *CreateUserRequest) Validate() *ErrorResponse {
 		return NewErrorResponse("Failed to create user.", http.StatusBadRequest, WithInvalidParams(invalidParams))
 	}
 
-	return nil
+	return validatePublicProfileScope(cur.PublicProfileScope, "Failed to create user.")
 }
 
 func (cur *CreateUserRequest) GenerateUser() *User {
@@ -300,6 +340,7 @@ func (cur *CreateUserRequest) GenerateUser() *User {
 	}
 
 	u.UnreadCount = uint64(0)
+	u.Status = UserStatusActive
 
 	nowTimestamp := time.Now().Unix()
 	u.LastAccessedTimestamp = nowTimestamp
@@ -349,8 +390,14 @@ func (u *User) DoPostProcessing() {
 	}
 }
 
+// RetrieveUsersRequest is a request of users
 type RetrieveUsersRequest struct {
 	scpb.RetrieveUsersRequest
+	MetaDataFilters []*MetaDataFilter `json:"metaDataFilters,omitempty"`
+}
+
+func (rur *RetrieveUsersRequest) Validate() *ErrorResponse {
+	return validateMetaDataFilters(rur.MetaDataFilters, "Failed to retrieve users.")
 }
 
 type UsersResponse struct {
@@ -429,7 +476,7 @@ func (uur *UpdateUserRequest) Validate() *ErrorResponse {
 		return NewErrorResponse("Failed to update user.", http.StatusBadRequest, WithInvalidParams(invalidParams))
 	}
 
-	return nil
+	return validatePublicProfileScope(uur.PublicProfileScope, "Failed to update user.")
 }
 
 func (uur *UpdateUserRequest) GenerateUserRoles() []*UserRole {
@@ -449,8 +496,18 @@ type DeleteUserRequest struct {
 	scpb.DeleteUserRequest
 }
 
+// RetrieveUserRoomsRequest is a request of the room list of a user
 type RetrieveUserRoomsRequest struct {
 	scpb.RetrieveUserRoomsRequest
+	Archived        bool              `json:"archived,omitempty"`
+	Favorite        *bool             `json:"favorite,omitempty"`
+	FolderID        string            `json:"folderId,omitempty"`
+	Sort            MiniRoomsSort     `json:"sort,omitempty"`
+	MetaDataFilters []*MetaDataFilter `json:"metaDataFilters,omitempty"`
+}
+
+func (rurr *RetrieveUserRoomsRequest) Validate() *ErrorResponse {
+	return validateMetaDataFilters(rurr.MetaDataFilters, "Failed to retrieve user rooms.")
 }
 
 type UserRoomsResponse struct {
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	// UserExportExpiration is seconds until the download link of an export expires
+	UserExportExpiration = 7 * 24 * 60 * 60
+)
+
+// UserExportStatus is status of user export
+type UserExportStatus int
+
+const (
+	UserExportStatusProcessing UserExportStatus = iota + 1
+	UserExportStatusCompleted
+	UserExportStatusFailed
+)
+
+type UserExport struct {
+	ID       uint64           `json:"-" db:"id"`
+	ExportID string           `json:"exportId" db:"export_id,notnull"`
+	UserID   string           `json:"userId" db:"user_id,notnull"`
+	Status   UserExportStatus `json:"status" db:"status,notnull"`
+	Filename string           `json:"-" db:"filename"`
+	Expired  int64            `json:"expired" db:"expired,notnull"`
+	Created  int64            `json:"created" db:"created,notnull"`
+	Modified int64            `json:"modified" db:"modified,notnull"`
+	Deleted  int64            `json:"-" db:"deleted,notnull"`
+}
+
+func (ue *UserExport) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	return json.Marshal(&struct {
+		ExportID string           `json:"exportId"`
+		UserID   string           `json:"userId"`
+		Status   UserExportStatus `json:"status"`
+		Expired  string           `json:"expired,omitempty"`
+		Created  string           `json:"created"`
+		Modified string           `json:"modified"`
+	}{
+		ExportID: ue.ExportID,
+		UserID:   ue.UserID,
+		Status:   ue.Status,
+		Expired:  ue.expiredString(l),
+		Created:  time.Unix(ue.Created, 0).In(l).Format(time.RFC3339),
+		Modified: time.Unix(ue.Modified, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+func (ue *UserExport) expiredString(l *time.Location) string {
+	if ue.Expired == 0 {
+		return ""
+	}
+	return time.Unix(ue.Expired, 0).In(l).Format(time.RFC3339)
+}
+
+// IsExpired reports whether the download link of the export has expired
+func (ue *UserExport) IsExpired() bool {
+	return ue.Expired != 0 && ue.Expired < time.Now().Unix()
+}
+
+// Complete marks the export as downloadable
+func (ue *UserExport) Complete(filename string) {
+	nowTimestamp := time.Now().Unix()
+	ue.Status = UserExportStatusCompleted
+	ue.Filename = filename
+	ue.Expired = nowTimestamp + UserExportExpiration
+	ue.Modified = nowTimestamp
+}
+
+// NotificationText returns the push text of the finished export
+func (ue *UserExport) NotificationText() string {
+	if ue.Status == UserExportStatusCompleted {
+		return "Your data export is ready to download"
+	}
+	return "Your data export failed"
+}
+
+// Fail marks the export as failed
+func (ue *UserExport) Fail() {
+	ue.Status = UserExportStatusFailed
+	ue.Modified = time.Now().Unix()
+}
+
+// UserExportArchive is the machine-readable archive of everything held about a user
+type UserExportArchive struct {
+	Exported  string      `json:"exported"`
+	User      *User       `json:"user"`
+	RoomUsers []*RoomUser `json:"roomUsers"`
+	Messages  []*Message  `json:"messages"`
+	Assets    []*Asset    `json:"assets"`
+}
+
+type CreateUserExportRequest struct {
+	UserID string `json:"userId"`
+}
+
+func (cuer *CreateUserExportRequest) Validate() *ErrorResponse {
+	if cuer.UserID == "" {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "userId is required, but it's empty.",
+			},
+		}
+		return NewErrorResponse("Failed to create user export.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (cuer *CreateUserExportRequest) GenerateUserExport() *UserExport {
+	ue := &UserExport{}
+	ue.ExportID = utils.GenerateUUID()
+	ue.UserID = cuer.UserID
+	ue.Status = UserExportStatusProcessing
+
+	nowTimestamp := time.Now().Unix()
+	ue.Created = nowTimestamp
+	ue.Modified = nowTimestamp
+
+	return ue
+}
+
+// UserExportFilename returns the storage filename of the export archive
+func UserExportFilename(exportID string) string {
+	return fmt.Sprintf("export-%s.json", exportID)
+}
+
+type RetrieveUserExportRequest struct {
+	UserID   string `json:"userId"`
+	ExportID string `json:"exportId"`
+}
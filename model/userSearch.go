@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const (
+	maxUserSearchQueryLength = 50
+	maxUserSearchLimit       = 100
+)
+
+// UserSearchMatch is how a search query is matched against user names
+type UserSearchMatch string
+
+const (
+	UserSearchMatchPrefix UserSearchMatch = "prefix"
+	UserSearchMatchFuzzy  UserSearchMatch = "fuzzy"
+)
+
+func (usm UserSearchMatch) IsValid() bool {
+	return usm == UserSearchMatchPrefix || usm == UserSearchMatchFuzzy
+}
+
+type SearchUsersRequest struct {
+	UserID          string            `json:"userId"`
+	Query           string            `json:"q"`
+	Match           UserSearchMatch   `json:"match,omitempty"`
+	MetaDataFilters []*MetaDataFilter `json:"metaDataFilters,omitempty"`
+	Limit           int32             `json:"limit"`
+	Offset          int32             `json:"offset"`
+}
+
+func (sur *SearchUsersRequest) Validate() *ErrorResponse {
+	if sur.Query == "" && len(sur.MetaDataFilters) == 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "q",
+				Reason: "q or metaData filter is required, but both are empty.",
+			},
+		}
+		return NewErrorResponse("Failed to search users.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if utf8.RuneCountInString(sur.Query) > maxUserSearchQueryLength {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "q",
+				Reason: fmt.Sprintf("q is invalid. A string up to %d symbols long.", maxUserSearchQueryLength),
+			},
+		}
+		return NewErrorResponse("Failed to search users.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if sur.Match != "" && !sur.Match.IsValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "match",
+				Reason: "match is incorrect. Available values are prefix and fuzzy.",
+			},
+		}
+		return NewErrorResponse("Failed to search users.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if sur.Limit < 1 || sur.Limit > maxUserSearchLimit {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "limit",
+				Reason: fmt.Sprintf("limit is invalid. Set a value from 1 to %d.", maxUserSearchLimit),
+			},
+		}
+		return NewErrorResponse("Failed to search users.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return validateMetaDataFilters(sur.MetaDataFilters, "Failed to search users.")
+}
+
+// SetDefaultParams sets the default match of the query
+func (sur *SearchUsersRequest) SetDefaultParams() {
+	if sur.Match == "" {
+		sur.Match = UserSearchMatchPrefix
+	}
+}
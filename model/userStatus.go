@@ -0,0 +1,183 @@
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/utils"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// UserStatus is status of a user account
+type UserStatus int
+
+const (
+	UserStatusActive UserStatus = iota + 1
+	UserStatusSuspended
+	UserStatusDeactivated
+)
+
+func (us UserStatus) IsValid() bool {
+	return us == UserStatusActive || us == UserStatusSuspended || us == UserStatusDeactivated
+}
+
+// EffectiveStatus returns the status in effect now.
+// A suspension ends at its expiry, and users created before statuses existed are active
+func (u *User) EffectiveStatus() UserStatus {
+	if u.Status == 0 {
+		return UserStatusActive
+	}
+	if u.IsSuspensionExpired() {
+		return UserStatusActive
+	}
+	return u.Status
+}
+
+// IsSuspensionExpired reports whether the user is still stored as suspended after the expiry of the suspension
+func (u *User) IsSuspensionExpired() bool {
+	return u.Status == UserStatusSuspended && u.StatusExpired != 0 && u.StatusExpired <= time.Now().Unix()
+}
+
+// IsAvailable reports whether the user is allowed to use the api
+func (u *User) IsAvailable() bool {
+	return u.EffectiveStatus() == UserStatusActive
+}
+
+// UpdateStatus sets the status of the request to the user
+func (u *User) UpdateStatus(req *UpdateUserStatusRequest) {
+	u.Status = req.Status
+	u.StatusReason = req.Reason
+	u.StatusExpired = req.expired()
+	u.MessagesHidden = req.HideMessages
+	u.ModifiedTimestamp = time.Now().Unix()
+}
+
+// UserStatusLog is an entry of the audit trail of user status changes
+type UserStatusLog struct {
+	ID             uint64     `json:"-" db:"id"`
+	LogID          string     `json:"logId" db:"log_id,notnull"`
+	UserID         string     `json:"userId" db:"user_id,notnull"`
+	Status         UserStatus `json:"status" db:"status,notnull"`
+	PreviousStatus UserStatus `json:"previousStatus" db:"previous_status,notnull"`
+	Reason         string     `json:"reason" db:"reason"`
+	Expired        int64      `json:"expired" db:"expired,notnull"`
+	MessagesHidden bool       `json:"messagesHidden" db:"messages_hidden,notnull"`
+	ClientID       string     `json:"clientId" db:"client_id,notnull"`
+	Created        int64      `json:"created" db:"created,notnull"`
+}
+
+func (usl *UserStatusLog) MarshalJSON() ([]byte, error) {
+	l, _ := time.LoadLocation("Etc/GMT")
+	expired := ""
+	if usl.Expired != 0 {
+		expired = time.Unix(usl.Expired, 0).In(l).Format(time.RFC3339)
+	}
+	return json.Marshal(&struct {
+		LogID          string     `json:"logId"`
+		UserID         string     `json:"userId"`
+		Status         UserStatus `json:"status"`
+		PreviousStatus UserStatus `json:"previousStatus"`
+		Reason         string     `json:"reason,omitempty"`
+		Expired        string     `json:"expired,omitempty"`
+		MessagesHidden bool       `json:"messagesHidden"`
+		ClientID       string     `json:"clientId"`
+		Created        string     `json:"created"`
+	}{
+		LogID:          usl.LogID,
+		UserID:         usl.UserID,
+		Status:         usl.Status,
+		PreviousStatus: usl.PreviousStatus,
+		Reason:         usl.Reason,
+		Expired:        expired,
+		MessagesHidden: usl.MessagesHidden,
+		ClientID:       usl.ClientID,
+		Created:        time.Unix(usl.Created, 0).In(l).Format(time.RFC3339),
+	})
+}
+
+type UpdateUserStatusRequest struct {
+	UserID       string     `json:"userId"`
+	Status       UserStatus `json:"status"`
+	Reason       string     `json:"reason,omitempty"`
+	Duration     int64      `json:"duration,omitempty"`
+	HideMessages bool       `json:"hideMessages,omitempty"`
+	ClientID     string     `json:"-"`
+}
+
+func (uusr *UpdateUserStatusRequest) Validate() *ErrorResponse {
+	if !uusr.Status.IsValid() {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "status",
+				Reason: "status is incorrect. Available values are 1 (active), 2 (suspended) and 3 (deactivated).",
+			},
+		}
+		return NewErrorResponse("Failed to update user status.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if uusr.Duration < 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "duration",
+				Reason: "duration must be 0 or more. 0 means no expiry.",
+			},
+		}
+		return NewErrorResponse("Failed to update user status.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if uusr.Duration != 0 && uusr.Status != UserStatusSuspended {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "duration",
+				Reason: "duration is available only for suspended.",
+			},
+		}
+		return NewErrorResponse("Failed to update user status.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	if uusr.HideMessages && uusr.Status == UserStatusActive {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "hideMessages",
+				Reason: "hideMessages is available only for suspended and deactivated.",
+			},
+		}
+		return NewErrorResponse("Failed to update user status.", http.StatusBadRequest, WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+func (uusr *UpdateUserStatusRequest) expired() int64 {
+	if uusr.Duration == 0 {
+		return 0
+	}
+	return time.Now().Unix() + uusr.Duration
+}
+
+func (uusr *UpdateUserStatusRequest) GenerateUserStatusLog(user *User, previousStatus UserStatus) *UserStatusLog {
+	usl := &UserStatusLog{}
+	usl.LogID = utils.GenerateUUID()
+	usl.UserID = user.UserID
+	usl.Status = user.Status
+	usl.PreviousStatus = previousStatus
+	usl.Reason = user.StatusReason
+	usl.Expired = user.StatusExpired
+	usl.MessagesHidden = user.MessagesHidden
+	usl.ClientID = uusr.ClientID
+	usl.Created = time.Now().Unix()
+	return usl
+}
+
+type RetrieveUserStatusLogsRequest struct {
+	UserID string `json:"userId"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+type UserStatusLogsResponse struct {
+	UserStatusLogs []*UserStatusLog `json:"userStatusLogs"`
+	Limit          int32            `json:"limit"`
+	Offset         int32            `json:"offset"`
+}
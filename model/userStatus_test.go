@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	TestModelUserEffectiveStatus             = "[model] User EffectiveStatus test"
+	TestModelUpdateUserStatusRequestValidate = "[model] UpdateUserStatusRequest Validate test"
+)
+
+func TestUserStatus(t *testing.T) {
+	t.Run(TestModelUserEffectiveStatus, func(t *testing.T) {
+		u := &User{}
+		if u.EffectiveStatus() != UserStatusActive {
+			t.Fatalf("Failed to %s. Expected status to be %d, but it was %d", TestModelUserEffectiveStatus, UserStatusActive, u.EffectiveStatus())
+		}
+
+		u.Status = UserStatusSuspended
+		if u.EffectiveStatus() != UserStatusSuspended {
+			t.Fatalf("Failed to %s. Expected status to be %d, but it was %d", TestModelUserEffectiveStatus, UserStatusSuspended, u.EffectiveStatus())
+		}
+
+		u.StatusExpired = time.Now().Unix() + 60
+		if u.IsAvailable() {
+			t.Fatalf("Failed to %s. Expected user not to be available", TestModelUserEffectiveStatus)
+		}
+
+		u.StatusExpired = time.Now().Unix() - 60
+		if !u.IsAvailable() {
+			t.Fatalf("Failed to %s. Expected user to be available after the suspension expired", TestModelUserEffectiveStatus)
+		}
+		if !u.IsSuspensionExpired() {
+			t.Fatalf("Failed to %s. Expected the suspension to be expired", TestModelUserEffectiveStatus)
+		}
+
+		u.Status = UserStatusDeactivated
+		if u.EffectiveStatus() != UserStatusDeactivated {
+			t.Fatalf("Failed to %s. Expected status to be %d, but it was %d", TestModelUserEffectiveStatus, UserStatusDeactivated, u.EffectiveStatus())
+		}
+	})
+
+	t.Run(TestModelUpdateUserStatusRequestValidate, func(t *testing.T) {
+		req := &UpdateUserStatusRequest{}
+		errRes := req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestModelUpdateUserStatusRequestValidate)
+		}
+
+		req.Status = UserStatusSuspended
+		req.Duration = 3600
+		req.HideMessages = true
+		errRes = req.Validate()
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestModelUpdateUserStatusRequestValidate)
+		}
+
+		req.Status = UserStatusDeactivated
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because duration is only for suspended", TestModelUpdateUserStatusRequestValidate)
+		}
+
+		req.Status = UserStatusActive
+		req.Duration = 0
+		errRes = req.Validate()
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil because hideMessages is not for active", TestModelUpdateUserStatusRequestValidate)
+		}
+	})
+}
@@ -20,6 +20,7 @@ type WebhookEventType int
 const (
 	WebhookEventTypeRoom WebhookEventType = iota + 1
 	WebhookEventTypeMessage
+	WebhookEventTypeUser
 )
 
 type Webhook struct {
@@ -244,6 +244,20 @@ func (ap *awssnsProvider) Unsubscribe(notificationSubscribeID string) Notificati
 }
 
 func (ap *awssnsProvider) Publish(notificationTopicID, roomID string, messageInfo *MessageInfo) NotificationChannel {
+	params := &sns.PublishInput{
+		TopicArn: aws.String(notificationTopicID),
+	}
+	return ap.publish(params, roomID, messageInfo)
+}
+
+func (ap *awssnsProvider) PublishToEndpoint(notificationDeviceID, roomID string, messageInfo *MessageInfo) NotificationChannel {
+	params := &sns.PublishInput{
+		TargetArn: aws.String(notificationDeviceID),
+	}
+	return ap.publish(params, roomID, messageInfo)
+}
+
+func (ap *awssnsProvider) publish(params *sns.PublishInput, roomID string, messageInfo *MessageInfo) NotificationChannel {
 	span := tracer.StartSpan(ap.ctx, "Publish", "notification")
 	defer tracer.Finish(span)
 
@@ -300,19 +314,16 @@ func (ap *awssnsProvider) Publish(notificationTopicID, roomID string, messageInf
 	}
 	message := string(pushData[:])
 
-	params := &sns.PublishInput{
-		Message:          aws.String(message),
-		MessageStructure: aws.String("json"),
-		Subject:          aws.String("subject"),
-		TopicArn:         aws.String(notificationTopicID),
-	}
+	params.Message = aws.String(message)
+	params.MessageStructure = aws.String("json")
+	params.Subject = aws.String("subject")
 	res, err := client.Publish(params)
 	if err != nil {
 		logger.Error(err.Error())
 		tracer.SetError(span, err)
 		return nil
 	}
-	logger.Info(fmt.Sprintf("[Amazon SNS]Publish message topicArn:%s message:%s response:%s", notificationTopicID, message, res.String()))
+	logger.Info(fmt.Sprintf("[Amazon SNS]Publish message topicArn:%s targetArn:%s message:%s response:%s", aws.StringValue(params.TopicArn), aws.StringValue(params.TargetArn), message, res.String()))
 
 	nc <- result
 
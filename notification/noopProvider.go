@@ -71,3 +71,11 @@ func (np *noopProvider) Publish(notificationTopicId, roomId string, messageInfo
 	notificationChannel <- result
 	return notificationChannel
 }
+
+func (np *noopProvider) PublishToEndpoint(notificationDeviceId, roomId string, messageInfo *MessageInfo) NotificationChannel {
+	notificationChannel := make(NotificationChannel, 1)
+	defer close(notificationChannel)
+	result := NotificationResult{}
+	notificationChannel <- result
+	return notificationChannel
+}
@@ -30,6 +30,7 @@ type provider interface {
 	Subscribe(string, string) NotificationChannel
 	Unsubscribe(string) NotificationChannel
 	Publish(string, string, *MessageInfo) NotificationChannel
+	PublishToEndpoint(string, string, *MessageInfo) NotificationChannel
 }
 
 func Provider(ctx context.Context) provider {
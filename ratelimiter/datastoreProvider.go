@@ -0,0 +1,96 @@
+package ratelimiter
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+)
+
+// maxRetries is how many times a request retries when other API instances update the same key at the same time
+const maxRetries = 5
+
+// datastoreProvider keeps counters in the datastore so that every API instance shares them
+type datastoreProvider struct {
+	ctx context.Context
+}
+
+func (dp *datastoreProvider) Take(key string, limit int32, period time.Duration) (time.Duration, error) {
+	for i := 0; i < maxRetries; i++ {
+		now := time.Now().UnixNano()
+
+		rateLimit, err := datastore.Provider(dp.ctx).SelectRateLimit(key)
+		if err != nil {
+			return 0, err
+		}
+
+		if rateLimit == nil {
+			tat, wait := gcra(now, 0, limit, period)
+			if wait > 0 {
+				return wait, nil
+			}
+
+			rateLimit = &model.RateLimit{}
+			rateLimit.LimitKey = key
+			rateLimit.Tat = tat
+			rateLimit.Modified = time.Now().Unix()
+			err = datastore.Provider(dp.ctx).InsertRateLimit(rateLimit)
+			if err == nil {
+				return 0, nil
+			}
+			// Another instance may have inserted the key first. Retry with its state
+			continue
+		}
+
+		previousTat := rateLimit.Tat
+		tat, wait := gcra(now, previousTat, limit, period)
+		if wait > 0 {
+			return wait, nil
+		}
+
+		rateLimit.Tat = tat
+		updated, err := datastore.Provider(dp.ctx).UpdateRateLimit(rateLimit, previousTat)
+		if err != nil {
+			return 0, err
+		}
+		if updated {
+			return 0, nil
+		}
+	}
+
+	return 0, errors.New("An error occurred while taking rate limit. Too many concurrent requests")
+}
+
+// TakeAll checks every limit before it counts the request against them.
+// A request of another instance between the check and the count may still be rejected by a limit after the previous ones are counted
+func (dp *datastoreProvider) TakeAll(limits []*Limit) (*Limit, time.Duration, error) {
+	now := time.Now().UnixNano()
+	for _, l := range limits {
+		rateLimit, err := datastore.Provider(dp.ctx).SelectRateLimit(l.Key)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var tat int64
+		if rateLimit != nil {
+			tat = rateLimit.Tat
+		}
+		if _, wait := gcra(now, tat, l.Limit, l.Period); wait > 0 {
+			return l, wait, nil
+		}
+	}
+
+	for _, l := range limits {
+		wait, err := dp.Take(l.Key, l.Limit, l.Period)
+		if err != nil {
+			return nil, 0, err
+		}
+		if wait > 0 {
+			return l, wait, nil
+		}
+	}
+
+	return nil, 0, nil
+}
@@ -0,0 +1,67 @@
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepThreshold is the number of keys that triggers removing keys whose limit has been restored
+const sweepThreshold = 10000
+
+type localStore struct {
+	mu   sync.Mutex
+	tats map[string]int64
+}
+
+var defaultLocalStore = newLocalStore()
+
+func newLocalStore() *localStore {
+	return &localStore{
+		tats: make(map[string]int64),
+	}
+}
+
+// localProvider keeps counters in process. Each API instance limits requests on its own
+type localProvider struct {
+	ctx   context.Context
+	store *localStore
+	now   func() time.Time
+}
+
+func (lp *localProvider) Take(key string, limit int32, period time.Duration) (time.Duration, error) {
+	_, wait, err := lp.TakeAll([]*Limit{&Limit{Key: key, Limit: limit, Period: period}})
+	return wait, err
+}
+
+func (lp *localProvider) TakeAll(limits []*Limit) (*Limit, time.Duration, error) {
+	now := time.Now().UnixNano()
+	if lp.now != nil {
+		now = lp.now().UnixNano()
+	}
+
+	lp.store.mu.Lock()
+	defer lp.store.mu.Unlock()
+
+	if len(lp.store.tats) > sweepThreshold {
+		for k, tat := range lp.store.tats {
+			if tat < now {
+				delete(lp.store.tats, k)
+			}
+		}
+	}
+
+	tats := make([]int64, len(limits))
+	for i, l := range limits {
+		tat, wait := gcra(now, lp.store.tats[l.Key], l.Limit, l.Period)
+		if wait > 0 {
+			return l, wait, nil
+		}
+		tats[i] = tat
+	}
+
+	for i, l := range limits {
+		lp.store.tats[l.Key] = tats[i]
+	}
+	return nil, 0, nil
+}
@@ -0,0 +1,63 @@
+package ratelimiter
+
+import (
+	"context"
+	"time"
+
+	"github.com/swagchat/chat-api/config"
+)
+
+// Limit is limit requests of the key per period
+type Limit struct {
+	Key    string
+	Limit  int32
+	Period time.Duration
+}
+
+type provider interface {
+	// Take counts a request of the key against limit requests per period.
+	// It returns 0 when the request is allowed, otherwise how long the caller has to wait
+	Take(key string, limit int32, period time.Duration) (time.Duration, error)
+	// TakeAll counts a request against every limit only if all of them allow it, so that a rejected request uses up none of them.
+	// It returns nil and 0 when the request is allowed, otherwise the first limit that rejects it and how long the caller has to wait
+	TakeAll(limits []*Limit) (*Limit, time.Duration, error)
+}
+
+func Provider(ctx context.Context) provider {
+	cfg := config.Config()
+
+	var p provider
+	switch cfg.RateLimiter.Provider {
+	case "datastore":
+		p = &datastoreProvider{
+			ctx: ctx,
+		}
+	default:
+		p = &localProvider{
+			ctx:   ctx,
+			store: defaultLocalStore,
+		}
+	}
+
+	return p
+}
+
+// gcra decides a request with the generic cell rate algorithm.
+// tat is the theoretical arrival time of the key. It returns the new tat and the wait time
+func gcra(now, tat int64, limit int32, period time.Duration) (int64, time.Duration) {
+	if limit <= 0 || period <= 0 {
+		return tat, 0
+	}
+
+	if tat < now {
+		tat = now
+	}
+
+	newTat := tat + int64(period)/int64(limit)
+	allowAt := newTat - int64(period)
+	if allowAt > now {
+		return tat, time.Duration(allowAt - now)
+	}
+
+	return newTat, 0
+}
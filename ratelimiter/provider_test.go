@@ -0,0 +1,125 @@
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+const (
+	TestRateLimiterSlowMode   = "[ratelimiter] slow mode test"
+	TestRateLimiterBurstLimit = "[ratelimiter] burst limit test"
+	TestRateLimiterTakeAll    = "[ratelimiter] take all test"
+)
+
+// fakeClock is a local stand-in of the wall clock so that tests do not sleep
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) Add(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+func newTestLocalProvider(clock *fakeClock) *localProvider {
+	return &localProvider{
+		ctx:   context.Background(),
+		store: newLocalStore(),
+		now:   clock.Now,
+	}
+}
+
+func TestLocalProvider(t *testing.T) {
+	t.Run(TestRateLimiterSlowMode, func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(1500000000, 0)}
+		p := newTestLocalProvider(clock)
+
+		wait, err := p.Take("slow:room-id-0001:user-id-0001", 1, 10*time.Second)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestRateLimiterSlowMode, err.Error())
+		}
+		if wait != 0 {
+			t.Fatalf("Failed to %s. Expected wait to be 0, but it was %s", TestRateLimiterSlowMode, wait)
+		}
+
+		clock.Add(4 * time.Second)
+		wait, _ = p.Take("slow:room-id-0001:user-id-0001", 1, 10*time.Second)
+		if wait != 6*time.Second {
+			t.Fatalf("Failed to %s. Expected wait to be 6s, but it was %s", TestRateLimiterSlowMode, wait)
+		}
+
+		wait, _ = p.Take("slow:room-id-0001:user-id-0002", 1, 10*time.Second)
+		if wait != 0 {
+			t.Fatalf("Failed to %s. Expected wait of another user to be 0, but it was %s", TestRateLimiterSlowMode, wait)
+		}
+
+		clock.Add(6 * time.Second)
+		wait, _ = p.Take("slow:room-id-0001:user-id-0001", 1, 10*time.Second)
+		if wait != 0 {
+			t.Fatalf("Failed to %s. Expected wait to be 0, but it was %s", TestRateLimiterSlowMode, wait)
+		}
+	})
+
+	t.Run(TestRateLimiterBurstLimit, func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(1500000000, 0)}
+		p := newTestLocalProvider(clock)
+
+		for i := 0; i < 3; i++ {
+			wait, _ := p.Take("burst:room-id-0001:user-id-0001", 3, 30*time.Second)
+			if wait != 0 {
+				t.Fatalf("Failed to %s. Expected wait of request %d to be 0, but it was %s", TestRateLimiterBurstLimit, i+1, wait)
+			}
+		}
+
+		wait, _ := p.Take("burst:room-id-0001:user-id-0001", 3, 30*time.Second)
+		if wait != 10*time.Second {
+			t.Fatalf("Failed to %s. Expected wait to be 10s, but it was %s", TestRateLimiterBurstLimit, wait)
+		}
+
+		clock.Add(10 * time.Second)
+		wait, _ = p.Take("burst:room-id-0001:user-id-0001", 3, 30*time.Second)
+		if wait != 0 {
+			t.Fatalf("Failed to %s. Expected wait to be 0, but it was %s", TestRateLimiterBurstLimit, wait)
+		}
+	})
+
+	t.Run(TestRateLimiterTakeAll, func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(1500000000, 0)}
+		p := newTestLocalProvider(clock)
+
+		burstLimit := &Limit{Key: "burst:room-id-0001:user-id-0001", Limit: 3, Period: 30 * time.Second}
+		slowModeLimit := &Limit{Key: "slow:room-id-0001:user-id-0001", Limit: 1, Period: 10 * time.Second}
+		limits := []*Limit{burstLimit, slowModeLimit}
+
+		rejectedLimit, wait, err := p.TakeAll(limits)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestRateLimiterTakeAll, err.Error())
+		}
+		if rejectedLimit != nil || wait != 0 {
+			t.Fatalf("Failed to %s. Expected the request to be allowed, but it was rejected by %s", TestRateLimiterTakeAll, rejectedLimit.Key)
+		}
+
+		clock.Add(1 * time.Second)
+		for i := 0; i < 5; i++ {
+			rejectedLimit, wait, _ = p.TakeAll(limits)
+			if rejectedLimit != slowModeLimit {
+				t.Fatalf("Failed to %s. Expected request %d to be rejected by slow mode, but it was not", TestRateLimiterTakeAll, i+1)
+			}
+			if wait != 9*time.Second {
+				t.Fatalf("Failed to %s. Expected wait to be 9s, but it was %s", TestRateLimiterTakeAll, wait)
+			}
+		}
+
+		// The rejected requests must not use up the burst limit
+		for i := 0; i < 2; i++ {
+			wait, _ = p.Take(burstLimit.Key, burstLimit.Limit, burstLimit.Period)
+			if wait != 0 {
+				t.Fatalf("Failed to %s. Expected wait of burst request %d to be 0, but it was %s", TestRateLimiterTakeAll, i+1, wait)
+			}
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setAppClientMux() {
+	mux.GetFunc("/appClients", commonHandler(adminAuthzHandler(getAppClients)))
+	mux.PostFunc("/appClients", commonHandler(adminAuthzHandler(postAppClient)))
+	mux.DeleteFunc("/appClients/:clientId", commonHandler(adminAuthzHandler(deleteAppClient)))
+	mux.PostFunc("/appClients/:clientId/secrets", commonHandler(adminAuthzHandler(postAppClientSecret)))
+
+	// The token endpoint is enabled only if access tokens can be signed
+	if config.Config().OAuth.SigningSecret != "" {
+		mux.PostFunc("/oauth/token", oauthHandler(postOAuthToken))
+	}
+}
+
+// oauthHandler is the handler chain of the token endpoint.
+// The client is authenticated with its credentials in the workspace of the X-Realm header
+func oauthHandler(fn http.HandlerFunc) http.HandlerFunc {
+	return (colsHandler(
+		tracer.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+
+				workspace := r.Header.Get(config.HeaderWorkspace)
+				ctx := context.WithValue(r.Context(), config.CtxWorkspace, workspace)
+				ctx = context.WithValue(ctx, config.CtxUserID, "")
+				ctx = context.WithValue(ctx, config.CtxClientID, "")
+				fn(w, r.WithContext(ctx))
+			})))
+}
+
+func getAppClients(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getAppClients", "rest")
+	defer tracer.Finish(span)
+
+	appClients, errRes := service.RetrieveAppClients(ctx)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", appClients)
+}
+
+func postAppClient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postAppClient", "rest")
+	defer tracer.Finish(span)
+
+	var req model.CreateAppClientRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	appClient, errRes := service.CreateAppClient(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respond(w, r, http.StatusCreated, "application/json", appClient)
+}
+
+func deleteAppClient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteAppClient", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RevokeAppClientRequest{}
+	req.ClientID = bone.GetValue(r, "clientId")
+
+	errRes := service.RevokeAppClient(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+func postAppClientSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postAppClientSecret", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RotateAppClientSecretRequest{}
+	req.ClientID = bone.GetValue(r, "clientId")
+
+	appClient, errRes := service.RotateAppClientSecret(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respond(w, r, http.StatusCreated, "application/json", appClient)
+}
+
+func postOAuthToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postOAuthToken", "rest")
+	defer tracer.Finish(span)
+
+	if err := r.ParseForm(); err != nil {
+		respondOAuthError(w, r, model.NewErrorResponse("The request body is not form-urlencoded.", http.StatusBadRequest))
+		return
+	}
+
+	req := &model.AccessTokenRequest{}
+	req.GrantType = r.PostForm.Get("grant_type")
+	req.ClientID = r.PostForm.Get("client_id")
+	req.ClientSecret = r.PostForm.Get("client_secret")
+
+	// The credentials of HTTP Basic authentication are form-urlencoded as described in RFC 6749
+	if username, password, ok := r.BasicAuth(); ok {
+		if req.ClientSecret != "" {
+			respondOAuthError(w, r, model.NewErrorResponse("Use only one method to authenticate the client.", http.StatusBadRequest))
+			return
+		}
+		clientID, err1 := url.QueryUnescape(username)
+		clientSecret, err2 := url.QueryUnescape(password)
+		if err1 != nil || err2 != nil {
+			respondOAuthError(w, r, model.NewErrorResponse("Client authentication failed.", http.StatusUnauthorized))
+			return
+		}
+		req.ClientID = clientID
+		req.ClientSecret = clientSecret
+	}
+
+	accessToken, errRes := service.IssueAccessToken(ctx, req)
+	if errRes != nil {
+		respondOAuthError(w, r, errRes)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	respond(w, r, http.StatusOK, "application/json", accessToken)
+}
+
+func respondOAuthError(w http.ResponseWriter, r *http.Request, errRes *model.ErrorResponse) {
+	if errRes.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=\"%s\"", config.AppName))
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	respond(w, r, errRes.Status, "application/json", model.NewOAuthError(errRes))
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setBulkImportMux() {
+	mux.PostFunc("/bulkImports", commonHandler(adminAuthzHandler(postBulkImport)))
+	mux.GetFunc("/bulkImports/#importId^[a-z0-9-]$", commonHandler(adminAuthzHandler(getBulkImport)))
+	mux.GetFunc("/bulkImports/#importId^[a-z0-9-]$/results", commonHandler(adminAuthzHandler(getBulkImportRowResults)))
+	mux.PostFunc("/bulkImports/#importId^[a-z0-9-]$/resume", commonHandler(adminAuthzHandler(postBulkImportResume)))
+}
+
+// postBulkImport takes the file to import as the request body.
+// The format is given by the format parameter, or by the content type when it is omitted
+func postBulkImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postBulkImport", "rest")
+	defer tracer.Finish(span)
+
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	req := &model.CreateBulkImportRequest{}
+	if kindArray, ok := params["kind"]; ok {
+		req.Kind = model.BulkImportKind(kindArray[0])
+	}
+	if formatArray, ok := params["format"]; ok {
+		req.Format = model.BulkImportFormat(formatArray[0])
+	} else if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		req.Format = model.BulkImportFormatCSV
+	} else {
+		req.Format = model.BulkImportFormatJSONL
+	}
+
+	req.Data, err = ioutil.ReadAll(r.Body)
+	if err != nil {
+		errRes := model.NewErrorResponse("Failed to create bulk import.", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	bulkImport, errRes := service.CreateBulkImport(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusAccepted, "application/json", bulkImport)
+}
+
+func getBulkImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getBulkImport", "rest")
+	defer tracer.Finish(span)
+
+	bulkImport, errRes := service.RetrieveBulkImport(ctx, bone.GetValue(r, "importId"))
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", bulkImport)
+}
+
+func getBulkImportRowResults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getBulkImportRowResults", "rest")
+	defer tracer.Finish(span)
+
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	limit, offset, _, _, _, errRes := setPagingParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	req := &model.RetrieveBulkImportRowResultsRequest{}
+	req.ImportID = bone.GetValue(r, "importId")
+	req.Limit = limit
+	req.Offset = offset
+
+	if failedOnlyArray, ok := params["failedOnly"]; ok {
+		req.FailedOnly = failedOnlyArray[0] == "true"
+	}
+
+	results, errRes := service.RetrieveBulkImportRowResults(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", results)
+}
+
+func postBulkImportResume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postBulkImportResume", "rest")
+	defer tracer.Finish(span)
+
+	bulkImport, errRes := service.ResumeBulkImport(ctx, bone.GetValue(r, "importId"))
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusAccepted, "application/json", bulkImport)
+}
@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setContactMux() {
+	mux.DeleteFunc("/users/#userId^[a-z0-9-]$/contacts/#contactUserId^[a-z0-9-]$", commonHandler(selfResourceAuthzHandler(deleteContact)))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/friendRequests", commonHandler(selfResourceAuthzHandler(postFriendRequest)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/friendRequests", commonHandler(selfResourceAuthzHandler(getFriendRequests)))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/friendRequests/#friendRequestId^[a-z0-9-]$/accept", commonHandler(selfResourceAuthzHandler(acceptFriendRequest)))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/friendRequests/#friendRequestId^[a-z0-9-]$/reject", commonHandler(selfResourceAuthzHandler(rejectFriendRequest)))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/friendRequests/#friendRequestId^[a-z0-9-]$/cancel", commonHandler(selfResourceAuthzHandler(cancelFriendRequest)))
+}
+
+func deleteContact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteContact", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.DeleteContactRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.ContactUserID = bone.GetValue(r, "contactUserId")
+
+	errRes := service.DeleteContact(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "application/json", nil)
+}
+
+func postFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postFriendRequest", "rest")
+	defer tracer.Finish(span)
+
+	var req model.CreateFriendRequestRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.UserID = bone.GetValue(r, "userId")
+
+	friendRequest, errRes := service.CreateFriendRequest(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, "application/json", friendRequest)
+}
+
+func getFriendRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getFriendRequests", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveFriendRequestsRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.Direction = model.FriendRequestDirectionIncoming
+
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	if directionArray, ok := params["direction"]; ok {
+		req.Direction = model.FriendRequestDirection(directionArray[0])
+	}
+
+	friendRequests, errRes := service.RetrieveFriendRequests(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", friendRequests)
+}
+
+func acceptFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "acceptFriendRequest", "rest")
+	defer tracer.Finish(span)
+
+	replyFriendRequest(w, r, model.FriendRequestStatusAccepted)
+}
+
+func rejectFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "rejectFriendRequest", "rest")
+	defer tracer.Finish(span)
+
+	replyFriendRequest(w, r, model.FriendRequestStatusRejected)
+}
+
+func cancelFriendRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "cancelFriendRequest", "rest")
+	defer tracer.Finish(span)
+
+	replyFriendRequest(w, r, model.FriendRequestStatusCanceled)
+}
+
+func replyFriendRequest(w http.ResponseWriter, r *http.Request, status model.FriendRequestStatus) {
+	req := &model.ReplyFriendRequestRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.FriendRequestID = bone.GetValue(r, "friendRequestId")
+	req.Status = status
+
+	friendRequest, errRes := service.ReplyFriendRequest(r.Context(), req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", friendRequest)
+}
@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setInvitationMux() {
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/invitations", commonHandler(roomMemberAuthzHandler(postInvitations)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/invitations", commonHandler(selfResourceAuthzHandler(getUserInvitations)))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/invitations/#invitationId^[a-z0-9-]$/accept", commonHandler(selfResourceAuthzHandler(acceptInvitation)))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/invitations/#invitationId^[a-z0-9-]$/decline", commonHandler(selfResourceAuthzHandler(declineInvitation)))
+}
+
+func postInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postInvitations", "rest")
+	defer tracer.Finish(span)
+
+	var req model.CreateInvitationsRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	if userID := ctx.Value(config.CtxUserID).(string); userID != "" {
+		req.InviterUserID = userID
+	}
+
+	invitations, errRes := service.CreateInvitations(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, "application/json", invitations)
+}
+
+func getUserInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getUserInvitations", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveUserInvitationsRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+
+	invitations, errRes := service.RetrieveUserInvitations(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", invitations)
+}
+
+func acceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "acceptInvitation", "rest")
+	defer tracer.Finish(span)
+
+	replyInvitation(w, r, model.InvitationStatusAccepted)
+}
+
+func declineInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "declineInvitation", "rest")
+	defer tracer.Finish(span)
+
+	replyInvitation(w, r, model.InvitationStatusDeclined)
+}
+
+func replyInvitation(w http.ResponseWriter, r *http.Request, status model.InvitationStatus) {
+	req := &model.ReplyInvitationRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.InvitationID = bone.GetValue(r, "invitationId")
+	req.Status = status
+
+	invitation, errRes := service.ReplyInvitation(r.Context(), req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", invitation)
+}
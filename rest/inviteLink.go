@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setInviteLinkMux() {
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/inviteLinks", commonHandler(roomMemberAuthzHandler(postInviteLink)))
+	mux.GetFunc("/rooms/#roomId^[a-z0-9-]$/inviteLinks", commonHandler(roomMemberAuthzHandler(getInviteLinks)))
+	mux.DeleteFunc("/rooms/#roomId^[a-z0-9-]$/inviteLinks/#token^[a-z0-9]$", commonHandler(roomMemberAuthzHandler(deleteInviteLink)))
+	mux.GetFunc("/rooms/#roomId^[a-z0-9-]$/inviteLinks/#token^[a-z0-9]$/uses", commonHandler(roomMemberAuthzHandler(getInviteLinkUses)))
+	mux.PostFunc("/invites/#token^[a-z0-9]$", commonHandler(postInviteRedeem))
+}
+
+func postInviteLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postInviteLink", "rest")
+	defer tracer.Finish(span)
+
+	var req model.CreateInviteLinkRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	if userID := ctx.Value(config.CtxUserID).(string); userID != "" {
+		req.CreatorUserID = userID
+	}
+
+	inviteLink, errRes := service.CreateInviteLink(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, "application/json", inviteLink)
+}
+
+func getInviteLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getInviteLinks", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveInviteLinksRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+
+	inviteLinks, errRes := service.RetrieveInviteLinks(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", inviteLinks)
+}
+
+func deleteInviteLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteInviteLink", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RevokeInviteLinkRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.Token = bone.GetValue(r, "token")
+
+	errRes := service.RevokeInviteLink(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+func getInviteLinkUses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getInviteLinkUses", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveInviteLinkUsesRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.Token = bone.GetValue(r, "token")
+
+	inviteLinkUses, errRes := service.RetrieveInviteLinkUses(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", inviteLinkUses)
+}
+
+func postInviteRedeem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postInviteRedeem", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RedeemInviteLinkRequest{}
+	req.Token = bone.GetValue(r, "token")
+	if userID := ctx.Value(config.CtxUserID).(string); userID != "" {
+		req.UserID = userID
+	}
+
+	roomUser, errRes := service.RedeemInviteLink(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", roomUser)
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setModerationMux() {
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/kick", commonHandler(roomMemberAuthzHandler(postRoomUserKick)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/ban", commonHandler(roomMemberAuthzHandler(postRoomUserBan)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/unban", commonHandler(roomMemberAuthzHandler(postRoomUserUnban)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/mute", commonHandler(roomMemberAuthzHandler(postRoomUserMute)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/unmute", commonHandler(roomMemberAuthzHandler(postRoomUserUnmute)))
+	mux.GetFunc("/rooms/#roomId^[a-z0-9-]$/moderationLogs", commonHandler(roomMemberAuthzHandler(getModerationLogs)))
+}
+
+func postRoomUserKick(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomUserKick", "rest")
+	defer tracer.Finish(span)
+
+	moderateRoomUser(w, r, service.KickRoomUser)
+}
+
+func postRoomUserBan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomUserBan", "rest")
+	defer tracer.Finish(span)
+
+	moderateRoomUser(w, r, service.BanRoomUser)
+}
+
+func postRoomUserUnban(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomUserUnban", "rest")
+	defer tracer.Finish(span)
+
+	moderateRoomUser(w, r, service.UnbanRoomUser)
+}
+
+func postRoomUserMute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomUserMute", "rest")
+	defer tracer.Finish(span)
+
+	moderateRoomUser(w, r, service.MuteRoomUser)
+}
+
+func postRoomUserUnmute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomUserUnmute", "rest")
+	defer tracer.Finish(span)
+
+	moderateRoomUser(w, r, service.UnmuteRoomUser)
+}
+
+func moderateRoomUser(w http.ResponseWriter, r *http.Request, moderate func(context.Context, *model.ModerateRoomUserRequest) *model.ErrorResponse) {
+	ctx := r.Context()
+
+	var req model.ModerateRoomUserRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.UserID = bone.GetValue(r, "userId")
+	if userID := ctx.Value(config.CtxUserID).(string); userID != "" {
+		req.ModeratorUserID = userID
+	}
+
+	errRes := moderate(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+func getModerationLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getModerationLogs", "rest")
+	defer tracer.Finish(span)
+
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	limit, offset, _, _, _, errRes := setPagingParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	req := &model.RetrieveModerationLogsRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.Limit = limit
+	req.Offset = offset
+
+	if userIDArray, ok := params["userId"]; ok {
+		req.UserID = userIDArray[0]
+	}
+
+	moderationLogs, errRes := service.RetrieveModerationLogs(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", moderationLogs)
+}
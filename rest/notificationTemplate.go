@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setNotificationTemplateMux() {
+	mux.GetFunc("/notificationTemplates", commonHandler(adminAuthzHandler(getNotificationTemplates)))
+	mux.PutFunc("/notificationTemplates/:messageType/:lang", commonHandler(adminAuthzHandler(putNotificationTemplate)))
+	mux.DeleteFunc("/notificationTemplates/:messageType/:lang", commonHandler(adminAuthzHandler(deleteNotificationTemplate)))
+}
+
+func getNotificationTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getNotificationTemplates", "rest")
+	defer tracer.Finish(span)
+
+	notificationTemplates, errRes := service.RetrieveNotificationTemplates(ctx)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", notificationTemplates)
+}
+
+func putNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putNotificationTemplate", "rest")
+	defer tracer.Finish(span)
+
+	var req model.PutNotificationTemplateRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.MessageType = bone.GetValue(r, "messageType")
+	req.Lang = bone.GetValue(r, "lang")
+
+	notificationTemplate, errRes := service.PutNotificationTemplate(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", notificationTemplate)
+}
+
+func deleteNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteNotificationTemplate", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.DeleteNotificationTemplateRequest{}
+	req.MessageType = bone.GetValue(r, "messageType")
+	req.Lang = bone.GetValue(r, "lang")
+
+	errRes := service.DeleteNotificationTemplate(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
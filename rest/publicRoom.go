@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+func setPublicRoomMux() {
+	mux.GetFunc("/rooms/public", commonHandler(getPublicRooms))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/join", commonHandler(postRoomJoin))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/leave", commonHandler(roomMemberAuthzHandler(postRoomLeave)))
+	mux.GetFunc("/rooms/#roomId^[a-z0-9-]$/joinRequests", commonHandler(roomMemberAuthzHandler(getJoinRequests)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/joinRequests/#joinRequestId^[a-z0-9-]$/approve", commonHandler(roomMemberAuthzHandler(approveJoinRequest)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/joinRequests/#joinRequestId^[a-z0-9-]$/reject", commonHandler(roomMemberAuthzHandler(rejectJoinRequest)))
+}
+
+func getPublicRooms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getPublicRooms", "rest")
+	defer tracer.Finish(span)
+
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	limit, offset, _, _, _, errRes := setPagingParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	req := &model.RetrievePublicRoomsRequest{}
+	req.Limit = limit
+	req.Offset = offset
+
+	if nameArray, ok := params["name"]; ok {
+		req.Name = nameArray[0]
+	}
+
+	if tagsArray, ok := params["tags"]; ok && tagsArray[0] != "" {
+		req.Tags = strings.Split(tagsArray[0], ",")
+	}
+
+	if sortArray, ok := params["sort"]; ok {
+		switch sortArray[0] {
+		case "activity":
+			req.Sort = model.PublicRoomsSortActivity
+		case "memberCount":
+			req.Sort = model.PublicRoomsSortMemberCount
+		default:
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "sort",
+					Reason: "sort is incorrect. Available values are activity and memberCount.",
+				},
+			}
+			errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+			respondError(w, r, errRes)
+			return
+		}
+	}
+
+	rooms, errRes := service.RetrievePublicRooms(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", rooms)
+}
+
+func postRoomJoin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomJoin", "rest")
+	defer tracer.Finish(span)
+
+	var req model.JoinRoomRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	if userID := ctx.Value(config.CtxUserID).(string); userID != "" {
+		req.UserID = userID
+	}
+
+	joinRequest, errRes := service.JoinRoom(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	if joinRequest != nil {
+		respond(w, r, http.StatusAccepted, "application/json", joinRequest)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+func postRoomLeave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomLeave", "rest")
+	defer tracer.Finish(span)
+
+	var req model.LeaveRoomRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	if userID := ctx.Value(config.CtxUserID).(string); userID != "" {
+		req.UserID = userID
+	}
+
+	errRes := service.LeaveRoom(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+func getJoinRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getJoinRequests", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveJoinRequestsRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+
+	joinRequests, errRes := service.RetrieveJoinRequests(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", joinRequests)
+}
+
+func approveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "approveJoinRequest", "rest")
+	defer tracer.Finish(span)
+
+	replyJoinRequest(w, r, model.JoinRequestStatusApproved)
+}
+
+func rejectJoinRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "rejectJoinRequest", "rest")
+	defer tracer.Finish(span)
+
+	replyJoinRequest(w, r, model.JoinRequestStatusRejected)
+}
+
+func replyJoinRequest(w http.ResponseWriter, r *http.Request, status model.JoinRequestStatus) {
+	req := &model.ReplyJoinRequestRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.JoinRequestID = bone.GetValue(r, "joinRequestId")
+	req.Status = status
+
+	joinRequest, errRes := service.ReplyJoinRequest(r.Context(), req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", joinRequest)
+}
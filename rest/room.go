@@ -3,11 +3,13 @@ package rest
 import (
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/go-zoo/bone"
 	"github.com/swagchat/chat-api/model"
 	"github.com/swagchat/chat-api/service"
 	"github.com/betchi/tracer"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
 
 func setRoomMux() {
@@ -16,7 +18,11 @@ func setRoomMux() {
 	mux.GetFunc("/rooms/#roomId^[a-z0-9-]$", commonHandler(roomMemberAuthzHandler(getRoom)))
 	mux.PutFunc("/rooms/#roomId^[a-z0-9-]$", commonHandler(roomMemberAuthzHandler(putRoom)))
 	mux.DeleteFunc("/rooms/#roomId^[a-z0-9-]$", commonHandler(roomMemberAuthzHandler(deleteRoom)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/archive", commonHandler(roomMemberAuthzHandler(postRoomArchive)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/unarchive", commonHandler(roomMemberAuthzHandler(postRoomUnarchive)))
+	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/transferOwnership", commonHandler(roomMemberAuthzHandler(postRoomTransferOwnership)))
 	mux.GetFunc("/rooms/#roomId^[a-z0-9-]$/messages", commonHandler(roomMemberAuthzHandler(updateLastAccessedHandler(getRoomMessages))))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/dm/#otherUserId^[a-z0-9-]$", commonHandler(selfResourceAuthzHandler(postDirectRoom)))
 }
 
 func postRoom(w http.ResponseWriter, r *http.Request) {
@@ -62,6 +68,29 @@ func getRooms(w http.ResponseWriter, r *http.Request) {
 	req.Offset = offset
 	req.Orders = orders
 
+	if archivedArray, ok := params["archived"]; ok {
+		archived, err := strconv.ParseBool(archivedArray[0])
+		if err != nil {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "archived",
+					Reason: "archived is incorrect.",
+				},
+			}
+			errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+			respondError(w, r, errRes)
+			return
+		}
+		req.Archived = &archived
+	}
+
+	metaDataFilters, errRes := setMetaDataFilterParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+	req.MetaDataFilters = metaDataFilters
+
 	rooms, errRes := service.RetrieveRooms(ctx, req)
 	if errRes != nil {
 		respondError(w, r, errRes)
@@ -168,3 +197,79 @@ func getRoomMessages(w http.ResponseWriter, r *http.Request) {
 
 	respond(w, r, http.StatusOK, "application/json", messages)
 }
+
+func postRoomArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomArchive", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.ArchiveRoomRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.Archived = true
+
+	room, errRes := service.ArchiveRoom(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", room)
+}
+
+func postRoomUnarchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomUnarchive", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.ArchiveRoomRequest{}
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.Archived = false
+
+	room, errRes := service.ArchiveRoom(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", room)
+}
+
+func postRoomTransferOwnership(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomTransferOwnership", "rest")
+	defer tracer.Finish(span)
+
+	var req model.TransferRoomOwnershipRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+
+	room, errRes := service.TransferRoomOwnership(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", room)
+}
+
+func postDirectRoom(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postDirectRoom", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.GetOrCreateDirectRoomRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.OtherUserID = bone.GetValue(r, "otherUserId")
+
+	room, errRes := service.GetOrCreateDirectRoom(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", room)
+}
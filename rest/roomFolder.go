@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setRoomFolderMux() {
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/roomFolders", commonHandler(selfResourceAuthzHandler(postRoomFolder)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/roomFolders", commonHandler(selfResourceAuthzHandler(getRoomFolders)))
+	mux.PutFunc("/users/#userId^[a-z0-9-]$/roomFolders/#folderId^[a-z0-9-]$", commonHandler(selfResourceAuthzHandler(putRoomFolder)))
+	mux.DeleteFunc("/users/#userId^[a-z0-9-]$/roomFolders/#folderId^[a-z0-9-]$", commonHandler(selfResourceAuthzHandler(deleteRoomFolder)))
+}
+
+func postRoomFolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postRoomFolder", "rest")
+	defer tracer.Finish(span)
+
+	var req model.CreateRoomFolderRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.UserID = bone.GetValue(r, "userId")
+
+	roomFolder, errRes := service.CreateRoomFolder(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, "application/json", roomFolder)
+}
+
+func getRoomFolders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getRoomFolders", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveRoomFoldersRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+
+	roomFolders, errRes := service.RetrieveRoomFolders(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", roomFolders)
+}
+
+func putRoomFolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putRoomFolder", "rest")
+	defer tracer.Finish(span)
+
+	var req model.UpdateRoomFolderRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.UserID = bone.GetValue(r, "userId")
+	req.FolderID = bone.GetValue(r, "folderId")
+
+	roomFolder, errRes := service.UpdateRoomFolder(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", roomFolder)
+}
+
+func deleteRoomFolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteRoomFolder", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.DeleteRoomFolderRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.FolderID = bone.GetValue(r, "folderId")
+
+	errRes := service.DeleteRoomFolder(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
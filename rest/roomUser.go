@@ -15,6 +15,9 @@ func setRoomUserMux() {
 	mux.PostFunc("/rooms/#roomId^[a-z0-9-]$/users", commonHandler(roomMemberAuthzHandler(postRoomUsers)))
 	mux.GetFunc("/rooms/#roomId^[a-z0-9-]$/users", commonHandler(roomMemberAuthzHandler(getRoomUsers)))
 	mux.PutFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$", commonHandler(roomMemberAuthzHandler(putRoomUser)))
+	mux.PutFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/role", commonHandler(roomMemberAuthzHandler(putRoomUserRole)))
+	mux.PutFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/notificationSetting", commonHandler(selfResourceAuthzHandler(putRoomUserNotificationSetting)))
+	mux.PutFunc("/rooms/#roomId^[a-z0-9-]$/users/#userId^[a-z0-9-]$/preference", commonHandler(selfResourceAuthzHandler(putRoomUserPreference)))
 	mux.DeleteFunc("/rooms/#roomId^[a-z0-9-]$/users", commonHandler(roomMemberAuthzHandler(deleteRoomUsers)))
 }
 
@@ -104,6 +107,75 @@ func putRoomUser(w http.ResponseWriter, r *http.Request) {
 	respond(w, r, http.StatusNoContent, "application/json", nil)
 }
 
+func putRoomUserRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putRoomUserRole", "rest")
+	defer tracer.Finish(span)
+
+	var req model.UpdateRoomUserRoleRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.UserID = bone.GetValue(r, "userId")
+
+	roomUser, errRes := service.UpdateRoomUserRole(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", roomUser)
+}
+
+func putRoomUserNotificationSetting(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putRoomUserNotificationSetting", "rest")
+	defer tracer.Finish(span)
+
+	var req model.UpdateRoomUserNotificationSettingRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.UserID = bone.GetValue(r, "userId")
+
+	roomUser, errRes := service.UpdateRoomUserNotificationSetting(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", roomUser)
+}
+
+func putRoomUserPreference(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putRoomUserPreference", "rest")
+	defer tracer.Finish(span)
+
+	var req model.UpdateRoomUserPreferenceRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.RoomID = bone.GetValue(r, "roomId")
+	req.UserID = bone.GetValue(r, "userId")
+
+	roomUser, errRes := service.UpdateRoomUserPreference(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", roomUser)
+}
+
 func deleteRoomUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	span := tracer.StartSpan(ctx, "deleteRoomUsers", "rest")
@@ -0,0 +1,359 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const scimContentType = "application/scim+json"
+
+func setScimMux() {
+	mux.PostFunc("/scim/tokens", commonHandler(adminAuthzHandler(postScimToken)))
+	mux.DeleteFunc("/scim/tokens/#tokenId^[a-z0-9-]$", commonHandler(adminAuthzHandler(deleteScimToken)))
+
+	mux.PostFunc("/scim/v2/Users", scimHandler(postScimUser))
+	mux.GetFunc("/scim/v2/Users", scimHandler(getScimUsers))
+	mux.GetFunc("/scim/v2/Users/#id^[a-z0-9-]$", scimHandler(getScimUser))
+	mux.PutFunc("/scim/v2/Users/#id^[a-z0-9-]$", scimHandler(putScimUser))
+	mux.PatchFunc("/scim/v2/Users/#id^[a-z0-9-]$", scimHandler(patchScimUser))
+	mux.DeleteFunc("/scim/v2/Users/#id^[a-z0-9-]$", scimHandler(deleteScimUser))
+
+	mux.PostFunc("/scim/v2/Groups", scimHandler(postScimGroup))
+	mux.GetFunc("/scim/v2/Groups", scimHandler(getScimGroups))
+	mux.GetFunc("/scim/v2/Groups/#id^[a-z0-9-]$", scimHandler(getScimGroup))
+	mux.PutFunc("/scim/v2/Groups/#id^[a-z0-9-]$", scimHandler(putScimGroup))
+	mux.PatchFunc("/scim/v2/Groups/#id^[a-z0-9-]$", scimHandler(patchScimGroup))
+	mux.DeleteFunc("/scim/v2/Groups/#id^[a-z0-9-]$", scimHandler(deleteScimGroup))
+}
+
+func postScimToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postScimToken", "rest")
+	defer tracer.Finish(span)
+
+	scimToken, errRes := service.CreateScimToken(ctx)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, "application/json", scimToken)
+}
+
+func deleteScimToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteScimToken", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.DeleteScimTokenRequest{}
+	req.TokenID = bone.GetValue(r, "tokenId")
+
+	errRes := service.DeleteScimToken(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+func postScimUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postScimUser", "rest")
+	defer tracer.Finish(span)
+
+	var req model.ScimUser
+	if err := decodeBody(r, &req); err != nil {
+		respondScimJSONDecodeError(w, r)
+		return
+	}
+
+	scimUser, errRes := service.CreateScimUser(ctx, &req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, scimContentType, scimUser)
+}
+
+func getScimUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getScimUsers", "rest")
+	defer tracer.Finish(span)
+
+	req, errRes := setScimListParams(r)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	res, errRes := service.RetrieveScimUsers(ctx, req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, res)
+}
+
+func getScimUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getScimUser", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveScimResourceRequest{}
+	req.ID = bone.GetValue(r, "id")
+
+	scimUser, errRes := service.RetrieveScimUser(ctx, req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, scimUser)
+}
+
+func putScimUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putScimUser", "rest")
+	defer tracer.Finish(span)
+
+	var req model.ScimUser
+	if err := decodeBody(r, &req); err != nil {
+		respondScimJSONDecodeError(w, r)
+		return
+	}
+
+	req.ID = bone.GetValue(r, "id")
+
+	scimUser, errRes := service.ReplaceScimUser(ctx, &req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, scimUser)
+}
+
+func patchScimUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "patchScimUser", "rest")
+	defer tracer.Finish(span)
+
+	var req model.ScimPatchRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondScimJSONDecodeError(w, r)
+		return
+	}
+
+	req.ID = bone.GetValue(r, "id")
+
+	scimUser, errRes := service.PatchScimUser(ctx, &req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, scimUser)
+}
+
+func deleteScimUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteScimUser", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.DeleteScimResourceRequest{}
+	req.ID = bone.GetValue(r, "id")
+
+	errRes := service.DeleteScimUser(ctx, req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+func postScimGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postScimGroup", "rest")
+	defer tracer.Finish(span)
+
+	var req model.ScimGroup
+	if err := decodeBody(r, &req); err != nil {
+		respondScimJSONDecodeError(w, r)
+		return
+	}
+
+	scimGroup, errRes := service.CreateScimGroup(ctx, &req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusCreated, scimContentType, scimGroup)
+}
+
+func getScimGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getScimGroups", "rest")
+	defer tracer.Finish(span)
+
+	req, errRes := setScimListParams(r)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	res, errRes := service.RetrieveScimGroups(ctx, req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, res)
+}
+
+func getScimGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getScimGroup", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveScimResourceRequest{}
+	req.ID = bone.GetValue(r, "id")
+
+	scimGroup, errRes := service.RetrieveScimGroup(ctx, req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, scimGroup)
+}
+
+func putScimGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putScimGroup", "rest")
+	defer tracer.Finish(span)
+
+	var req model.ScimGroup
+	if err := decodeBody(r, &req); err != nil {
+		respondScimJSONDecodeError(w, r)
+		return
+	}
+
+	req.ID = bone.GetValue(r, "id")
+
+	scimGroup, errRes := service.ReplaceScimGroup(ctx, &req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, scimGroup)
+}
+
+func patchScimGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "patchScimGroup", "rest")
+	defer tracer.Finish(span)
+
+	var req model.ScimPatchRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondScimJSONDecodeError(w, r)
+		return
+	}
+
+	req.ID = bone.GetValue(r, "id")
+
+	scimGroup, errRes := service.PatchScimGroup(ctx, &req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, scimContentType, scimGroup)
+}
+
+func deleteScimGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "deleteScimGroup", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.DeleteScimResourceRequest{}
+	req.ID = bone.GetValue(r, "id")
+
+	errRes := service.DeleteScimGroup(ctx, req)
+	if errRes != nil {
+		respondScimError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusNoContent, "", nil)
+}
+
+// setScimListParams parses filter, startIndex and count of a SCIM list
+func setScimListParams(r *http.Request) (*model.RetrieveScimResourcesRequest, *model.ErrorResponse) {
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+	}
+
+	req := &model.RetrieveScimResourcesRequest{}
+	req.StartIndex = 1
+	req.Count = model.ScimMaxCount
+
+	for _, name := range []string{"startIndex", "count"} {
+		value := params.Get(name)
+		if value == "" {
+			continue
+		}
+
+		i, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   name,
+					Reason: name + " is incorrect.",
+				},
+			}
+			return nil, model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+		}
+
+		if name == "startIndex" {
+			req.StartIndex = int32(i)
+		} else {
+			req.Count = int32(i)
+		}
+	}
+
+	if filter := params.Get("filter"); filter != "" {
+		scimFilter, errRes := model.ParseScimFilter(filter)
+		if errRes != nil {
+			return nil, errRes
+		}
+		req.Filter = scimFilter
+	}
+
+	return req, nil
+}
+
+// respondScimError responds an error in the format of SCIM. Unlike respondError, 404 and 409 have a body
+func respondScimError(w http.ResponseWriter, r *http.Request, errRes *model.ErrorResponse) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(errRes.Status)
+	encodeBody(w, r, model.NewScimError(errRes))
+}
+
+func respondScimJSONDecodeError(w http.ResponseWriter, r *http.Request) {
+	errRes := model.NewErrorResponse("Json parse error.", http.StatusBadRequest)
+	respondScimError(w, r, errRes)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -52,15 +53,26 @@ func Run(ctx context.Context) {
 	mux.GetFunc("/stats", stats_api.Handler)
 	mux.GetFunc("/", indexHandler)
 	mux.OptionsFunc("/*", optionsHandler)
+	setAppClientMux()
 	setAssetMux()
 	setBlockUserMux()
+	setBulkImportMux()
+	setContactMux()
 	setDeviceMux()
+	setInvitationMux()
+	setInviteLinkMux()
 	setMessageMux()
+	setModerationMux()
+	setNotificationTemplateMux()
+	setPublicRoomMux()
 	setRoomMux()
+	setRoomFolderMux()
 	setRoomUserMux()
+	setScimMux()
 	setSettingMux()
 	setUserMux()
 	setUserRoleMux()
+	setUserStatusMux()
 
 	if cfg.Storage.Provider == "awsS3" {
 		setAssetAwsSnsMux()
@@ -113,10 +125,23 @@ func commonHandler(fn http.HandlerFunc) http.HandlerFunc {
 		tracer.HandlerFunc(
 			jwtHandler(
 				judgeAppClientHandler(
-					func(w http.ResponseWriter, r *http.Request) {
-						defer r.Body.Close()
-						fn(w, r)
-					})))))
+					userStatusHandler(
+						func(w http.ResponseWriter, r *http.Request) {
+							defer r.Body.Close()
+							fn(w, r)
+						}))))))
+}
+
+// scimHandler is the handler chain of the SCIM endpoints.
+// They are called by identity providers with a bearer token instead of the headers of an app client
+func scimHandler(fn http.HandlerFunc) http.HandlerFunc {
+	return (colsHandler(
+		tracer.HandlerFunc(
+			scimAuthzHandler(
+				func(w http.ResponseWriter, r *http.Request) {
+					defer r.Body.Close()
+					fn(w, r)
+				}))))
 }
 
 func colsHandler(fn http.HandlerFunc) http.HandlerFunc {
@@ -128,6 +153,28 @@ func colsHandler(fn http.HandlerFunc) http.HandlerFunc {
 
 func jwtHandler(fn http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if config.Config().Authentication.Mode == config.AuthenticationModeJWT {
+			principal, errRes := service.TokenAuthn(bearerToken(r))
+			if errRes != nil {
+				respondError(w, r, errRes)
+				return
+			}
+
+			workspace := principal.Workspace
+			if workspace == "" {
+				workspace = config.Config().Datastore.Database
+			}
+
+			ctx := context.WithValue(r.Context(), config.CtxUserID, principal.UserID)
+			ctx = context.WithValue(ctx, config.CtxWorkspace, workspace)
+			ctx = context.WithValue(ctx, config.CtxClientID, principal.ClientID)
+			if principal.RoleIDs != nil {
+				ctx = context.WithValue(ctx, config.CtxRoleIDs, principal.RoleIDs)
+			}
+			fn(w, r.WithContext(ctx))
+			return
+		}
+
 		userID := r.Header.Get(config.HeaderUserID)
 		ctx := context.WithValue(r.Context(), config.CtxUserID, userID)
 
@@ -168,27 +215,57 @@ func updateLastAccessedHandler(fn http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// judgeAppClientHandler handles the request as an app client only if the client is registered and not revoked.
+// The client is the client of the token in the jwt mode. In the header authentication mode,
+// it is the X-ClientId header, or the client of the bearer access token if the token endpoint is enabled.
+// A bearer token that is not an access token of the token endpoint does not make the request an app client
 func judgeAppClientHandler(fn http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		clientID := r.Header.Get(config.HeaderClientID)
-		if clientID != "" {
-			appCli, err := datastore.Provider(r.Context()).SelectLatestAppClient(
-				datastore.SelectAppClientOptionFilterByClientID(clientID),
-			)
-			if err != nil {
-				errRes := model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+		if config.Config().Authentication.Mode == config.AuthenticationModeJWT {
+			clientID = r.Context().Value(config.CtxClientID).(string)
+		} else if config.Config().OAuth.SigningSecret != "" {
+			// Once access tokens are issued, X-ClientId alone is not trusted
+			accessTokenClientID, errRes := service.AccessTokenAuthn(bearerToken(r))
+			if errRes != nil {
 				respondError(w, r, errRes)
 				return
 			}
-			if appCli != nil {
-				clientID = appCli.ClientID
-			}
+			clientID = accessTokenClientID
+		}
+
+		clientID, errRes := service.AppClientAuthn(r.Context(), clientID)
+		if errRes != nil {
+			respondError(w, r, errRes)
+			return
 		}
+
 		ctx := context.WithValue(r.Context(), config.CtxClientID, clientID)
 		fn(w, r.WithContext(ctx))
 	}
 }
 
+// userStatusHandler rejects the requests of a suspended or deactivated user.
+// Requests of an app client are not rejected so that an admin can reactivate the user
+func userStatusHandler(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		clientID := r.Context().Value(config.CtxClientID)
+		if clientID != "" {
+			fn(w, r)
+			return
+		}
+
+		userID := r.Context().Value(config.CtxUserID).(string)
+		errRes := service.UserStatusAuthz(r.Context(), userID)
+		if errRes != nil {
+			respondError(w, r, errRes)
+			return
+		}
+
+		fn(w, r)
+	}
+}
+
 func adminAuthzHandler(fn http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		clientID := r.Context().Value(config.CtxClientID)
@@ -201,6 +278,33 @@ func adminAuthzHandler(fn http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// scimAuthzHandler authenticates the bearer token and handles the request as the app client the token belongs to
+func scimAuthzHandler(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		workspace := r.Header.Get(config.HeaderWorkspace)
+		ctx := context.WithValue(r.Context(), config.CtxWorkspace, workspace)
+
+		clientID, errRes := service.ScimAuthz(ctx, bearerToken(r))
+		if errRes != nil {
+			respondScimError(w, r, errRes)
+			return
+		}
+
+		ctx = context.WithValue(ctx, config.CtxClientID, clientID)
+		ctx = context.WithValue(ctx, config.CtxUserID, "")
+		fn(w, r.WithContext(ctx))
+	}
+}
+
+// bearerToken returns the token of the Authorization header, or "" if it is not a bearer token
+func bearerToken(r *http.Request) string {
+	authorization := r.Header.Get("Authorization")
+	if strings.HasPrefix(strings.ToLower(authorization), "bearer ") {
+		return strings.TrimSpace(authorization[len("bearer "):])
+	}
+	return ""
+}
+
 func selfResourceAuthzHandler(fn http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		clientID := r.Context().Value(config.CtxClientID)
@@ -209,7 +313,8 @@ func selfResourceAuthzHandler(fn http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		requestUserID := r.Header.Get(config.HeaderUserID)
+		// The user is set by jwtHandler. It is the subject of the verified token in the jwt mode, not the X-Sub header
+		requestUserID := r.Context().Value(config.CtxUserID).(string)
 		resourceUserID := bone.GetValue(r, "userId")
 
 		if (requestUserID == "" && resourceUserID == "") || (requestUserID != resourceUserID) {
@@ -230,7 +335,7 @@ func contactsAuthzHandler(fn http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		requestUserID := r.Header.Get(config.HeaderUserID)
+		requestUserID := r.Context().Value(config.CtxUserID).(string)
 		resourceUserID := bone.GetValue(r, "userId")
 		errRes := service.ContactsAuthz(r.Context(), requestUserID, resourceUserID)
 		if errRes != nil {
@@ -250,7 +355,7 @@ func roomMemberAuthzHandler(fn http.HandlerFunc) http.HandlerFunc {
 		}
 
 		roomID := bone.GetValue(r, "roomId")
-		userID := r.Header.Get(config.HeaderUserID)
+		userID := r.Context().Value(config.CtxUserID).(string)
 
 		errRes := service.RoomAuthz(r.Context(), roomID, userID)
 		if errRes != nil {
@@ -297,6 +402,9 @@ func respondError(w http.ResponseWriter, r *http.Request, errRes *model.ErrorRes
 			errRes.DeveloperMessage = errRes.Error.Error()
 		}
 	}
+	if errRes.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(errRes.RetryAfter, 10))
+	}
 	respond(w, r, errRes.Status, "application/json", errRes)
 }
 
@@ -392,3 +500,28 @@ func setPagingParams(params url.Values) (int32, int32, int64, int64, []*scpb.Ord
 
 	return limit, offset, limitTimestamp, offsetTimestamp, orders, nil
 }
+
+// setMetaDataFilterParams parses metaData filter expressions.
+// ex) metaData[orderId]=1, metaData[storeId][in]=a,b, metaData[orderId][exists]=true
+func setMetaDataFilterParams(params url.Values) ([]*model.MetaDataFilter, *model.ErrorResponse) {
+	var keys []string
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var filters []*model.MetaDataFilter
+	for _, key := range keys {
+		filter, ok, invalidParam := model.ParseMetaDataFilter(key, params[key][0])
+		if !ok {
+			continue
+		}
+		if invalidParam != nil {
+			invalidParams := []*scpb.InvalidParam{invalidParam}
+			return nil, model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
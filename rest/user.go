@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -19,9 +20,14 @@ func setUserMux() {
 	mux.PutFunc("/users/#userId^[a-z0-9-]$", commonHandler(selfResourceAuthzHandler(putUser)))
 	mux.DeleteFunc("/users/#userId^[a-z0-9-]$", commonHandler(selfResourceAuthzHandler(deleteUser)))
 
-	// mux.GetFunc("/users/#userId^[a-z0-9-]$/unreadCount", commonHandler(selfResourceAuthzHandler(getUserUnreadCount)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/unreadCount", commonHandler(selfResourceAuthzHandler(getUserUnreadCount)))
 	mux.GetFunc("/users/#userId^[a-z0-9-]$/rooms", commonHandler(selfResourceAuthzHandler(getUserRooms)))
 	mux.GetFunc("/users/#userId^[a-z0-9-]$/contacts", commonHandler(selfResourceAuthzHandler(getContacts)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/search", commonHandler(selfResourceAuthzHandler(getUserSearch)))
+	mux.PutFunc("/users/#userId^[a-z0-9-]$/doNotDisturb", commonHandler(selfResourceAuthzHandler(putUserDoNotDisturb)))
+	mux.PostFunc("/users/#userId^[a-z0-9-]$/export", commonHandler(selfResourceAuthzHandler(postUserExport)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/exports/#exportId^[a-z0-9-]$", commonHandler(selfResourceAuthzHandler(getUserExport)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/exports/#exportId^[a-z0-9-]$/download", commonHandler(selfResourceAuthzHandler(downloadUserExport)))
 	mux.GetFunc("/profiles/#userId^[a-z0-9-]$", commonHandler(contactsAuthzHandler(getProfile)))
 	mux.GetFunc("/roles/#roleId^[0-9]$/users", commonHandler(adminAuthzHandler(getRoleUsers)))
 }
@@ -69,6 +75,13 @@ func getUsers(w http.ResponseWriter, r *http.Request) {
 	req.Offset = offset
 	req.Orders = orders
 
+	metaDataFilters, errRes := setMetaDataFilterParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+	req.MetaDataFilters = metaDataFilters
+
 	users, errRes := service.RetrieveUsers(ctx, req)
 	if errRes != nil {
 		respondError(w, r, errRes)
@@ -119,6 +132,28 @@ func putUser(w http.ResponseWriter, r *http.Request) {
 	respond(w, r, http.StatusOK, "application/json", user)
 }
 
+func putUserDoNotDisturb(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putUserDoNotDisturb", "rest")
+	defer tracer.Finish(span)
+
+	var req model.UpdateUserDoNotDisturbRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.UserID = bone.GetValue(r, "userId")
+
+	user, errRes := service.UpdateUserDoNotDisturb(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", user)
+}
+
 func deleteUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	span := tracer.StartSpan(ctx, "deleteUser", "rest")
@@ -186,6 +221,65 @@ func getUserRooms(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if archivedArray, ok := params["archived"]; ok {
+		archived, err := strconv.ParseBool(archivedArray[0])
+		if err != nil {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "archived",
+					Reason: "archived is incorrect.",
+				},
+			}
+			errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+			respondError(w, r, errRes)
+			return
+		}
+		req.Archived = archived
+	}
+
+	if favoriteArray, ok := params["favorite"]; ok {
+		favorite, err := strconv.ParseBool(favoriteArray[0])
+		if err != nil {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "favorite",
+					Reason: "favorite is incorrect.",
+				},
+			}
+			errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+			respondError(w, r, errRes)
+			return
+		}
+		req.Favorite = &favorite
+	}
+
+	if folderIDArray, ok := params["folderId"]; ok {
+		req.FolderID = folderIDArray[0]
+	}
+
+	if sortArray, ok := params["sort"]; ok {
+		sort := model.MiniRoomsSort(sortArray[0])
+		if !sort.IsValid() {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "sort",
+					Reason: "sort is incorrect. Available values are lastMessage, unreadFirst and manual.",
+				},
+			}
+			errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+			respondError(w, r, errRes)
+			return
+		}
+		req.Sort = sort
+	}
+
+	metaDataFilters, errRes := setMetaDataFilterParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+	req.MetaDataFilters = metaDataFilters
+
 	roomUsers, errRes := service.RetrieveUserRooms(ctx, req)
 	if errRes != nil {
 		respondError(w, r, errRes)
@@ -195,6 +289,56 @@ func getUserRooms(w http.ResponseWriter, r *http.Request) {
 	respond(w, r, http.StatusOK, "application/json", roomUsers)
 }
 
+func getUserSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getUserSearch", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.SearchUsersRequest{}
+
+	userID := bone.GetValue(r, "userId")
+	req.UserID = userID
+
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	limit, offset, _, _, _, errRes := setPagingParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	req.Limit = limit
+	req.Offset = offset
+
+	if queryArray, ok := params["q"]; ok {
+		req.Query = queryArray[0]
+	}
+
+	if matchArray, ok := params["match"]; ok {
+		req.Match = model.UserSearchMatch(matchArray[0])
+	}
+
+	metaDataFilters, errRes := setMetaDataFilterParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+	req.MetaDataFilters = metaDataFilters
+
+	users, errRes := service.SearchUsers(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", users)
+}
+
 func getContacts(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	span := tracer.StartSpan(ctx, "getContacts", "rest")
@@ -231,6 +375,63 @@ func getContacts(w http.ResponseWriter, r *http.Request) {
 	respond(w, r, http.StatusOK, "application/json", contacts)
 }
 
+func postUserExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "postUserExport", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.CreateUserExportRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+
+	userExport, errRes := service.CreateUserExport(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusAccepted, "application/json", userExport)
+}
+
+func getUserExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getUserExport", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveUserExportRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.ExportID = bone.GetValue(r, "exportId")
+
+	userExport, errRes := service.RetrieveUserExport(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", userExport)
+}
+
+func downloadUserExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "downloadUserExport", "rest")
+	defer tracer.Finish(span)
+
+	req := &model.RetrieveUserExportRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.ExportID = bone.GetValue(r, "exportId")
+
+	bytes, errRes := service.DownloadUserExport(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", model.UserExportFilename(req.ExportID)))
+	w.Header().Set("Content-Length", strconv.Itoa(len(bytes)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
 func getProfile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	span := tracer.StartSpan(ctx, "getProfile", "rest")
@@ -282,14 +483,19 @@ func getRoleUsers(w http.ResponseWriter, r *http.Request) {
 	respond(w, r, http.StatusOK, "application/json", roleUsers)
 }
 
-// func getUserUnreadCount(w http.ResponseWriter, r *http.Request) {
-// 	userID := bone.GetValue(r, "userId")
+func getUserUnreadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getUserUnreadCount", "rest")
+	defer tracer.Finish(span)
 
-// 	userUnreadCount, pd := service.RetrieveUserUnreadCount(ctx, userID)
-// 	if pd != nil {
-// 		respondErr(w, r, pd.Status, pd)
-// 		return
-// 	}
+	req := &model.RetrieveUnreadSummaryRequest{}
+	req.UserID = bone.GetValue(r, "userId")
 
-// 	respond(w, r, http.StatusOK, "application/json", userUnreadCount)
-// }
+	unreadSummary, errRes := service.RetrieveUnreadSummary(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", unreadSummary)
+}
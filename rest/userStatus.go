@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/betchi/tracer"
+	"github.com/go-zoo/bone"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/service"
+)
+
+func setUserStatusMux() {
+	mux.PutFunc("/users/#userId^[a-z0-9-]$/status", commonHandler(adminAuthzHandler(putUserStatus)))
+	mux.GetFunc("/users/#userId^[a-z0-9-]$/statusLogs", commonHandler(adminAuthzHandler(getUserStatusLogs)))
+}
+
+func putUserStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "putUserStatus", "rest")
+	defer tracer.Finish(span)
+
+	var req model.UpdateUserStatusRequest
+	if err := decodeBody(r, &req); err != nil {
+		respondJSONDecodeError(w, r, "")
+		return
+	}
+
+	req.UserID = bone.GetValue(r, "userId")
+
+	user, errRes := service.UpdateUserStatus(ctx, &req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", user)
+}
+
+func getUserStatusLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := tracer.StartSpan(ctx, "getUserStatusLogs", "rest")
+	defer tracer.Finish(span)
+
+	params, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		errRes := model.NewErrorResponse("", http.StatusBadRequest, model.WithError(err))
+		respondError(w, r, errRes)
+		return
+	}
+
+	limit, offset, _, _, _, errRes := setPagingParams(params)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	req := &model.RetrieveUserStatusLogsRequest{}
+	req.UserID = bone.GetValue(r, "userId")
+	req.Limit = limit
+	req.Offset = offset
+
+	userStatusLogs, errRes := service.RetrieveUserStatusLogs(ctx, req)
+	if errRes != nil {
+		respondError(w, r, errRes)
+		return
+	}
+
+	respond(w, r, http.StatusOK, "application/json", userStatusLogs)
+}
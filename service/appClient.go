@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/jwt"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
+)
+
+// CreateAppClient creates an app client and issues its first secret
+func CreateAppClient(ctx context.Context, req *model.CreateAppClientRequest) (*model.AppClient, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateAppClient", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	appClient := req.GenerateAppClient()
+	err := datastore.Provider(ctx).InsertAppClient(appClient)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create app client.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	appClientSecret, secret := model.NewAppClientSecret(appClient.ClientID)
+	err = datastore.Provider(ctx).InsertAppClientSecret(appClientSecret)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create app client.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	appClient.ClientSecret = secret
+	return appClient, nil
+}
+
+// RetrieveAppClients retrieves the app clients that have not been revoked
+func RetrieveAppClients(ctx context.Context) (*model.AppClientsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveAppClients", "service")
+	defer tracer.Finish(span)
+
+	appClients, err := datastore.Provider(ctx).SelectAppClients()
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to get app clients.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return &model.AppClientsResponse{
+		AppClients: appClients,
+	}, nil
+}
+
+// RevokeAppClient revokes an app client and all its secrets.
+// The access tokens already issued to the client are rejected because the client is no longer registered
+func RevokeAppClient(ctx context.Context, req *model.RevokeAppClientRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "RevokeAppClient", "service")
+	defer tracer.Finish(span)
+
+	if clientID, _ := ctx.Value(config.CtxClientID).(string); clientID == req.ClientID {
+		return model.NewErrorResponse("Failed to revoke app client. The app client of the request can not be revoked.", http.StatusBadRequest)
+	}
+
+	appClient, errRes := confirmAppClientExist(ctx, req.ClientID)
+	if errRes != nil {
+		errRes.Message = "Failed to revoke app client."
+		return errRes
+	}
+
+	nowTimestamp := time.Now().Unix()
+
+	appClient.Expired = nowTimestamp
+	err := datastore.Provider(ctx).UpdateAppClient(appClient)
+	if err != nil {
+		return model.NewErrorResponse("Failed to revoke app client.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	appClientSecrets, err := datastore.Provider(ctx).SelectAppClientSecrets(req.ClientID)
+	if err != nil {
+		return model.NewErrorResponse("Failed to revoke app client.", http.StatusInternalServerError, model.WithError(err))
+	}
+	for _, appClientSecret := range appClientSecrets {
+		appClientSecret.Expired = nowTimestamp
+		err = datastore.Provider(ctx).UpdateAppClientSecret(appClientSecret)
+		if err != nil {
+			return model.NewErrorResponse("Failed to revoke app client.", http.StatusInternalServerError, model.WithError(err))
+		}
+	}
+
+	return nil
+}
+
+// RotateAppClientSecret issues a new secret of an app client.
+// The previous secrets stay valid for oauth.secretRotationOverlap seconds so that the client can switch to the new secret
+func RotateAppClientSecret(ctx context.Context, req *model.RotateAppClientSecretRequest) (*model.AppClient, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RotateAppClientSecret", "service")
+	defer tracer.Finish(span)
+
+	appClient, errRes := confirmAppClientExist(ctx, req.ClientID)
+	if errRes != nil {
+		errRes.Message = "Failed to rotate app client secret."
+		return nil, errRes
+	}
+
+	previousSecrets, err := datastore.Provider(ctx).SelectAppClientSecrets(req.ClientID)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to rotate app client secret.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	// The new secret is stored first so that a failure does not leave the client without a valid secret
+	appClientSecret, secret := model.NewAppClientSecret(appClient.ClientID)
+	err = datastore.Provider(ctx).InsertAppClientSecret(appClientSecret)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to rotate app client secret.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	expired := appClientSecret.Created + config.Config().OAuth.SecretRotationOverlap
+	for _, previousSecret := range previousSecrets {
+		if previousSecret.Expired != 0 && previousSecret.Expired <= expired {
+			continue
+		}
+		previousSecret.Expired = expired
+		err = datastore.Provider(ctx).UpdateAppClientSecret(previousSecret)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to rotate app client secret.", http.StatusInternalServerError, model.WithError(err))
+		}
+	}
+
+	appClient.ClientSecret = secret
+	return appClient, nil
+}
+
+// IssueAccessToken issues an access token of the client credentials grant.
+// The token carries the client ID in the claim of authentication.jwt.clientIdClaim, so it is accepted in the jwt authentication mode
+func IssueAccessToken(ctx context.Context, req *model.AccessTokenRequest) (*model.AccessTokenResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "IssueAccessToken", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	errRes = confirmAppClientCredentials(ctx, req.ClientID, req.ClientSecret)
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	cfg := config.Config()
+	nowTimestamp := time.Now().Unix()
+
+	clientIDClaim := jwt.ClientIDClaim(cfg.Authentication.JWT)
+
+	claims := jwt.Claims{
+		clientIDClaim: req.ClientID,
+		"jti":         utils.GenerateUUID(),
+		"iat":         nowTimestamp,
+		"exp":         nowTimestamp + cfg.OAuth.AccessTokenExpiresIn,
+	}
+	if cfg.Authentication.JWT.Issuer != "" {
+		claims["iss"] = cfg.Authentication.JWT.Issuer
+	}
+	if cfg.Authentication.JWT.Audience != "" {
+		claims["aud"] = cfg.Authentication.JWT.Audience
+	}
+	if workspace, _ := ctx.Value(config.CtxWorkspace).(string); workspace != "" && cfg.Authentication.JWT.WorkspaceClaim != "" {
+		claims[cfg.Authentication.JWT.WorkspaceClaim] = workspace
+	}
+
+	accessToken, err := jwt.SignHS256(claims, []byte(cfg.OAuth.SigningSecret))
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return &model.AccessTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   model.OAuthTokenTypeBearer,
+		ExpiresIn:   cfg.OAuth.AccessTokenExpiresIn,
+	}, nil
+}
+
+// confirmAppClientCredentials confirms that the app client is registered and the secret is one of its valid secrets
+func confirmAppClientCredentials(ctx context.Context, clientID, secret string) *model.ErrorResponse {
+	appClient, err := datastore.Provider(ctx).SelectLatestAppClient(
+		datastore.SelectAppClientOptionFilterByClientID(clientID),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if appClient == nil {
+		return model.NewErrorResponse("Client authentication failed.", http.StatusUnauthorized)
+	}
+
+	appClientSecrets, err := datastore.Provider(ctx).SelectAppClientSecrets(clientID)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	for _, appClientSecret := range appClientSecrets {
+		if appClientSecret.Matches(secret) {
+			return nil
+		}
+	}
+
+	return model.NewErrorResponse("Client authentication failed.", http.StatusUnauthorized)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/swagchat/chat-api/config"
 	"github.com/swagchat/chat-api/datastore"
 	"github.com/swagchat/chat-api/model"
 	"github.com/swagchat/chat-api/storage"
@@ -18,7 +19,10 @@ func PostAsset(ctx context.Context, contentType string, file io.Reader, size int
 	span := tracer.StartSpan(ctx, "PostAsset", "service")
 	defer tracer.Finish(span)
 
+	userID, _ := ctx.Value(config.CtxUserID).(string)
+
 	asset := &model.Asset{
+		UserID: userID,
 		Mime:   contentType,
 		Size:   size,
 		Width:  width,
@@ -4,13 +4,30 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/config"
 	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/jwt"
 	"github.com/swagchat/chat-api/model"
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
 
 // ContactsAuthz is contacts authorize
 func ContactsAuthz(ctx context.Context, requestUserID, resourceUserID string) *model.ErrorResponse {
+	mode := contactMode(ctx)
+	if mode.IncludesExplicit() {
+		contact, err := datastore.Provider(ctx).SelectContact(requestUserID, resourceUserID)
+		if err != nil {
+			return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+		}
+		if contact != nil {
+			return nil
+		}
+		if !mode.IncludesImplicit() {
+			return model.NewErrorResponse("You do not have permission", http.StatusUnauthorized)
+		}
+	}
+
 	req := &model.RetrieveContactsRequest{}
 	req.UserID = requestUserID
 
@@ -34,6 +51,116 @@ func ContactsAuthz(ctx context.Context, requestUserID, resourceUserID string) *m
 	return nil
 }
 
+// UserStatusAuthz rejects the requests of a suspended or deactivated user
+func UserStatusAuthz(ctx context.Context, userID string) *model.ErrorResponse {
+	if userID == "" {
+		return nil
+	}
+
+	user, err := datastore.Provider(ctx).SelectUser(userID)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if user == nil {
+		return nil
+	}
+
+	reactivateExpiredSuspension(ctx, user)
+
+	switch user.EffectiveStatus() {
+	case model.UserStatusSuspended:
+		return model.NewErrorResponse("Your account is suspended", http.StatusForbidden)
+	case model.UserStatusDeactivated:
+		return model.NewErrorResponse("Your account is deactivated", http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// ScimAuthz authenticates a bearer token of SCIM and returns the client id of the app client the token belongs to
+func ScimAuthz(ctx context.Context, token string) (string, *model.ErrorResponse) {
+	if token == "" {
+		return "", model.NewErrorResponse("Unauthorized", http.StatusUnauthorized)
+	}
+
+	scimToken, err := datastore.Provider(ctx).SelectScimToken(
+		datastore.SelectScimTokenOptionFilterByTokenHash(model.HashScimToken(token)),
+	)
+	if err != nil {
+		return "", model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if scimToken == nil {
+		return "", model.NewErrorResponse("Unauthorized", http.StatusUnauthorized)
+	}
+
+	appClient, err := datastore.Provider(ctx).SelectLatestAppClient(
+		datastore.SelectAppClientOptionFilterByClientID(scimToken.ClientID),
+	)
+	if err != nil {
+		return "", model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if appClient == nil {
+		return "", model.NewErrorResponse("Unauthorized", http.StatusUnauthorized)
+	}
+
+	return appClient.ClientID, nil
+}
+
+// TokenAuthn verifies the bearer token and returns whom the token is issued to.
+// It is used for every request in the jwt authentication mode
+func TokenAuthn(token string) (*jwt.Principal, *model.ErrorResponse) {
+	if token == "" {
+		return nil, model.NewErrorResponse("Unauthorized", http.StatusUnauthorized)
+	}
+
+	principal, err := jwt.Authenticate(token)
+	if err != nil {
+		if errors.Cause(err) == jwt.ErrInvalidToken {
+			return nil, model.NewErrorResponse("Unauthorized", http.StatusUnauthorized, model.WithError(err))
+		}
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return principal, nil
+}
+
+// AccessTokenAuthn returns the app client of the bearer token if it is an access token issued by the token endpoint, otherwise "".
+// Other bearer tokens are left to the header authentication
+func AccessTokenAuthn(token string) (string, *model.ErrorResponse) {
+	if token == "" {
+		return "", nil
+	}
+
+	clientID, err := jwt.AuthenticateAccessToken(token)
+	if err != nil {
+		if errors.Cause(err) == jwt.ErrInvalidToken {
+			return "", nil
+		}
+		return "", model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return clientID, nil
+}
+
+// AppClientAuthn returns the client ID if the app client is registered in the workspace, otherwise ""
+func AppClientAuthn(ctx context.Context, clientID string) (string, *model.ErrorResponse) {
+	if clientID == "" {
+		return "", nil
+	}
+
+	appClient, err := datastore.Provider(ctx).SelectLatestAppClient(
+		datastore.SelectAppClientOptionFilterByClientID(clientID),
+	)
+	if err != nil {
+		return "", model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if appClient == nil {
+		return "", nil
+	}
+
+	return appClient.ClientID, nil
+}
+
 // RoomAuthz is room authorize
 func RoomAuthz(ctx context.Context, roomID, userID string) *model.ErrorResponse {
 	room, errRes := confirmRoomExist(ctx, roomID, datastore.SelectRoomOptionWithUsers(true))
@@ -59,3 +186,92 @@ func RoomAuthz(ctx context.Context, roomID, userID string) *model.ErrorResponse
 
 	return nil
 }
+
+// RoomPermissionAuthz confirms that the request user is granted the permission in the room.
+// Requests from an app client, and gRPC or internal calls that carry no user, are not restricted.
+// A REST request always carries a user, so one without X-Sub is denied as a non-member
+func RoomPermissionAuthz(ctx context.Context, room *model.Room, permission model.RoomPermission) *model.ErrorResponse {
+	userID, restricted := requestUserID(ctx)
+	if !restricted {
+		return nil
+	}
+
+	role, errRes := roomMemberRole(ctx, room, userID)
+	if errRes != nil {
+		return errRes
+	}
+
+	if !role.Can(permission) {
+		return model.NewErrorResponse("You do not have permission", http.StatusUnauthorized)
+	}
+
+	return nil
+}
+
+// roomMemberOutrankAuthz confirms that the request user has a higher role than every target user in the room
+func roomMemberOutrankAuthz(ctx context.Context, room *model.Room, userIDs []string) *model.ErrorResponse {
+	userID, restricted := requestUserID(ctx)
+	if !restricted {
+		return nil
+	}
+
+	role, errRes := roomMemberRole(ctx, room, userID)
+	if errRes != nil {
+		return errRes
+	}
+
+	roomUsers, err := datastore.Provider(ctx).SelectRoomUsers(
+		datastore.SelectRoomUsersOptionWithRoomID(room.RoomID),
+		datastore.SelectRoomUsersOptionWithUserIDs(userIDs),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	for _, ru := range roomUsers {
+		if ru.UserID == room.UserID || ru.MemberRole >= role {
+			return model.NewErrorResponse("You do not have permission", http.StatusUnauthorized)
+		}
+	}
+
+	return nil
+}
+
+// requestUserID returns the request user and whether the request is restricted to what the user may do.
+// It is not restricted for an app client or when the context has no user at all
+func requestUserID(ctx context.Context) (string, bool) {
+	if clientID, _ := ctx.Value(config.CtxClientID).(string); clientID != "" {
+		return "", false
+	}
+
+	userID, ok := ctx.Value(config.CtxUserID).(string)
+	if !ok {
+		return "", false
+	}
+
+	return userID, true
+}
+
+// requestRoleIDs returns the role IDs of the token in the jwt authentication mode, otherwise the roles of the user
+func requestRoleIDs(ctx context.Context, user *model.User) []int32 {
+	if roleIDs, ok := ctx.Value(config.CtxRoleIDs).([]int32); ok {
+		return roleIDs
+	}
+	return user.Roles
+}
+
+func roomMemberRole(ctx context.Context, room *model.Room, userID string) (model.RoomMemberRole, *model.ErrorResponse) {
+	if userID != "" && userID == room.UserID {
+		return model.RoomMemberRoleOwner, nil
+	}
+
+	ru, err := datastore.Provider(ctx).SelectRoomUser(room.RoomID, userID)
+	if err != nil {
+		return model.RoomMemberRoleMember, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if ru == nil {
+		return model.RoomMemberRoleMember, model.NewErrorResponse("You are not this room member", http.StatusUnauthorized)
+	}
+
+	return ru.MemberRole, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/swagchat/chat-api/datastore"
 	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
 	"github.com/betchi/tracer"
 )
 
@@ -154,3 +155,39 @@ func DeleteBlockUsers(ctx context.Context, req *model.DeleteBlockUsersRequest) *
 
 	return nil
 }
+
+// confirmNotBlockedBy confirms that none of the users blocks the user
+func confirmNotBlockedBy(ctx context.Context, userID string, userIDs []string) *model.ErrorResponse {
+	blockerUserIDs, err := datastore.Provider(ctx).SelectBlockedUserIDs(userID)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	for _, blockerUserID := range blockerUserIDs {
+		if utils.SearchStringValueInSlice(userIDs, blockerUserID) {
+			return model.NewErrorResponse("You are blocked by the user", http.StatusForbidden)
+		}
+	}
+
+	return nil
+}
+
+// excludeBlockerUserIDs removes the users who block the user from userIDs
+func excludeBlockerUserIDs(ctx context.Context, userID string, userIDs []string) ([]string, error) {
+	blockerUserIDs, err := datastore.Provider(ctx).SelectBlockedUserIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockerUserIDs) == 0 {
+		return userIDs, nil
+	}
+
+	filteredUserIDs := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if !utils.SearchStringValueInSlice(blockerUserIDs, id) {
+			filteredUserIDs = append(filteredUserIDs, id)
+		}
+	}
+
+	return filteredUserIDs, nil
+}
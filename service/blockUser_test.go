@@ -16,6 +16,7 @@ const (
 	TestServiceRetrieveBlockUserIDs   = "[service] retrieve block userIds test"
 	TestServiceRetrieveBlockedUsers   = "[service] retrieve blocked users test"
 	TestServiceRetrieveBlockedUserIDs = "[service] retrieve blocked userIds test"
+	TestServiceConfirmNotBlockedBy    = "[service] confirm not blocked by test"
 	TestServiceDeleteBlockUsers       = "[service] delete block users test"
 	TestServiceTearDownBlockUser      = "[service] tear down blockUser"
 )
@@ -171,6 +172,26 @@ func TestBlockUser(t *testing.T) {
 		}
 	})
 
+	t.Run(TestServiceConfirmNotBlockedBy, func(t *testing.T) {
+		errRes := confirmNotBlockedBy(ctx, "block-user-service-user-id-0002", []string{"block-user-service-user-id-0001", "block-user-service-user-id-0005"})
+		if errRes == nil {
+			t.Fatalf("Failed to %s. Expected errRes to be not nil, but it was nil", TestServiceConfirmNotBlockedBy)
+		}
+
+		errRes = confirmNotBlockedBy(ctx, "block-user-service-user-id-0001", []string{"block-user-service-user-id-0002"})
+		if errRes != nil {
+			t.Fatalf("Failed to %s. Expected errRes to be nil, but it was not nil", TestServiceConfirmNotBlockedBy)
+		}
+
+		userIDs, err := excludeBlockerUserIDs(ctx, "block-user-service-user-id-0002", []string{"block-user-service-user-id-0001", "block-user-service-user-id-0005"})
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestServiceConfirmNotBlockedBy, err.Error())
+		}
+		if len(userIDs) != 1 || userIDs[0] != "block-user-service-user-id-0005" {
+			t.Fatalf("Failed to %s. Expected userIDs to be [block-user-service-user-id-0005], but it was %v", TestServiceConfirmNotBlockedBy, userIDs)
+		}
+	})
+
 	t.Run(TestServiceDeleteBlockUsers, func(t *testing.T) {
 		req := &model.DeleteBlockUsersRequest{}
 		req.UserID = "block-user-service-user-id-0001"
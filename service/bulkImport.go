@@ -0,0 +1,351 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/storage"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// CreateBulkImport creates a bulk import of users, rooms or room users. The rows are imported asynchronously
+func CreateBulkImport(ctx context.Context, req *model.CreateBulkImportRequest) (*model.BulkImport, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateBulkImport", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	if clientID, ok := ctx.Value(config.CtxClientID).(string); ok {
+		req.ClientID = clientID
+	}
+
+	bulkImport := req.GenerateBulkImport()
+
+	assetInfo := &storage.AssetInfo{
+		Filename: model.BulkImportFilename(bulkImport.ImportID, bulkImport.Format),
+		Data:     bytes.NewReader(req.Data),
+	}
+	filename, err := storage.Provider(ctx).Post(assetInfo)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create bulk import.", http.StatusInternalServerError, model.WithError(err))
+	}
+	bulkImport.Filename = filename
+
+	err = datastore.Provider(ctx).InsertBulkImport(bulkImport)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create bulk import.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go runBulkImport(ctx, bulkImport)
+
+	return bulkImport, nil
+}
+
+// RetrieveBulkImport retrieves the progress of a bulk import
+func RetrieveBulkImport(ctx context.Context, importID string) (*model.BulkImport, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveBulkImport", "service")
+	defer tracer.Finish(span)
+
+	bulkImport, errRes := confirmBulkImportExist(ctx, importID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve bulk import."
+		return nil, errRes
+	}
+
+	return bulkImport, nil
+}
+
+// RetrieveBulkImportRowResults retrieves the result of each row of a bulk import
+func RetrieveBulkImportRowResults(ctx context.Context, req *model.RetrieveBulkImportRowResultsRequest) (*model.BulkImportRowResultsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveBulkImportRowResults", "service")
+	defer tracer.Finish(span)
+
+	_, errRes := confirmBulkImportExist(ctx, req.ImportID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve bulk import results."
+		return nil, errRes
+	}
+
+	var opts []datastore.SelectBulkImportRowResultsOption
+	if req.FailedOnly {
+		opts = append(opts, datastore.SelectBulkImportRowResultsOptionFilterByStatus(model.BulkImportRowStatusFailed))
+	}
+
+	results, err := datastore.Provider(ctx).SelectBulkImportRowResults(req.ImportID, req.Limit, req.Offset, opts...)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve bulk import results.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.BulkImportRowResultsResponse{}
+	res.Results = results
+	res.Limit = req.Limit
+	res.Offset = req.Offset
+	return res, nil
+}
+
+// ResumeBulkImport starts a failed or stopped bulk import again from the first row not committed yet
+func ResumeBulkImport(ctx context.Context, importID string) (*model.BulkImport, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "ResumeBulkImport", "service")
+	defer tracer.Finish(span)
+
+	bulkImport, errRes := confirmBulkImportExist(ctx, importID)
+	if errRes != nil {
+		errRes.Message = "Failed to resume bulk import."
+		return nil, errRes
+	}
+
+	if !bulkImport.IsResumable() {
+		return nil, model.NewErrorResponse("Failed to resume bulk import. The import is completed or still processing.", http.StatusConflict)
+	}
+
+	bulkImport.Resume()
+	err := datastore.Provider(ctx).UpdateBulkImport(bulkImport)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to resume bulk import.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go runBulkImport(ctx, bulkImport)
+
+	return bulkImport, nil
+}
+
+func runBulkImport(ctx context.Context, bulkImport *model.BulkImport) {
+	span := tracer.StartSpan(ctx, "runBulkImport", "service")
+	defer tracer.Finish(span)
+
+	err := importBulkImportRows(ctx, bulkImport)
+	if err != nil {
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		bulkImport.Fail(err)
+	} else {
+		bulkImport.Complete()
+	}
+
+	err = datastore.Provider(ctx).UpdateBulkImport(bulkImport)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+func importBulkImportRows(ctx context.Context, bulkImport *model.BulkImport) error {
+	assetInfo := &storage.AssetInfo{
+		Filename: bulkImport.Filename,
+	}
+	data, err := storage.Provider(ctx).Get(assetInfo)
+	if err != nil {
+		return err
+	}
+
+	rows, err := model.ParseBulkImportRows(bulkImport.Kind, bulkImport.Format, data)
+	if err != nil {
+		return err
+	}
+	bulkImport.TotalRows = int64(len(rows))
+
+	for start := int(bulkImport.ProcessedRows); start < len(rows); start += model.BulkImportBatchSize {
+		end := start + model.BulkImportBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		batch, err := makeBulkImportBatch(ctx, bulkImport, rows[start:end])
+		if err != nil {
+			return err
+		}
+
+		// The progress is saved with the rows. It is restored when the batch is rolled back
+		progress := *bulkImport
+		bulkImport.Proceed(batch.Results)
+		err = datastore.Provider(ctx).InsertBulkImportBatch(bulkImport, batch)
+		if err != nil {
+			*bulkImport = progress
+			return err
+		}
+
+		if len(batch.RoomUsers) > 0 {
+			go subscribeByRoomUsers(ctx, batch.RoomUsers)
+		}
+	}
+
+	return nil
+}
+
+// makeBulkImportBatch validates the rows with the rules of the api creating them one by one.
+// Invalid rows are reported in the results and the others are inserted
+func makeBulkImportBatch(ctx context.Context, bulkImport *model.BulkImport, rows []*model.BulkImportRow) (*model.BulkImportBatch, error) {
+	batch := &model.BulkImportBatch{}
+	seen := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		result := &model.BulkImportRowResult{
+			ImportID: bulkImport.ImportID,
+			Row:      row.Row,
+			Status:   model.BulkImportRowStatusSucceeded,
+			Created:  time.Now().Unix(),
+		}
+		batch.Results = append(batch.Results, result)
+
+		if row.ParseError != "" {
+			result.Status = model.BulkImportRowStatusFailed
+			result.Reason = row.ParseError
+			continue
+		}
+
+		var errRes *model.ErrorResponse
+		switch bulkImport.Kind {
+		case model.BulkImportKindUsers:
+			result.ResourceID, errRes = addBulkImportUser(ctx, batch, row.User, seen)
+		case model.BulkImportKindRooms:
+			result.ResourceID, errRes = addBulkImportRoom(ctx, batch, row.Room, seen)
+		case model.BulkImportKindRoomUsers:
+			result.ResourceID, errRes = addBulkImportRoomUser(ctx, batch, row.RoomUser, seen)
+		}
+		if errRes != nil {
+			if errRes.Status == http.StatusInternalServerError {
+				return nil, errRes.Error
+			}
+			result.Status = model.BulkImportRowStatusFailed
+			result.Reason = bulkImportRowReason(errRes)
+		}
+	}
+
+	return batch, nil
+}
+
+func addBulkImportUser(ctx context.Context, batch *model.BulkImportBatch, req *model.CreateUserRequest, seen map[string]bool) (string, *model.ErrorResponse) {
+	errRes := req.Validate()
+	if errRes != nil {
+		return "", errRes
+	}
+
+	user := req.GenerateUser()
+	req.UserID = &user.UserID
+
+	if seen[user.UserID] {
+		return user.UserID, model.NewErrorResponse("The user is duplicated in the file.", http.StatusConflict)
+	}
+
+	_, errRes = confirmUserNotExist(ctx, user.UserID)
+	if errRes != nil {
+		return user.UserID, errRes
+	}
+
+	seen[user.UserID] = true
+	batch.Users = append(batch.Users, user)
+	batch.BlockUsers = append(batch.BlockUsers, req.GenerateBlockUsers()...)
+	batch.UserRoles = append(batch.UserRoles, req.GenerateUserRoles()...)
+	return user.UserID, nil
+}
+
+func addBulkImportRoom(ctx context.Context, batch *model.BulkImportBatch, req *model.CreateRoomRequest, seen map[string]bool) (string, *model.ErrorResponse) {
+	errRes := req.Validate()
+	if errRes != nil {
+		return "", errRes
+	}
+
+	room := req.GenerateRoom()
+	req.RoomID = &room.RoomID
+
+	if seen[room.RoomID] {
+		return room.RoomID, model.NewErrorResponse("The room is duplicated in the file.", http.StatusConflict)
+	}
+
+	existRoom, err := datastore.Provider(ctx).SelectRoom(room.RoomID)
+	if err != nil {
+		return room.RoomID, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if existRoom != nil {
+		return room.RoomID, model.NewErrorResponse("The room already exists.", http.StatusConflict)
+	}
+
+	_, errRes = confirmUserExist(ctx, room.UserID)
+	if errRes != nil {
+		return room.RoomID, errRes
+	}
+
+	if len(req.UserIDs) > 0 {
+		errRes = confirmUserIDsExist(ctx, req.UserIDs, "userIds")
+		if errRes != nil {
+			return room.RoomID, errRes
+		}
+	}
+
+	var directRoomKey string
+	if room.Type == scpb.RoomType_OneOnOneRoom {
+		// The pair is looked up in both directions, so that a file with A-B and B-A rows creates one room
+		firstUserID, secondUserID := model.SortDirectRoomUserIDs(room.UserID, req.UserIDs[0])
+		directRoomKey = fmt.Sprintf("directRoom:%s:%s", firstUserID, secondUserID)
+		if seen[directRoomKey] {
+			return room.RoomID, model.NewErrorResponse("The 1on1 room of the users is duplicated in the file.", http.StatusConflict)
+		}
+
+		errRes = confirmDirectRoomNotExist(ctx, room.UserID, req.UserIDs[0])
+		if errRes != nil {
+			return room.RoomID, errRes
+		}
+		batch.DirectRooms = append(batch.DirectRooms, model.NewDirectRoom(room.RoomID, room.UserID, req.UserIDs[0]))
+	}
+
+	seen[room.RoomID] = true
+	if directRoomKey != "" {
+		seen[directRoomKey] = true
+	}
+	batch.Rooms = append(batch.Rooms, room)
+	batch.RoomUsers = append(batch.RoomUsers, req.GenerateRoomUsers()...)
+	return room.RoomID, nil
+}
+
+func addBulkImportRoomUser(ctx context.Context, batch *model.BulkImportBatch, req *model.BulkImportRoomUser, seen map[string]bool) (string, *model.ErrorResponse) {
+	errRes := req.Validate()
+	if errRes != nil {
+		return "", errRes
+	}
+
+	key := fmt.Sprintf("%s:%s", req.RoomID, req.UserID)
+	if seen[key] {
+		return req.RoomID, model.NewErrorResponse("The room user is duplicated in the file.", http.StatusConflict)
+	}
+
+	_, errRes = confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		return req.RoomID, errRes
+	}
+
+	_, errRes = confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		return req.RoomID, errRes
+	}
+
+	roomUser, err := datastore.Provider(ctx).SelectRoomUser(req.RoomID, req.UserID)
+	if err != nil {
+		return req.RoomID, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if roomUser != nil {
+		return req.RoomID, model.NewErrorResponse("The user is already a member of the room.", http.StatusConflict)
+	}
+
+	seen[key] = true
+	batch.RoomUsers = append(batch.RoomUsers, req.GenerateRoomUser())
+	return req.RoomID, nil
+}
+
+// bulkImportRowReason makes the reason of a failed row from the error response
+func bulkImportRowReason(errRes *model.ErrorResponse) string {
+	if len(errRes.InvalidParams) > 0 {
+		invalidParam := errRes.InvalidParams[0]
+		return fmt.Sprintf("%s: %s", invalidParam.Name, invalidParam.Reason)
+	}
+	return errRes.Message
+}
@@ -10,6 +10,20 @@ import (
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
 
+func confirmAppClientExist(ctx context.Context, clientID string) (*model.AppClient, *model.ErrorResponse) {
+	appClient, err := datastore.Provider(ctx).SelectLatestAppClient(
+		datastore.SelectAppClientOptionFilterByClientID(clientID),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if appClient == nil {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return appClient, nil
+}
+
 func confirmAssetExist(ctx context.Context, assetID string) (*model.Asset, *model.ErrorResponse) {
 	asset, err := datastore.Provider(ctx).SelectAsset(assetID)
 	if err != nil {
@@ -27,6 +41,18 @@ func confirmAssetExist(ctx context.Context, assetID string) (*model.Asset, *mode
 	return asset, nil
 }
 
+func confirmBulkImportExist(ctx context.Context, importID string) (*model.BulkImport, *model.ErrorResponse) {
+	bulkImport, err := datastore.Provider(ctx).SelectBulkImport(importID)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if bulkImport == nil {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return bulkImport, nil
+}
+
 func confirmDeviceExist(ctx context.Context, userID string, platform scpb.Platform) (*model.Device, *model.ErrorResponse) {
 	device, err := datastore.Provider(ctx).SelectDevice(userID, platform)
 	if err != nil {
@@ -63,6 +89,54 @@ func confirmDeviceNotExist(ctx context.Context, userID string, platform scpb.Pla
 	return device, nil
 }
 
+func confirmFriendRequestExist(ctx context.Context, userID, friendRequestID string) (*model.FriendRequest, *model.ErrorResponse) {
+	friendRequest, err := datastore.Provider(ctx).SelectFriendRequest(friendRequestID)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if friendRequest == nil || (friendRequest.RequesterUserID != userID && friendRequest.AddresseeUserID != userID) {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return friendRequest, nil
+}
+
+func confirmInvitationExist(ctx context.Context, userID, invitationID string) (*model.Invitation, *model.ErrorResponse) {
+	invitation, err := datastore.Provider(ctx).SelectInvitation(invitationID)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if invitation == nil || invitation.InviteeUserID != userID {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return invitation, nil
+}
+
+func confirmInviteLinkExist(ctx context.Context, roomID, token string) (*model.InviteLink, *model.ErrorResponse) {
+	inviteLink, err := datastore.Provider(ctx).SelectInviteLink(token)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if inviteLink == nil || (roomID != "" && inviteLink.RoomID != roomID) {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return inviteLink, nil
+}
+
+func confirmJoinRequestExist(ctx context.Context, roomID, joinRequestID string) (*model.JoinRequest, *model.ErrorResponse) {
+	joinRequest, err := datastore.Provider(ctx).SelectJoinRequest(joinRequestID)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if joinRequest == nil || joinRequest.RoomID != roomID {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return joinRequest, nil
+}
+
 func confirmMessageExist(ctx context.Context, messageID string) (*model.Message, *model.ErrorResponse) {
 	message, err := datastore.Provider(ctx).SelectMessage(messageID)
 	if err != nil {
@@ -117,6 +191,18 @@ func confirmRoomExist(ctx context.Context, roomID string, opts ...datastore.Sele
 	return room, nil
 }
 
+func confirmRoomFolderExist(ctx context.Context, userID, folderID string) (*model.RoomFolder, *model.ErrorResponse) {
+	roomFolder, err := datastore.Provider(ctx).SelectRoomFolder(folderID)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if roomFolder == nil || roomFolder.UserID != userID {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return roomFolder, nil
+}
+
 func confirmRoomUserExist(ctx context.Context, roomID, userID string) (*model.RoomUser, *model.ErrorResponse) {
 	roomUser, err := datastore.Provider(ctx).SelectRoomUser(roomID, userID)
 	if err != nil {
@@ -135,6 +221,42 @@ func confirmRoomUserExist(ctx context.Context, roomID, userID string) (*model.Ro
 	return roomUser, nil
 }
 
+func confirmScimGroupExist(ctx context.Context, roomID string) (*model.Room, *model.ErrorResponse) {
+	room, err := datastore.Provider(ctx).SelectRoom(roomID, datastore.SelectRoomOptionWithUsers(true))
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if room == nil || !model.IsScimResource(room.MetaData) {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return room, nil
+}
+
+func confirmScimTokenExist(ctx context.Context, clientID, tokenID string) (*model.ScimToken, *model.ErrorResponse) {
+	scimToken, err := datastore.Provider(ctx).SelectScimToken(datastore.SelectScimTokenOptionFilterByTokenID(tokenID))
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if scimToken == nil || scimToken.ClientID != clientID {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return scimToken, nil
+}
+
+func confirmScimUserExist(ctx context.Context, userID string) (*model.User, *model.ErrorResponse) {
+	user, err := datastore.Provider(ctx).SelectUser(userID)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if user == nil || !model.IsScimResource(user.MetaData) {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return user, nil
+}
+
 func confirmUserExist(ctx context.Context, userID string, opts ...datastore.SelectUserOption) (*model.User, *model.ErrorResponse) {
 	user, err := datastore.Provider(ctx).SelectUser(userID, opts...)
 	if err != nil {
@@ -171,6 +293,18 @@ func confirmUserNotExist(ctx context.Context, userID string, opts ...datastore.S
 	return user, nil
 }
 
+func confirmUserExportExist(ctx context.Context, userID, exportID string) (*model.UserExport, *model.ErrorResponse) {
+	userExport, err := datastore.Provider(ctx).SelectUserExport(exportID)
+	if err != nil {
+		return nil, model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if userExport == nil || userExport.UserID != userID {
+		return nil, model.NewErrorResponse("", http.StatusNotFound)
+	}
+
+	return userExport, nil
+}
+
 func confirmUserIDsExist(ctx context.Context, requestUserIDs []string, keyName string) *model.ErrorResponse {
 	existUserIDs, err := datastore.Provider(ctx).SelectUserIDsOfUser(requestUserIDs)
 	if err != nil {
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/notification"
+	"github.com/swagchat/chat-api/producer"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// CreateFriendRequest sends a friend request to another user
+func CreateFriendRequest(ctx context.Context, req *model.CreateFriendRequestRequest) (*model.FriendRequest, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateFriendRequest", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	if !contactMode(ctx).IncludesExplicit() {
+		return nil, model.NewErrorResponse("Failed to create friend request. Friend requests are not available in this workspace.", http.StatusForbidden)
+	}
+
+	requester, errRes := confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create friend request."
+		return nil, errRes
+	}
+
+	_, errRes = confirmUserExist(ctx, req.AddresseeUserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create friend request."
+		return nil, errRes
+	}
+
+	errRes = confirmNotBlockedEachOther(ctx, req.UserID, req.AddresseeUserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create friend request."
+		return nil, errRes
+	}
+
+	contact, err := datastore.Provider(ctx).SelectContact(req.UserID, req.AddresseeUserID)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create friend request.", http.StatusInternalServerError, model.WithError(err))
+	}
+	if contact != nil {
+		return nil, model.NewErrorResponse("Failed to create friend request. That user is already a contact.", http.StatusConflict)
+	}
+
+	errRes = confirmFriendRequestNotPending(ctx, req.UserID, req.AddresseeUserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create friend request. A friend request has already been sent."
+		return nil, errRes
+	}
+
+	errRes = confirmFriendRequestNotPending(ctx, req.AddresseeUserID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create friend request. That user has already sent you a friend request."
+		return nil, errRes
+	}
+
+	friendRequest := req.GenerateFriendRequest()
+	err = datastore.Provider(ctx).InsertFriendRequest(friendRequest)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create friend request.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go publishFriendRequest(ctx, friendRequest, friendRequest.AddresseeUserID)
+	go pushFriendRequest(ctx, friendRequest, requester)
+
+	return friendRequest, nil
+}
+
+// RetrieveFriendRequests retrieves pending friend requests sent to or by a user
+func RetrieveFriendRequests(ctx context.Context, req *model.RetrieveFriendRequestsRequest) (*model.FriendRequestsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveFriendRequests", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	opts := []datastore.SelectFriendRequestsOption{
+		datastore.SelectFriendRequestsOptionFilterByStatus(model.FriendRequestStatusPending),
+	}
+	if req.Direction == model.FriendRequestDirectionOutgoing {
+		opts = append(opts, datastore.SelectFriendRequestsOptionFilterByRequesterUserID(req.UserID))
+	} else {
+		opts = append(opts, datastore.SelectFriendRequestsOptionFilterByAddresseeUserID(req.UserID))
+	}
+
+	friendRequests, err := datastore.Provider(ctx).SelectFriendRequests(opts...)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve friend requests.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.FriendRequestsResponse{}
+	res.FriendRequests = friendRequests
+	return res, nil
+}
+
+// ReplyFriendRequest accepts, rejects or cancels a friend request
+func ReplyFriendRequest(ctx context.Context, req *model.ReplyFriendRequestRequest) (*model.FriendRequest, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "ReplyFriendRequest", "service")
+	defer tracer.Finish(span)
+
+	friendRequest, errRes := confirmFriendRequestExist(ctx, req.UserID, req.FriendRequestID)
+	if errRes != nil {
+		errRes.Message = "Failed to reply friend request."
+		return nil, errRes
+	}
+
+	if !friendRequest.IsRepliableBy(req.UserID, req.Status) {
+		return nil, model.NewErrorResponse("Failed to reply friend request. You do not have permission.", http.StatusForbidden)
+	}
+
+	if friendRequest.Status != model.FriendRequestStatusPending {
+		return nil, model.NewErrorResponse("Failed to reply friend request. The friend request has already been replied.", http.StatusConflict)
+	}
+
+	var opts []datastore.UpdateFriendRequestOption
+	if req.Status == model.FriendRequestStatusAccepted {
+		if !contactMode(ctx).IncludesExplicit() {
+			return nil, model.NewErrorResponse("Failed to reply friend request. Friend requests are not available in this workspace.", http.StatusForbidden)
+		}
+
+		errRes = confirmNotBlockedEachOther(ctx, friendRequest.RequesterUserID, friendRequest.AddresseeUserID)
+		if errRes != nil {
+			errRes.Message = "Failed to reply friend request."
+			return nil, errRes
+		}
+
+		opts = append(opts, datastore.UpdateFriendRequestOptionWithContacts(friendRequest.GenerateContacts()))
+	}
+
+	friendRequest.Reply(req.Status)
+	err := datastore.Provider(ctx).UpdateFriendRequest(friendRequest, opts...)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to reply friend request.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	if req.Status == model.FriendRequestStatusCanceled {
+		go publishFriendRequest(ctx, friendRequest, friendRequest.AddresseeUserID)
+	} else {
+		go publishFriendRequest(ctx, friendRequest, friendRequest.RequesterUserID)
+	}
+
+	return friendRequest, nil
+}
+
+// DeleteContact removes a contact of both users
+func DeleteContact(ctx context.Context, req *model.DeleteContactRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "DeleteContact", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return errRes
+	}
+
+	contact, err := datastore.Provider(ctx).SelectContact(req.UserID, req.ContactUserID)
+	if err != nil {
+		return model.NewErrorResponse("Failed to delete contact.", http.StatusInternalServerError, model.WithError(err))
+	}
+	if contact == nil {
+		return model.NewErrorResponse("Failed to delete contact.", http.StatusNotFound)
+	}
+
+	err = datastore.Provider(ctx).DeleteContacts(req.UserID, req.ContactUserID)
+	if err != nil {
+		return model.NewErrorResponse("Failed to delete contact.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return nil
+}
+
+// contactMode returns the contact mode of the workspace
+func contactMode(ctx context.Context) model.ContactMode {
+	setting, err := datastore.Provider(ctx).SelectLatestSetting()
+	if err != nil {
+		logger.Error(err.Error())
+		return model.ContactModeImplicit
+	}
+
+	return setting.ContactMode()
+}
+
+func confirmFriendRequestNotPending(ctx context.Context, requesterUserID, addresseeUserID string) *model.ErrorResponse {
+	friendRequests, err := datastore.Provider(ctx).SelectFriendRequests(
+		datastore.SelectFriendRequestsOptionFilterByRequesterUserID(requesterUserID),
+		datastore.SelectFriendRequestsOptionFilterByAddresseeUserID(addresseeUserID),
+		datastore.SelectFriendRequestsOptionFilterByStatus(model.FriendRequestStatusPending),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if len(friendRequests) > 0 {
+		return model.NewErrorResponse("", http.StatusConflict)
+	}
+
+	return nil
+}
+
+func publishFriendRequest(ctx context.Context, friendRequest *model.FriendRequest, userID string) {
+	buffer := new(bytes.Buffer)
+	json.NewEncoder(buffer).Encode(friendRequest)
+	event := &scpb.EventData{
+		Type:    scpb.EventType_MessageEvent,
+		Data:    buffer.Bytes(),
+		UserIDs: []string{userID},
+	}
+	err := producer.Provider(ctx).PublishMessage(event)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+func pushFriendRequest(ctx context.Context, friendRequest *model.FriendRequest, requester *model.User) {
+	mi := &notification.MessageInfo{
+		Text: fmt.Sprintf("%s sent you a friend request", requester.Name),
+	}
+	pushToUser(ctx, friendRequest.AddresseeUserID, "", mi)
+}
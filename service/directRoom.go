@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// GetOrCreateDirectRoom returns the 1-on-1 room of the pair of users, creating it if it does not exist yet
+func GetOrCreateDirectRoom(ctx context.Context, req *model.GetOrCreateDirectRoomRequest) (*model.Room, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "GetOrCreateDirectRoom", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	errRes = confirmUserIDsExist(ctx, []string{req.UserID, req.OtherUserID}, "otherUserId")
+	if errRes != nil {
+		errRes.Message = "Failed to get or create direct message room."
+		return nil, errRes
+	}
+
+	errRes = confirmNotBlockedEachOther(ctx, req.UserID, req.OtherUserID)
+	if errRes != nil {
+		errRes.Message = "Failed to get or create direct message room. " + errRes.Message
+		return nil, errRes
+	}
+
+	room, errRes := selectDirectRoom(ctx, req.UserID, req.OtherUserID)
+	if errRes != nil {
+		return nil, errRes
+	}
+	if room != nil {
+		return room, nil
+	}
+
+	room, errRes = CreateRoom(ctx, req.GenerateCreateRoomRequest())
+	if errRes != nil {
+		// The room may have been created by a concurrent request for the same pair
+		existingRoom, existingErrRes := selectDirectRoom(ctx, req.UserID, req.OtherUserID)
+		if existingErrRes == nil && existingRoom != nil {
+			return existingRoom, nil
+		}
+		errRes.Message = "Failed to get or create direct message room."
+		return nil, errRes
+	}
+
+	return room, nil
+}
+
+func selectDirectRoom(ctx context.Context, userID, otherUserID string) (*model.Room, *model.ErrorResponse) {
+	var roomID string
+
+	directRoom, err := datastore.Provider(ctx).SelectDirectRoom(userID, otherUserID)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to get or create direct message room.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	if directRoom != nil {
+		roomID = directRoom.RoomID
+	} else {
+		// 1-on-1 rooms created before the pair was registered are looked up from both sides
+		roomUser, err := datastore.Provider(ctx).SelectRoomUserOfOneOnOne(userID, otherUserID)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to get or create direct message room.", http.StatusInternalServerError, model.WithError(err))
+		}
+		if roomUser == nil {
+			roomUser, err = datastore.Provider(ctx).SelectRoomUserOfOneOnOne(otherUserID, userID)
+			if err != nil {
+				return nil, model.NewErrorResponse("Failed to get or create direct message room.", http.StatusInternalServerError, model.WithError(err))
+			}
+		}
+		if roomUser == nil {
+			return nil, nil
+		}
+
+		roomID = roomUser.RoomID
+		err = datastore.Provider(ctx).InsertDirectRoom(model.NewDirectRoom(roomID, userID, otherUserID))
+		if err != nil {
+			logger.Error(err.Error())
+		}
+	}
+
+	room, err := datastore.Provider(ctx).SelectRoom(roomID, datastore.SelectRoomOptionWithUsers(true))
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to get or create direct message room.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return room, nil
+}
+
+// confirmDirectRoomNotExist confirms that the pair of users does not have a 1-on-1 room yet
+func confirmDirectRoomNotExist(ctx context.Context, userID, otherUserID string) *model.ErrorResponse {
+	room, errRes := selectDirectRoom(ctx, userID, otherUserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create room."
+		return errRes
+	}
+	if room != nil {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userIds",
+				Reason: "The 1-on-1 room of these users already exists. roomId=" + room.RoomID,
+			},
+		}
+		return model.NewErrorResponse("Failed to create room.", http.StatusConflict, model.WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+// confirmNotBlockedEachOther confirms that neither of the users blocks the other
+func confirmNotBlockedEachOther(ctx context.Context, userID, otherUserID string) *model.ErrorResponse {
+	blockUser, err := datastore.Provider(ctx).SelectBlockUser(userID, otherUserID)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if blockUser == nil {
+		blockUser, err = datastore.Provider(ctx).SelectBlockUser(otherUserID, userID)
+		if err != nil {
+			return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+		}
+	}
+
+	if blockUser != nil {
+		return model.NewErrorResponse("These users can not send direct messages to each other", http.StatusForbidden)
+	}
+
+	return nil
+}
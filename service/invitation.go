@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/notification"
+	"github.com/swagchat/chat-api/producer"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// CreateInvitations creates invitations to a room
+func CreateInvitations(ctx context.Context, req *model.CreateInvitationsRequest) (*model.InvitationsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateInvitations", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID, datastore.SelectRoomOptionWithUsers(true))
+	if errRes != nil {
+		errRes.Message = "Failed to create invitations."
+		return nil, errRes
+	}
+
+	if room.IsArchived() {
+		return nil, archivedRoomErrorResponse("Failed to create invitations.")
+	}
+
+	req.Room = room
+
+	errRes = req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionAddMembers)
+	if errRes != nil {
+		errRes.Message = "Failed to create invitations."
+		return nil, errRes
+	}
+
+	errRes = confirmUserIDsExist(ctx, req.UserIDs, "userIds")
+	if errRes != nil {
+		errRes.Message = "Failed to create invitations."
+		return nil, errRes
+	}
+
+	errRes = confirmUsersNotBanned(ctx, req.RoomID, req.UserIDs, "userIds")
+	if errRes != nil {
+		errRes.Message = "Failed to create invitations."
+		return nil, errRes
+	}
+
+	if userID, restricted := requestUserID(ctx); restricted {
+		errRes = confirmNotBlockedBy(ctx, userID, req.UserIDs)
+		if errRes != nil {
+			errRes.Message = "Failed to create invitations. " + errRes.Message
+			return nil, errRes
+		}
+	}
+
+	// Members and users who already have a pending invitation are not invited again
+	pendingInvitations, err := datastore.Provider(ctx).SelectInvitations(
+		datastore.SelectInvitationsOptionFilterByRoomID(req.RoomID),
+		datastore.SelectInvitationsOptionFilterByStatus(model.InvitationStatusPending),
+		datastore.SelectInvitationsOptionExcludeExpired(true),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create invitations.", http.StatusInternalServerError, model.WithError(err))
+	}
+	invitedUserIDs := make(map[string]bool)
+	for _, invitation := range pendingInvitations {
+		invitedUserIDs[invitation.InviteeUserID] = true
+	}
+	userIDs := make([]string, 0, len(req.UserIDs))
+	for _, userID := range room.NonMemberUserIDs(req.UserIDs) {
+		if !invitedUserIDs[userID] {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	req.UserIDs = userIDs
+
+	invitations := req.GenerateInvitations()
+	err = datastore.Provider(ctx).InsertInvitations(invitations)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create invitations.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	for _, invitation := range invitations {
+		go publishInvitation(ctx, invitation, invitation.InviteeUserID)
+		go pushInvitation(ctx, invitation, room)
+	}
+
+	res := &model.InvitationsResponse{}
+	res.Invitations = invitations
+	return res, nil
+}
+
+// RetrieveUserInvitations retrieves pending invitations of a user
+func RetrieveUserInvitations(ctx context.Context, req *model.RetrieveUserInvitationsRequest) (*model.InvitationsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveUserInvitations", "service")
+	defer tracer.Finish(span)
+
+	invitations, err := datastore.Provider(ctx).SelectInvitations(
+		datastore.SelectInvitationsOptionFilterByInviteeUserID(req.UserID),
+		datastore.SelectInvitationsOptionFilterByStatus(model.InvitationStatusPending),
+		datastore.SelectInvitationsOptionExcludeExpired(true),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve invitations.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.InvitationsResponse{}
+	res.Invitations = invitations
+	return res, nil
+}
+
+// ReplyInvitation accepts or declines an invitation
+func ReplyInvitation(ctx context.Context, req *model.ReplyInvitationRequest) (*model.Invitation, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "ReplyInvitation", "service")
+	defer tracer.Finish(span)
+
+	invitation, errRes := confirmInvitationExist(ctx, req.UserID, req.InvitationID)
+	if errRes != nil {
+		errRes.Message = "Failed to reply invitation."
+		return nil, errRes
+	}
+
+	if invitation.Status != model.InvitationStatusPending {
+		return nil, model.NewErrorResponse("Failed to reply invitation. The invitation has already been replied.", http.StatusConflict)
+	}
+
+	if invitation.IsExpired() {
+		return nil, model.NewErrorResponse("Failed to reply invitation. The invitation has expired.", http.StatusGone)
+	}
+
+	if req.Status == model.InvitationStatusAccepted {
+		room, errRes := confirmRoomExist(ctx, invitation.RoomID, datastore.SelectRoomOptionWithUsers(true))
+		if errRes != nil {
+			errRes.Message = "Failed to reply invitation."
+			return nil, errRes
+		}
+
+		if room.IsArchived() {
+			return nil, archivedRoomErrorResponse("Failed to reply invitation.")
+		}
+
+		addReq := &model.AddRoomUsersRequest{}
+		addReq.RoomID = invitation.RoomID
+		addReq.UserIDs = []string{invitation.InviteeUserID}
+		addReq.Display = true
+		errRes = addRoomUsers(ctx, addReq, room)
+		if errRes != nil {
+			return nil, errRes
+		}
+	}
+
+	invitation.Reply(req.Status)
+	err := datastore.Provider(ctx).UpdateInvitation(invitation)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to reply invitation.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go publishInvitation(ctx, invitation, invitation.InviterUserID)
+
+	return invitation, nil
+}
+
+func publishInvitation(ctx context.Context, invitation *model.Invitation, userID string) {
+	buffer := new(bytes.Buffer)
+	json.NewEncoder(buffer).Encode(invitation)
+	event := &scpb.EventData{
+		Type:    scpb.EventType_RoomEvent,
+		Data:    buffer.Bytes(),
+		UserIDs: []string{userID},
+	}
+	err := producer.Provider(ctx).PublishMessage(event)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+func pushInvitation(ctx context.Context, invitation *model.Invitation, room *model.Room) {
+	mi := &notification.MessageInfo{
+		Text: fmt.Sprintf("You are invited to %s", room.Name),
+	}
+	pushToUser(ctx, invitation.InviteeUserID, room.RoomID, mi)
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// CreateInviteLink creates an invite link of a room
+func CreateInviteLink(ctx context.Context, req *model.CreateInviteLinkRequest) (*model.InviteLink, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateInviteLink", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to create invite link."
+		return nil, errRes
+	}
+
+	if room.Type == scpb.RoomType_OneOnOneRoom {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "roomId",
+				Reason: "In case of 1-on-1 room type, invite links can not be created.",
+			},
+		}
+		return nil, model.NewErrorResponse("Failed to create invite link.", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	}
+
+	if room.IsArchived() {
+		return nil, archivedRoomErrorResponse("Failed to create invite link.")
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionManageInviteLinks)
+	if errRes != nil {
+		errRes.Message = "Failed to create invite link."
+		return nil, errRes
+	}
+
+	if userID, restricted := requestUserID(ctx); restricted && req.MemberRole != nil {
+		role, errRes := roomMemberRole(ctx, room, userID)
+		if errRes != nil {
+			errRes.Message = "Failed to create invite link."
+			return nil, errRes
+		}
+		if *req.MemberRole >= role {
+			return nil, model.NewErrorResponse("Failed to create invite link. You do not have permission", http.StatusUnauthorized)
+		}
+	}
+
+	inviteLink := req.GenerateInviteLink()
+	err := datastore.Provider(ctx).InsertInviteLink(inviteLink)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create invite link.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return inviteLink, nil
+}
+
+// RetrieveInviteLinks retrieves invite links of a room
+func RetrieveInviteLinks(ctx context.Context, req *model.RetrieveInviteLinksRequest) (*model.InviteLinksResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveInviteLinks", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve invite links."
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionManageInviteLinks)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve invite links."
+		return nil, errRes
+	}
+
+	inviteLinks, err := datastore.Provider(ctx).SelectInviteLinks(
+		datastore.SelectInviteLinksOptionFilterByRoomID(req.RoomID),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve invite links.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.InviteLinksResponse{}
+	res.InviteLinks = inviteLinks
+	return res, nil
+}
+
+// RevokeInviteLink revokes an invite link so that it can no longer be redeemed
+func RevokeInviteLink(ctx context.Context, req *model.RevokeInviteLinkRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "RevokeInviteLink", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to revoke invite link."
+		return errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionManageInviteLinks)
+	if errRes != nil {
+		errRes.Message = "Failed to revoke invite link."
+		return errRes
+	}
+
+	inviteLink, errRes := confirmInviteLinkExist(ctx, req.RoomID, req.Token)
+	if errRes != nil {
+		errRes.Message = "Failed to revoke invite link."
+		return errRes
+	}
+
+	if inviteLink.IsRevoked() {
+		return model.NewErrorResponse("Failed to revoke invite link. The invite link has already been revoked.", http.StatusConflict)
+	}
+
+	inviteLink.Revoke()
+	err := datastore.Provider(ctx).UpdateInviteLink(inviteLink)
+	if err != nil {
+		return model.NewErrorResponse("Failed to revoke invite link.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return nil
+}
+
+// RetrieveInviteLinkUses retrieves the users who joined a room through an invite link
+func RetrieveInviteLinkUses(ctx context.Context, req *model.RetrieveInviteLinkUsesRequest) (*model.InviteLinkUsesResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveInviteLinkUses", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve invite link uses."
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionManageInviteLinks)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve invite link uses."
+		return nil, errRes
+	}
+
+	_, errRes = confirmInviteLinkExist(ctx, req.RoomID, req.Token)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve invite link uses."
+		return nil, errRes
+	}
+
+	inviteLinkUses, err := datastore.Provider(ctx).SelectInviteLinkUses(req.Token)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve invite link uses.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.InviteLinkUsesResponse{}
+	res.InviteLinkUses = inviteLinkUses
+	return res, nil
+}
+
+// RedeemInviteLink adds the request user to the room of an invite link
+func RedeemInviteLink(ctx context.Context, req *model.RedeemInviteLinkRequest) (*model.RoomUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RedeemInviteLink", "service")
+	defer tracer.Finish(span)
+
+	inviteLink, errRes := confirmInviteLinkExist(ctx, "", req.Token)
+	if errRes != nil {
+		errRes.Message = "Failed to redeem invite link."
+		return nil, errRes
+	}
+
+	if inviteLink.IsRevoked() {
+		return nil, model.NewErrorResponse("Failed to redeem invite link. The invite link has been revoked.", http.StatusGone)
+	}
+
+	if inviteLink.IsExpired() {
+		return nil, model.NewErrorResponse("Failed to redeem invite link. The invite link has expired.", http.StatusGone)
+	}
+
+	if inviteLink.IsExhausted() {
+		return nil, model.NewErrorResponse("Failed to redeem invite link. The invite link has reached its maximum number of uses.", http.StatusGone)
+	}
+
+	room, errRes := confirmRoomExist(ctx, inviteLink.RoomID, datastore.SelectRoomOptionWithUsers(true))
+	if errRes != nil {
+		errRes.Message = "Failed to redeem invite link."
+		return nil, errRes
+	}
+
+	if room.IsArchived() {
+		return nil, archivedRoomErrorResponse("Failed to redeem invite link.")
+	}
+
+	for _, user := range room.Users {
+		if user.UserID == req.UserID {
+			return nil, model.NewErrorResponse("Failed to redeem invite link. You are already this room member.", http.StatusConflict)
+		}
+	}
+
+	errRes = confirmUsersNotBanned(ctx, inviteLink.RoomID, []string{req.UserID}, "userId")
+	if errRes != nil {
+		errRes.Message = "Failed to redeem invite link."
+		return nil, errRes
+	}
+
+	errRes = confirmNotBlockedBy(ctx, req.UserID, []string{inviteLink.CreatorUserID})
+	if errRes != nil {
+		errRes.Message = "Failed to redeem invite link. " + errRes.Message
+		return nil, errRes
+	}
+
+	addReq := &model.AddRoomUsersRequest{}
+	addReq.RoomID = inviteLink.RoomID
+	addReq.UserIDs = []string{req.UserID}
+	addReq.Display = true
+	errRes = addRoomUsers(ctx, addReq, room, datastore.InsertRoomUsersOptionWithInviteLinkUse(req.GenerateInviteLinkUse(inviteLink.RoomID)))
+	if errRes != nil {
+		if errRes.Status == http.StatusGone {
+			errRes.Message = "Failed to redeem invite link. The invite link has reached its maximum number of uses."
+			return nil, errRes
+		}
+		errRes.Message = "Failed to redeem invite link."
+		return nil, errRes
+	}
+
+	ru, errRes := confirmRoomUserExist(ctx, inviteLink.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to redeem invite link."
+		return nil, errRes
+	}
+
+	if inviteLink.MemberRole != model.RoomMemberRoleMember {
+		ru.MemberRole = inviteLink.MemberRole
+		err := datastore.Provider(ctx).UpdateRoomUser(ru)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to redeem invite link.", http.StatusInternalServerError, model.WithError(err))
+		}
+	}
+
+	return ru, nil
+}
@@ -4,16 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"strconv"
+	"time"
 
 	logger "github.com/betchi/zapper"
 	"github.com/swagchat/chat-api/config"
 	"github.com/swagchat/chat-api/datastore"
 	"github.com/swagchat/chat-api/model"
-	"github.com/swagchat/chat-api/notification"
 	"github.com/swagchat/chat-api/producer"
+	"github.com/swagchat/chat-api/utils"
 	"github.com/betchi/tracer"
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
@@ -28,12 +27,42 @@ func SendMessage(ctx context.Context, req *model.SendMessageRequest) (*model.Mes
 		return nil, errRes
 	}
 
+	// The sender must be the request user, otherwise a user could post as anyone and pass the block check below
+	if userID, restricted := requestUserID(ctx); restricted && userID != *req.UserID {
+		return nil, model.NewErrorResponse("Failed to create message. You can not send a message as another user", http.StatusUnauthorized)
+	}
+
 	room, errRes := confirmRoomExist(ctx, *req.RoomID)
 	if errRes != nil {
 		errRes.Message = "Failed to create message."
 		return nil, errRes
 	}
 
+	if room.IsArchived() {
+		return nil, archivedRoomErrorResponse("Failed to create message.")
+	}
+
+	errRes = confirmRoomUserNotMuted(ctx, room.RoomID, *req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create message. " + errRes.Message
+		return nil, errRes
+	}
+
+	if room.Type == scpb.RoomType_OneOnOneRoom {
+		userIDs, err := datastore.Provider(ctx).SelectUserIDsOfRoomUser(
+			datastore.SelectUserIDsOfRoomUserOptionWithRoomID(room.RoomID),
+		)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to create message.", http.StatusInternalServerError, model.WithError(err))
+		}
+
+		errRes = confirmNotBlockedBy(ctx, *req.UserID, userIDs)
+		if errRes != nil {
+			errRes.Message = "Failed to create message. " + errRes.Message
+			return nil, errRes
+		}
+	}
+
 	user, errRes := confirmUserExist(ctx, *req.UserID, datastore.SelectUserOptionWithRoles(true))
 	if errRes != nil {
 		errRes.Message = "Failed to create message."
@@ -53,25 +82,20 @@ func SendMessage(ctx context.Context, req *model.SendMessageRequest) (*model.Mes
 		return nil, errRes
 	}
 
+	errRes = confirmMessageRateLimit(ctx, room, *req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create message. " + errRes.Message
+		return nil, errRes
+	}
+
 	err := datastore.Provider(ctx).InsertMessage(message)
 	if err != nil {
 		errRes := model.NewErrorResponse("Failed to create message.", http.StatusInternalServerError, model.WithError(err))
 		return nil, errRes
 	}
 
-	// notification
-	lastMessage := "" // TODO
-	mi := &notification.MessageInfo{
-		Text: fmt.Sprintf("[%s]%s", room.Name, lastMessage),
-	}
-	cfg := config.Config()
-	if cfg.Notification.DefaultBadgeCount != "" {
-		dBadgeCount, err := strconv.Atoi(cfg.Notification.DefaultBadgeCount)
-		if err == nil {
-			mi.Badge = dBadgeCount
-		}
-	}
-	go notification.Provider(ctx).Publish(room.NotificationTopicID, room.RoomID, mi)
+	// notification. The text and the badge are set for each recipient in publishNotification
+	go publishNotification(ctx, room, message, user)
 
 	publishMessage(ctx, message)
 	webhookMessage(ctx, message, user)
@@ -105,6 +129,46 @@ func RetrieveMessage(ctx context.Context, messageID string) (*model.Message, *mo
 	return message, nil
 }
 
+// sendSystemMessage posts a message generated by the server to the room history
+func sendSystemMessage(ctx context.Context, roomID, userID string, payload interface{}) {
+	buffer := new(bytes.Buffer)
+	err := json.NewEncoder(buffer).Encode(payload)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	nowTimestamp := time.Now().Unix()
+	message := &model.Message{}
+	message.MessageID = utils.GenerateUUID()
+	message.RoomID = roomID
+	message.UserID = userID
+	message.Type = model.MessageTypeUpdateRoomUser
+	message.Payload = model.JSONText(buffer.Bytes())
+	message.Role = config.RoleGeneral
+	message.CreatedTimestamp = nowTimestamp
+	message.ModifiedTimestamp = nowTimestamp
+
+	err = datastore.Provider(ctx).InsertMessage(message)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	publishMessage(ctx, message)
+}
+
+// sendRoomChangeMessage posts the system message of a membership or room change.
+// The actor is the sender, or the first target user if the change is made by a server
+func sendRoomChangeMessage(ctx context.Context, roomID string, payload *model.PayloadRoomChange) {
+	userID := payload.ActorUserID
+	if userID == "" && len(payload.UserIDs) > 0 {
+		userID = payload.UserIDs[0]
+	}
+
+	sendSystemMessage(ctx, roomID, userID, payload)
+}
+
 func publishMessage(ctx context.Context, message *model.Message) {
 	userIDs, err := datastore.Provider(ctx).SelectUserIDsOfRoomUser(
 		datastore.SelectUserIDsOfRoomUserOptionWithRoomID(message.RoomID),
@@ -115,6 +179,13 @@ func publishMessage(ctx context.Context, message *model.Message) {
 		return
 	}
 
+	// Users who block the sender do not receive the message
+	userIDs, err = excludeBlockerUserIDs(ctx, message.UserID, userIDs)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
 	buffer := new(bytes.Buffer)
 	json.NewEncoder(buffer).Encode(message)
 	event := &scpb.EventData{
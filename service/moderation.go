@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// KickRoomUser removes a member from a room. The member can join the room again
+func KickRoomUser(ctx context.Context, req *model.ModerateRoomUserRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "KickRoomUser", "service")
+	defer tracer.Finish(span)
+
+	req.Action = model.ModerationActionKick
+	errRes := confirmModeration(ctx, req, model.RoomPermissionRemoveMembers)
+	if errRes != nil {
+		return errRes
+	}
+
+	ru, errRes := confirmRoomUserExist(ctx, req.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to kick room user."
+		return errRes
+	}
+
+	err := datastore.Provider(ctx).DeleteRoomUsers(
+		datastore.DeleteRoomUsersOptionFilterByRoomIDs([]string{req.RoomID}),
+		datastore.DeleteRoomUsersOptionFilterByUserIDs([]string{req.UserID}),
+	)
+	if err != nil {
+		return model.NewErrorResponse("Failed to kick room user.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go sendRoomChangeMessage(ctx, req.RoomID, model.NewMembershipChange(false, req.ModeratorUserID, []string{req.UserID}))
+	go unsubscribeByRoomUsers(ctx, []*model.RoomUser{ru})
+
+	return recordModeration(ctx, req, 0)
+}
+
+// BanRoomUser removes a user from a room and keeps the user from being added again until the ban expires or is lifted
+func BanRoomUser(ctx context.Context, req *model.ModerateRoomUserRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "BanRoomUser", "service")
+	defer tracer.Finish(span)
+
+	req.Action = model.ModerationActionBan
+	errRes := confirmModeration(ctx, req, model.RoomPermissionModerateMembers)
+	if errRes != nil {
+		return errRes
+	}
+
+	_, errRes = confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to ban room user."
+		return errRes
+	}
+
+	ru, err := datastore.Provider(ctx).SelectRoomUser(req.RoomID, req.UserID)
+	if err != nil {
+		return model.NewErrorResponse("Failed to ban room user.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	if ru != nil {
+		err = datastore.Provider(ctx).DeleteRoomUsers(
+			datastore.DeleteRoomUsersOptionFilterByRoomIDs([]string{req.RoomID}),
+			datastore.DeleteRoomUsersOptionFilterByUserIDs([]string{req.UserID}),
+		)
+		if err != nil {
+			return model.NewErrorResponse("Failed to ban room user.", http.StatusInternalServerError, model.WithError(err))
+		}
+
+		go sendRoomChangeMessage(ctx, req.RoomID, model.NewMembershipChange(false, req.ModeratorUserID, []string{req.UserID}))
+		go unsubscribeByRoomUsers(ctx, []*model.RoomUser{ru})
+	}
+
+	sanction := req.GenerateRoomSanction(model.RoomSanctionTypeBan)
+	err = datastore.Provider(ctx).InsertRoomSanction(sanction)
+	if err != nil {
+		return model.NewErrorResponse("Failed to ban room user.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return recordModeration(ctx, req, sanction.Expired)
+}
+
+// UnbanRoomUser lifts the ban of a user in a room
+func UnbanRoomUser(ctx context.Context, req *model.ModerateRoomUserRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "UnbanRoomUser", "service")
+	defer tracer.Finish(span)
+
+	req.Action = model.ModerationActionUnban
+	return liftRoomSanction(ctx, req, model.RoomSanctionTypeBan)
+}
+
+// MuteRoomUser keeps a member from sending messages to a room until the mute expires or is lifted
+func MuteRoomUser(ctx context.Context, req *model.ModerateRoomUserRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "MuteRoomUser", "service")
+	defer tracer.Finish(span)
+
+	req.Action = model.ModerationActionMute
+	errRes := confirmModeration(ctx, req, model.RoomPermissionModerateMembers)
+	if errRes != nil {
+		return errRes
+	}
+
+	_, errRes = confirmRoomUserExist(ctx, req.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to mute room user."
+		return errRes
+	}
+
+	sanction := req.GenerateRoomSanction(model.RoomSanctionTypeMute)
+	err := datastore.Provider(ctx).InsertRoomSanction(sanction)
+	if err != nil {
+		return model.NewErrorResponse("Failed to mute room user.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return recordModeration(ctx, req, sanction.Expired)
+}
+
+// UnmuteRoomUser lifts the mute of a member in a room
+func UnmuteRoomUser(ctx context.Context, req *model.ModerateRoomUserRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "UnmuteRoomUser", "service")
+	defer tracer.Finish(span)
+
+	req.Action = model.ModerationActionUnmute
+	return liftRoomSanction(ctx, req, model.RoomSanctionTypeMute)
+}
+
+// RetrieveModerationLogs retrieves the moderation audit trail of a room
+func RetrieveModerationLogs(ctx context.Context, req *model.RetrieveModerationLogsRequest) (*model.ModerationLogsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveModerationLogs", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve moderation logs."
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionModerateMembers)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve moderation logs."
+		return nil, errRes
+	}
+
+	moderationLogs, err := datastore.Provider(ctx).SelectModerationLogs(
+		req.Limit,
+		req.Offset,
+		datastore.SelectModerationLogsOptionFilterByRoomID(req.RoomID),
+		datastore.SelectModerationLogsOptionFilterByTargetUserID(req.UserID),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve moderation logs.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.ModerationLogsResponse{}
+	res.ModerationLogs = moderationLogs
+	res.Limit = req.Limit
+	res.Offset = req.Offset
+	return res, nil
+}
+
+// confirmModeration confirms that the request user is allowed to take the moderation action against the target user
+func confirmModeration(ctx context.Context, req *model.ModerateRoomUserRequest, permission model.RoomPermission) *model.ErrorResponse {
+	errMessage := "Failed to " + string(req.Action) + " room user."
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return errRes
+	}
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = errMessage
+		return errRes
+	}
+
+	if req.UserID == room.UserID {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "The room owner can not be moderated.",
+			},
+		}
+		return model.NewErrorResponse(errMessage, http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, permission)
+	if errRes != nil {
+		errRes.Message = errMessage
+		return errRes
+	}
+
+	errRes = roomMemberOutrankAuthz(ctx, room, []string{req.UserID})
+	if errRes != nil {
+		errRes.Message = errMessage
+		return errRes
+	}
+
+	return nil
+}
+
+func liftRoomSanction(ctx context.Context, req *model.ModerateRoomUserRequest, sanctionType model.RoomSanctionType) *model.ErrorResponse {
+	errMessage := "Failed to " + string(req.Action) + " room user."
+
+	errRes := confirmModeration(ctx, req, model.RoomPermissionModerateMembers)
+	if errRes != nil {
+		return errRes
+	}
+
+	sanctions, err := datastore.Provider(ctx).SelectRoomSanctions(
+		datastore.SelectRoomSanctionsOptionFilterByRoomID(req.RoomID),
+		datastore.SelectRoomSanctionsOptionFilterByUserIDs([]string{req.UserID}),
+		datastore.SelectRoomSanctionsOptionFilterByType(sanctionType),
+		datastore.SelectRoomSanctionsOptionExcludeExpired(true),
+	)
+	if err != nil {
+		return model.NewErrorResponse(errMessage, http.StatusInternalServerError, model.WithError(err))
+	}
+	if len(sanctions) == 0 {
+		return model.NewErrorResponse(errMessage, http.StatusNotFound)
+	}
+
+	err = datastore.Provider(ctx).DeleteRoomSanction(req.RoomID, req.UserID, sanctionType)
+	if err != nil {
+		return model.NewErrorResponse(errMessage, http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return recordModeration(ctx, req, 0)
+}
+
+// recordModeration writes the moderation log and posts the system message of the action
+func recordModeration(ctx context.Context, req *model.ModerateRoomUserRequest, expired int64) *model.ErrorResponse {
+	moderationLog := req.GenerateModerationLog(expired)
+	err := datastore.Provider(ctx).InsertModerationLog(moderationLog)
+	if err != nil {
+		return model.NewErrorResponse("Failed to "+string(req.Action)+" room user.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	payload := &model.PayloadModeration{
+		Action:          req.Action,
+		UserID:          req.UserID,
+		ModeratorUserID: req.ModeratorUserID,
+		Reason:          req.Reason,
+		Expired:         expired,
+	}
+	go sendSystemMessage(ctx, req.RoomID, req.ModeratorUserID, payload)
+
+	return nil
+}
+
+// confirmUsersNotBanned confirms that none of the users is banned from the room
+func confirmUsersNotBanned(ctx context.Context, roomID string, userIDs []string, keyName string) *model.ErrorResponse {
+	sanctions, err := datastore.Provider(ctx).SelectRoomSanctions(
+		datastore.SelectRoomSanctionsOptionFilterByRoomID(roomID),
+		datastore.SelectRoomSanctionsOptionFilterByUserIDs(userIDs),
+		datastore.SelectRoomSanctionsOptionFilterByType(model.RoomSanctionTypeBan),
+		datastore.SelectRoomSanctionsOptionExcludeExpired(true),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	if len(sanctions) > 0 {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   keyName,
+				Reason: "It contains a userId that is banned from this room.",
+			},
+		}
+		return model.NewErrorResponse("", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	}
+
+	return nil
+}
+
+// confirmRoomUserNotMuted confirms that the user is not muted by a moderator of the room
+func confirmRoomUserNotMuted(ctx context.Context, roomID, userID string) *model.ErrorResponse {
+	sanctions, err := datastore.Provider(ctx).SelectRoomSanctions(
+		datastore.SelectRoomSanctionsOptionFilterByRoomID(roomID),
+		datastore.SelectRoomSanctionsOptionFilterByUserIDs([]string{userID}),
+		datastore.SelectRoomSanctionsOptionFilterByType(model.RoomSanctionTypeMute),
+		datastore.SelectRoomSanctionsOptionExcludeExpired(true),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	if len(sanctions) > 0 {
+		return model.NewErrorResponse("You are muted in this room", http.StatusForbidden)
+	}
+
+	return nil
+}
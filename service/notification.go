@@ -339,3 +339,181 @@ func createTopic(ctx context.Context, roomID string) (string, *model.ErrorRespon
 	}
 	return *nRes.Data.(*string), nil
 }
+
+func publishRoomUpdate(ctx context.Context, room *model.Room) {
+	userIDs, err := datastore.Provider(ctx).SelectUserIDsOfRoomUser(
+		datastore.SelectUserIDsOfRoomUserOptionWithRoomID(room.RoomID),
+	)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	buffer := new(bytes.Buffer)
+	json.NewEncoder(buffer).Encode(room)
+	event := &scpb.EventData{
+		Type:    scpb.EventType_RoomEvent,
+		Data:    buffer.Bytes(),
+		UserIDs: userIDs,
+	}
+	err = producer.Provider(ctx).PublishMessage(event)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+// publishNotification pushes the message to the room members in the language of each member with their unread count as the badge.
+// The room topic is used while no member restricts notifications and every member gets the same push,
+// otherwise the recipients are pushed in groups of the same text and badge
+func publishNotification(ctx context.Context, room *model.Room, message *model.Message, sender *model.User) {
+	span := tracer.StartSpan(ctx, "publishNotification", "service")
+	defer tracer.Finish(span)
+
+	roomUsers, err := datastore.Provider(ctx).SelectRoomUsers(
+		datastore.SelectRoomUsersOptionWithRoomID(room.RoomID),
+	)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	memberUserIDs := make([]string, 0, len(roomUsers))
+	for _, ru := range roomUsers {
+		if ru.UserID != message.UserID {
+			memberUserIDs = append(memberUserIDs, ru.UserID)
+		}
+	}
+	if len(memberUserIDs) == 0 {
+		return
+	}
+
+	blockerUserIDs, err := datastore.Provider(ctx).SelectBlockedUserIDs(message.UserID)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	templates, err := datastore.Provider(ctx).SelectNotificationTemplates()
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+	nts := model.NewNotificationTemplateSet(templates, config.Config().Notification.DefaultLang)
+
+	members, err := datastore.Provider(ctx).SelectUsersByUserIDs(memberUserIDs)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+	users := make(map[string]*model.User, len(members))
+	for _, user := range members {
+		users[user.UserID] = user
+	}
+
+	userUnreadCounts, err := datastore.Provider(ctx).SelectUnreadCountsOfRoomMembers(room.RoomID)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+	badges := make(map[string]int, len(userUnreadCounts))
+	for _, uuc := range userUnreadCounts {
+		badges[uuc.UserID] = int(uuc.UnreadCount)
+	}
+
+	senderName := sender.Name
+	if senderName == "" {
+		senderName = sender.UserID
+	}
+
+	now := time.Now()
+	restricted := false
+	var topicMi *notification.MessageInfo
+	recipientUserIDs := make(map[notification.MessageInfo][]string)
+	for _, ru := range roomUsers {
+		if ru.UserID == message.UserID {
+			continue
+		}
+
+		// Users who block the sender are not notified
+		if utils.SearchStringValueInSlice(blockerUserIDs, ru.UserID) {
+			restricted = true
+			continue
+		}
+
+		// Suspended and deactivated users are not notified. Their topic subscriptions are already deleted
+		user, ok := users[ru.UserID]
+		if !ok || !user.IsAvailable() {
+			continue
+		}
+
+		// The devices of a user whose suspension has just expired are not subscribed to the topic yet
+		if user.IsSuspensionExpired() {
+			reactivateExpiredSuspension(ctx, user)
+			restricted = true
+		}
+
+		mi := notification.MessageInfo{
+			Text:  nts.Render(user.Lang, message, senderName, room.Name),
+			Badge: badges[ru.UserID],
+		}
+		if topicMi == nil {
+			topicMi = &mi
+		} else if mi != *topicMi {
+			restricted = true
+		}
+
+		dnd := user.IsDoNotDisturb(now)
+		if ru.IsNotificationRestricted(now) || dnd {
+			restricted = true
+		}
+		if ru.ShouldNotify(message, now) && !dnd {
+			recipientUserIDs[mi] = append(recipientUserIDs[mi], ru.UserID)
+		}
+	}
+
+	if !restricted && topicMi != nil {
+		nRes := <-notification.Provider(ctx).Publish(room.NotificationTopicID, room.RoomID, topicMi)
+		if nRes.Error != nil {
+			logger.Error(nRes.Error.Error())
+		}
+		return
+	}
+
+	for mi, userIDs := range recipientUserIDs {
+		mi := mi
+		pushToDevices(ctx, userIDs, room.RoomID, &mi)
+	}
+}
+
+// pushToUser pushes to every device of the user without the room topic. Suspended and deactivated users are not pushed
+func pushToUser(ctx context.Context, userID, roomID string, mi *notification.MessageInfo) {
+	user, err := datastore.Provider(ctx).SelectUser(userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+	if user == nil || !user.IsAvailable() {
+		return
+	}
+
+	pushToDevices(ctx, []string{userID}, roomID, mi)
+}
+
+// pushToDevices pushes the same message info to every device of the users
+func pushToDevices(ctx context.Context, userIDs []string, roomID string, mi *notification.MessageInfo) {
+	devices, err := datastore.Provider(ctx).SelectDevices(datastore.SelectDevicesOptionFilterByUserIDs(userIDs))
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	for _, device := range devices {
+		if device.NotificationDeviceID == "" {
+			continue
+		}
+		nRes := <-notification.Provider(ctx).PublishToEndpoint(device.NotificationDeviceID, roomID, mi)
+		if nRes.Error != nil {
+			logger.Error(nRes.Error.Error())
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+)
+
+// UpdateRoomUserNotificationSetting updates notification level and mute of a room user
+func UpdateRoomUserNotificationSetting(ctx context.Context, req *model.UpdateRoomUserNotificationSettingRequest) (*model.RoomUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "UpdateRoomUserNotificationSetting", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	ru, errRes := confirmRoomUserExist(ctx, req.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to update notification setting."
+		return nil, errRes
+	}
+
+	ru.UpdateNotificationSetting(req)
+
+	err := datastore.Provider(ctx).UpdateRoomUser(ru)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to update notification setting.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return ru, nil
+}
+
+// UpdateUserDoNotDisturb updates do-not-disturb schedule of a user
+func UpdateUserDoNotDisturb(ctx context.Context, req *model.UpdateUserDoNotDisturbRequest) (*model.User, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "UpdateUserDoNotDisturb", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	user, errRes := confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to update do-not-disturb."
+		return nil, errRes
+	}
+
+	user.UpdateDoNotDisturb(req)
+
+	err := datastore.Provider(ctx).UpdateUser(user)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to update do-not-disturb.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return user, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+)
+
+// RetrieveNotificationTemplates retrieves the notification templates of the workspace
+func RetrieveNotificationTemplates(ctx context.Context) (*model.NotificationTemplatesResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveNotificationTemplates", "service")
+	defer tracer.Finish(span)
+
+	notificationTemplates, err := datastore.Provider(ctx).SelectNotificationTemplates()
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to get notification templates.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return &model.NotificationTemplatesResponse{
+		NotificationTemplates: notificationTemplates,
+	}, nil
+}
+
+// PutNotificationTemplate creates or replaces the notification template of a message type in a language
+func PutNotificationTemplate(ctx context.Context, req *model.PutNotificationTemplateRequest) (*model.NotificationTemplate, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "PutNotificationTemplate", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	nt := req.GenerateNotificationTemplate()
+
+	existingNt, err := datastore.Provider(ctx).SelectNotificationTemplate(nt.MessageType, nt.Lang)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to put notification template.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	if existingNt == nil {
+		err = datastore.Provider(ctx).InsertNotificationTemplate(nt)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to put notification template.", http.StatusInternalServerError, model.WithError(err))
+		}
+		return nt, nil
+	}
+
+	existingNt.Body = nt.Body
+	existingNt.Modified = time.Now().Unix()
+	err = datastore.Provider(ctx).UpdateNotificationTemplate(existingNt)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to put notification template.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return existingNt, nil
+}
+
+// DeleteNotificationTemplate deletes the notification template of a message type in a language.
+// The message type falls back to the default template or the built-in template afterwards
+func DeleteNotificationTemplate(ctx context.Context, req *model.DeleteNotificationTemplateRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "DeleteNotificationTemplate", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return errRes
+	}
+
+	lang := model.NormalizeLang(req.Lang)
+	nt, err := datastore.Provider(ctx).SelectNotificationTemplate(req.MessageType, lang)
+	if err != nil {
+		return model.NewErrorResponse("Failed to delete notification template.", http.StatusInternalServerError, model.WithError(err))
+	}
+	if nt == nil {
+		return model.NewErrorResponse("Failed to delete notification template.", http.StatusNotFound)
+	}
+
+	err = datastore.Provider(ctx).DeleteNotificationTemplate(req.MessageType, lang)
+	if err != nil {
+		return model.NewErrorResponse("Failed to delete notification template.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
+)
+
+// profileRelationships resolves the relationship of the viewer to each of the users
+func profileRelationships(ctx context.Context, viewerUserID string, userIDs []string) (map[string]model.ProfileRelationship, error) {
+	relationships := make(map[string]model.ProfileRelationship, len(userIDs))
+	otherUserIDs := make([]string, 0, len(userIDs))
+	for _, userID := range utils.RemoveDuplicateString(userIDs) {
+		if userID == viewerUserID {
+			relationships[userID] = model.ProfileRelationshipSelf
+			continue
+		}
+		relationships[userID] = model.ProfileRelationshipAnyone
+		otherUserIDs = append(otherUserIDs, userID)
+	}
+
+	if len(otherUserIDs) == 0 {
+		return relationships, nil
+	}
+
+	mode := contactMode(ctx)
+
+	roomMateUserIDs, err := datastore.Provider(ctx).SelectRoomMateUserIDs(viewerUserID, otherUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range roomMateUserIDs {
+		if mode.IncludesImplicit() {
+			relationships[userID] = model.ProfileRelationshipContact
+		} else {
+			relationships[userID] = model.ProfileRelationshipSharedRoom
+		}
+	}
+
+	if mode.IncludesExplicit() {
+		contactUserIDs, err := datastore.Provider(ctx).SelectContactUserIDs(viewerUserID, otherUserIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range contactUserIDs {
+			relationships[userID] = model.ProfileRelationshipContact
+		}
+	}
+
+	return relationships, nil
+}
+
+// applyUsersProfileVisibility strips the fields of the users that the requesting user is not allowed to see.
+// Requests of an admin or an app client see everything
+func applyUsersProfileVisibility(ctx context.Context, users []*model.User) error {
+	viewerUserID, restricted := requestUserID(ctx)
+	if !restricted || len(users) == 0 {
+		return nil
+	}
+
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.UserID
+	}
+
+	relationships, err := profileRelationships(ctx, viewerUserID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		user.ApplyProfileVisibility(relationships[user.UserID])
+	}
+
+	return nil
+}
+
+// applyMiniUsersProfileVisibility strips the fields of the room members that the requesting user is not allowed to see
+func applyMiniUsersProfileVisibility(ctx context.Context, miniUsers []*model.MiniUser) error {
+	viewerUserID, restricted := requestUserID(ctx)
+	if !restricted || len(miniUsers) == 0 {
+		return nil
+	}
+
+	userIDs := make([]string, len(miniUsers))
+	for i, miniUser := range miniUsers {
+		userIDs[i] = miniUser.UserID
+	}
+
+	relationships, err := profileRelationships(ctx, viewerUserID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, miniUser := range miniUsers {
+		miniUser.ApplyProfileVisibility(relationships[miniUser.UserID])
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/producer"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// RetrievePublicRooms retrieves the public room directory
+func RetrievePublicRooms(ctx context.Context, req *model.RetrievePublicRoomsRequest) (*model.PublicRoomsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrievePublicRooms", "service")
+	defer tracer.Finish(span)
+
+	rooms, err := datastore.Provider(ctx).SelectPublicRooms(
+		req.Limit,
+		req.Offset,
+		datastore.SelectPublicRoomsOptionFilterByName(req.Name),
+		datastore.SelectPublicRoomsOptionFilterByTags(req.Tags),
+		datastore.SelectPublicRoomsOptionWithSort(req.Sort),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve public rooms.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	count, err := datastore.Provider(ctx).SelectCountPublicRooms(
+		datastore.SelectPublicRoomsOptionFilterByName(req.Name),
+		datastore.SelectPublicRoomsOptionFilterByTags(req.Tags),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve public rooms.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.PublicRoomsResponse{}
+	res.Rooms = rooms
+	res.AllCount = count
+	res.Limit = req.Limit
+	res.Offset = req.Offset
+	return res, nil
+}
+
+// JoinRoom joins a public room. If the room requires approval, a join request is returned instead
+func JoinRoom(ctx context.Context, req *model.JoinRoomRequest) (*model.JoinRequest, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "JoinRoom", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID, datastore.SelectRoomOptionWithUsers(true))
+	if errRes != nil {
+		errRes.Message = "Failed to join room."
+		return nil, errRes
+	}
+
+	if room.Type != scpb.RoomType_PublicRoom {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "roomId",
+				Reason: "Only public rooms can be joined.",
+			},
+		}
+		return nil, model.NewErrorResponse("Failed to join room.", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	}
+
+	if room.IsArchived() {
+		return nil, archivedRoomErrorResponse("Failed to join room.")
+	}
+
+	for _, user := range room.Users {
+		if user.UserID == req.UserID {
+			return nil, model.NewErrorResponse("Failed to join room. You are already this room member.", http.StatusConflict)
+		}
+	}
+
+	errRes = confirmUsersNotBanned(ctx, req.RoomID, []string{req.UserID}, "userId")
+	if errRes != nil {
+		errRes.Message = "Failed to join room."
+		return nil, errRes
+	}
+
+	errRes = confirmNotBlockedBy(ctx, req.UserID, []string{room.UserID})
+	if errRes != nil {
+		errRes.Message = "Failed to join room. " + errRes.Message
+		return nil, errRes
+	}
+
+	switch room.JoinPolicy {
+	case model.RoomJoinPolicyInviteOnly:
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "roomId",
+				Reason: "This room is invite-only.",
+			},
+		}
+		return nil, model.NewErrorResponse("Failed to join room.", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	case model.RoomJoinPolicyApproval:
+		joinRequests, err := datastore.Provider(ctx).SelectJoinRequests(
+			datastore.SelectJoinRequestsOptionFilterByRoomID(req.RoomID),
+			datastore.SelectJoinRequestsOptionFilterByUserID(req.UserID),
+			datastore.SelectJoinRequestsOptionFilterByStatus(model.JoinRequestStatusPending),
+		)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to join room.", http.StatusInternalServerError, model.WithError(err))
+		}
+		if len(joinRequests) > 0 {
+			return nil, model.NewErrorResponse("Failed to join room. The join request is already pending.", http.StatusConflict)
+		}
+
+		joinRequest := req.GenerateJoinRequest()
+		err = datastore.Provider(ctx).InsertJoinRequest(joinRequest)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to join room.", http.StatusInternalServerError, model.WithError(err))
+		}
+
+		go publishJoinRequest(ctx, joinRequest, room.UserID)
+
+		return joinRequest, nil
+	}
+
+	addReq := &model.AddRoomUsersRequest{}
+	addReq.RoomID = req.RoomID
+	addReq.UserIDs = []string{req.UserID}
+	addReq.Display = true
+	errRes = addRoomUsers(ctx, addReq, room)
+	if errRes != nil {
+		errRes.Message = "Failed to join room."
+		return nil, errRes
+	}
+
+	return nil, nil
+}
+
+// LeaveRoom leaves a room
+func LeaveRoom(ctx context.Context, req *model.LeaveRoomRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "LeaveRoom", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID, datastore.SelectRoomOptionWithUsers(true))
+	if errRes != nil {
+		errRes.Message = "Failed to leave room."
+		return errRes
+	}
+
+	ru, errRes := confirmRoomUserExist(ctx, req.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to leave room."
+		return errRes
+	}
+
+	if !room.CanLeft {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "roomId",
+				Reason: "Users can not leave this room.",
+			},
+		}
+		return model.NewErrorResponse("Failed to leave room.", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	}
+
+	err := datastore.Provider(ctx).DeleteRoomUsers(
+		datastore.DeleteRoomUsersOptionFilterByRoomIDs([]string{req.RoomID}),
+		datastore.DeleteRoomUsersOptionFilterByUserIDs([]string{req.UserID}),
+	)
+	if err != nil {
+		return model.NewErrorResponse("Failed to leave room.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go sendRoomChangeMessage(ctx, req.RoomID, model.NewMembershipChange(false, req.UserID, []string{req.UserID}))
+
+	if req.UserID == room.UserID {
+		errRes = succeedRoomOwnership(ctx, room, model.RoomOwnershipTransferReasonOwnerLeft)
+		if errRes != nil {
+			errRes.Message = "Failed to leave room."
+			return errRes
+		}
+	}
+
+	go unsubscribeByRoomUsers(ctx, []*model.RoomUser{ru})
+
+	return nil
+}
+
+// RetrieveJoinRequests retrieves pending join requests of a room
+func RetrieveJoinRequests(ctx context.Context, req *model.RetrieveJoinRequestsRequest) (*model.JoinRequestsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveJoinRequests", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve join requests."
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionAddMembers)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve join requests."
+		return nil, errRes
+	}
+
+	joinRequests, err := datastore.Provider(ctx).SelectJoinRequests(
+		datastore.SelectJoinRequestsOptionFilterByRoomID(req.RoomID),
+		datastore.SelectJoinRequestsOptionFilterByStatus(model.JoinRequestStatusPending),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve join requests.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.JoinRequestsResponse{}
+	res.JoinRequests = joinRequests
+	return res, nil
+}
+
+// ReplyJoinRequest approves or rejects a join request
+func ReplyJoinRequest(ctx context.Context, req *model.ReplyJoinRequestRequest) (*model.JoinRequest, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "ReplyJoinRequest", "service")
+	defer tracer.Finish(span)
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID, datastore.SelectRoomOptionWithUsers(true))
+	if errRes != nil {
+		errRes.Message = "Failed to reply join request."
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionAddMembers)
+	if errRes != nil {
+		errRes.Message = "Failed to reply join request."
+		return nil, errRes
+	}
+
+	joinRequest, errRes := confirmJoinRequestExist(ctx, req.RoomID, req.JoinRequestID)
+	if errRes != nil {
+		errRes.Message = "Failed to reply join request."
+		return nil, errRes
+	}
+
+	if joinRequest.Status != model.JoinRequestStatusPending {
+		return nil, model.NewErrorResponse("Failed to reply join request. The join request has already been replied.", http.StatusConflict)
+	}
+
+	if req.Status == model.JoinRequestStatusApproved {
+		isMember := false
+		for _, user := range room.Users {
+			if user.UserID == joinRequest.UserID {
+				isMember = true
+				break
+			}
+		}
+
+		if !isMember {
+			addReq := &model.AddRoomUsersRequest{}
+			addReq.RoomID = joinRequest.RoomID
+			addReq.UserIDs = []string{joinRequest.UserID}
+			addReq.Display = true
+			errRes = addRoomUsers(ctx, addReq, room)
+			if errRes != nil {
+				return nil, errRes
+			}
+		}
+	}
+
+	joinRequest.Reply(req.Status)
+	err := datastore.Provider(ctx).UpdateJoinRequest(joinRequest)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to reply join request.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go publishJoinRequest(ctx, joinRequest, joinRequest.UserID)
+
+	return joinRequest, nil
+}
+
+func publishJoinRequest(ctx context.Context, joinRequest *model.JoinRequest, userID string) {
+	buffer := new(bytes.Buffer)
+	json.NewEncoder(buffer).Encode(joinRequest)
+	event := &scpb.EventData{
+		Type:    scpb.EventType_RoomEvent,
+		Data:    buffer.Bytes(),
+		UserIDs: []string{userID},
+	}
+	err := producer.Provider(ctx).PublishMessage(event)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
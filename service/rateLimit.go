@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/ratelimiter"
+)
+
+const (
+	userSearchRateLimit  = 30
+	userSearchRatePeriod = time.Minute
+)
+
+// confirmMessageRateLimit confirms that the user has not exceeded the slow mode and the burst limit of the room.
+// Members who are granted RoomPermissionBypassRateLimits are exempt
+func confirmMessageRateLimit(ctx context.Context, room *model.Room, userID string) *model.ErrorResponse {
+	if !room.IsRateLimited() {
+		return nil
+	}
+
+	if _, restricted := requestUserID(ctx); !restricted {
+		return nil
+	}
+
+	role := model.RoomMemberRoleMember
+	if userID == room.UserID {
+		role = model.RoomMemberRoleOwner
+	} else {
+		ru, err := datastore.Provider(ctx).SelectRoomUser(room.RoomID, userID)
+		if err != nil {
+			return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+		}
+		if ru != nil {
+			role = ru.MemberRole
+		}
+	}
+	if role.Can(model.RoomPermissionBypassRateLimits) {
+		return nil
+	}
+
+	// Both limits are checked before either is counted, so that a message rejected by one does not use up the other
+	var burstLimit, slowModeLimit *ratelimiter.Limit
+	limits := make([]*ratelimiter.Limit, 0, 2)
+	if room.BurstLimit > 0 {
+		burstLimit = &ratelimiter.Limit{
+			Key:    fmt.Sprintf("burst:%s:%s", room.RoomID, userID),
+			Limit:  room.BurstLimit,
+			Period: time.Duration(room.BurstWindow) * time.Second,
+		}
+		limits = append(limits, burstLimit)
+	}
+	if room.SlowModeInterval > 0 {
+		slowModeLimit = &ratelimiter.Limit{
+			Key:    fmt.Sprintf("slow:%s:%s", room.RoomID, userID),
+			Limit:  1,
+			Period: time.Duration(room.SlowModeInterval) * time.Second,
+		}
+		limits = append(limits, slowModeLimit)
+	}
+
+	rejectedLimit, wait, err := ratelimiter.Provider(ctx).TakeAll(limits)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	switch {
+	case rejectedLimit == nil:
+		return nil
+	case rejectedLimit == slowModeLimit:
+		return model.NewErrorResponse("Slow mode is on in this room", http.StatusTooManyRequests, model.WithRetryAfter(retryAfterSeconds(wait)))
+	default:
+		return model.NewErrorResponse("You are sending messages too fast", http.StatusTooManyRequests, model.WithRetryAfter(retryAfterSeconds(wait)))
+	}
+}
+
+// confirmUserSearchRateLimit confirms that the user has not exceeded the rate of user directory searches
+func confirmUserSearchRateLimit(ctx context.Context, userID string) *model.ErrorResponse {
+	if _, restricted := requestUserID(ctx); !restricted {
+		return nil
+	}
+
+	key := fmt.Sprintf("search:%s", userID)
+	wait, err := ratelimiter.Provider(ctx).Take(key, userSearchRateLimit, userSearchRatePeriod)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+	if wait > 0 {
+		return model.NewErrorResponse("You are searching users too fast", http.StatusTooManyRequests, model.WithRetryAfter(retryAfterSeconds(wait)))
+	}
+
+	return nil
+}
+
+// retryAfterSeconds rounds the wait time up to seconds for Retry-After header
+func retryAfterSeconds(wait time.Duration) int64 {
+	return int64(math.Ceil(wait.Seconds()))
+}
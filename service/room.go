@@ -11,6 +11,7 @@ import (
 	"github.com/swagchat/chat-api/model"
 	"github.com/swagchat/chat-api/notification"
 	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
 	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
 
@@ -31,18 +32,9 @@ func CreateRoom(ctx context.Context, req *model.CreateRoomRequest) (*model.Room,
 	}
 
 	if *req.Type == scpb.RoomType_OneOnOneRoom {
-		roomUser, err := datastore.Provider(ctx).SelectRoomUserOfOneOnOne(*req.UserID, req.UserIDs[0])
-		if err != nil {
-			return nil, model.NewErrorResponse("Failed to create room.", http.StatusBadRequest, model.WithError(err))
-		}
-		if roomUser != nil {
-			invalidParams := []*scpb.InvalidParam{
-				&scpb.InvalidParam{
-					Name:   "userId",
-					Reason: "userId does not exist",
-				},
-			}
-			return nil, model.NewErrorResponse("Failed to create a room.", http.StatusConflict, model.WithInvalidParams(invalidParams))
+		errRes = confirmDirectRoomNotExist(ctx, *req.UserID, req.UserIDs[0])
+		if errRes != nil {
+			return nil, errRes
 		}
 	}
 
@@ -55,22 +47,49 @@ func CreateRoom(ctx context.Context, req *model.CreateRoomRequest) (*model.Room,
 			errRes.Message = "Failed to create room."
 			return nil, errRes
 		}
-	}
-	rus := req.GenerateRoomUsers()
 
-	if req.UserIDs != nil {
-		notificationTopicID, errRes := createTopic(ctx, *req.RoomID)
+		errRes = confirmNotBlockedBy(ctx, *req.UserID, req.UserIDs)
 		if errRes != nil {
+			errRes.Message = "Failed to create room. " + errRes.Message
 			return nil, errRes
 		}
-		r.NotificationTopicID = notificationTopicID
+	}
+	rus := req.GenerateRoomUsers()
+
+	insertOpts := []datastore.InsertRoomOption{
+		datastore.InsertRoomOptionWithRoomUser(rus),
+	}
+	if *req.Type == scpb.RoomType_OneOnOneRoom {
+		insertOpts = append(insertOpts, datastore.InsertRoomOptionWithDirectRoom(model.NewDirectRoom(r.RoomID, r.UserID, req.UserIDs[0])))
 	}
 
-	err := datastore.Provider(ctx).InsertRoom(r, datastore.InsertRoomOptionWithRoomUser(rus))
+	err := datastore.Provider(ctx).InsertRoom(r, insertOpts...)
 	if err != nil {
+		// The 1-on-1 room of the pair may have been created by a concurrent request
+		if *req.Type == scpb.RoomType_OneOnOneRoom {
+			errRes = confirmDirectRoomNotExist(ctx, *req.UserID, req.UserIDs[0])
+			if errRes != nil {
+				return nil, errRes
+			}
+		}
 		return nil, model.NewErrorResponse("Failed to create room.", http.StatusInternalServerError, model.WithError(err))
 	}
 
+	// The topic is created after the room is stored so that a failed creation does not leave a topic behind.
+	// If it fails, the topic is created again when the devices are subscribed
+	if req.UserIDs != nil {
+		notificationTopicID, errRes := createTopic(ctx, r.RoomID)
+		if errRes != nil {
+			logger.Error(errRes.Error.Error())
+		} else if notificationTopicID != "" {
+			r.NotificationTopicID = notificationTopicID
+			err = datastore.Provider(ctx).UpdateRoom(r)
+			if err != nil {
+				logger.Error(err.Error())
+			}
+		}
+	}
+
 	roomUsers, err := datastore.Provider(ctx).SelectRoomUsers(
 		datastore.SelectRoomUsersOptionWithRoomID(r.RoomID),
 	)
@@ -98,16 +117,33 @@ func RetrieveRooms(ctx context.Context, req *model.RetrieveRoomsRequest) (*model
 	span := tracer.StartSpan(ctx, "RetrieveRooms", "service")
 	defer tracer.Finish(span)
 
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	opts := []datastore.SelectRoomsOption{
+		datastore.SelectRoomsOptionWithOrders(req.Orders),
+		datastore.SelectRoomsOptionFilterByMetaData(req.MetaDataFilters),
+	}
+	countOpts := []datastore.SelectRoomsOption{
+		datastore.SelectRoomsOptionFilterByMetaData(req.MetaDataFilters),
+	}
+	if req.Archived != nil {
+		opts = append(opts, datastore.SelectRoomsOptionFilterByArchived(*req.Archived))
+		countOpts = append(countOpts, datastore.SelectRoomsOptionFilterByArchived(*req.Archived))
+	}
+
 	rooms, err := datastore.Provider(ctx).SelectRooms(
 		req.Limit,
 		req.Offset,
-		datastore.SelectRoomsOptionWithOrders(req.Orders),
+		opts...,
 	)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to get rooms.", http.StatusInternalServerError, model.WithError(err))
 	}
 
-	count, err := datastore.Provider(ctx).SelectCountRooms()
+	count, err := datastore.Provider(ctx).SelectCountRooms(countOpts...)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to get rooms.", http.StatusInternalServerError, model.WithError(err))
 	}
@@ -142,7 +178,7 @@ func RetrieveRoom(ctx context.Context, req *model.RetrieveRoomRequest) (*model.R
 			errRes.Message = "Failed to retrieve room."
 			return nil, errRes
 		}
-		roles = user.Roles
+		roles = requestRoleIDs(ctx, user)
 	}
 
 	count, err := datastore.Provider(ctx).SelectCountMessages(
@@ -154,6 +190,11 @@ func RetrieveRoom(ctx context.Context, req *model.RetrieveRoomRequest) (*model.R
 	}
 	room.MessageCount = count
 
+	err = applyMiniUsersProfileVisibility(ctx, room.Users)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve room.", http.StatusInternalServerError, model.WithError(err))
+	}
+
 	return room, nil
 }
 
@@ -168,30 +209,98 @@ func UpdateRoom(ctx context.Context, req *model.UpdateRoomRequest) (*model.Room,
 		return nil, errRes
 	}
 
+	if room.IsArchived() {
+		return nil, archivedRoomErrorResponse("Failed to update room.")
+	}
+
 	errRes = req.Validate(room)
 	if errRes != nil {
 		return nil, errRes
 	}
 
-	room.UpdateRoom(req)
+	for _, permission := range req.RequiredPermissions(room) {
+		errRes = RoomPermissionAuthz(ctx, room, permission)
+		if errRes != nil {
+			errRes.Message = "Failed to update room."
+			return nil, errRes
+		}
+	}
 
-	if len(req.UserIDs) > 0 {
-		errRes := confirmUserIDsExist(ctx, req.UserIDs, "userIds")
+	actorUserID, _ := requestUserID(ctx)
+	changes := req.GenerateRoomChanges(room, actorUserID)
+
+	// userIds only adds members. They are added in the same way as AddRoomUsers,
+	// so that banned users and users who block the request user are not added
+	newUserIDs := room.NonMemberUserIDs(req.UserIDs)
+	if len(newUserIDs) > 0 {
+		if room.JoinPolicy == model.RoomJoinPolicyInviteOnly {
+			return nil, inviteOnlyRoomErrorResponse("Failed to update room.")
+		}
+
+		addReq := &model.AddRoomUsersRequest{}
+		addReq.RoomID = room.RoomID
+		addReq.UserIDs = newUserIDs
+		addReq.Display = true
+		errRes = addRoomUsers(ctx, addReq, room)
 		if errRes != nil {
-			errRes.Message = "Failed to create room."
+			errRes.Message = "Failed to update room."
 			return nil, errRes
 		}
 	}
-	rus := req.GenerateRoomUsers(room)
 
-	err := datastore.Provider(ctx).UpdateRoom(
-		room,
-		datastore.UpdateRoomOptionWithRoomUser(rus),
-	)
+	room.UpdateRoom(req)
+
+	err := datastore.Provider(ctx).UpdateRoom(room)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to update room.", http.StatusInternalServerError, model.WithError(err))
 	}
 
+	for _, change := range changes {
+		go sendRoomChangeMessage(ctx, room.RoomID, change)
+	}
+
+	return room, nil
+}
+
+// ArchiveRoom archives or restores room
+func ArchiveRoom(ctx context.Context, req *model.ArchiveRoomRequest) (*model.Room, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "ArchiveRoom", "service")
+	defer tracer.Finish(span)
+
+	errMsg := "Failed to archive room."
+	if !req.Archived {
+		errMsg = "Failed to unarchive room."
+	}
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = errMsg
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionArchiveRoom)
+	if errRes != nil {
+		errRes.Message = errMsg
+		return nil, errRes
+	}
+
+	if room.IsArchived() == req.Archived {
+		return nil, model.NewErrorResponse(errMsg, http.StatusConflict)
+	}
+
+	if req.Archived {
+		room.Archive()
+	} else {
+		room.Unarchive()
+	}
+
+	err := datastore.Provider(ctx).UpdateRoom(room)
+	if err != nil {
+		return nil, model.NewErrorResponse(errMsg, http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go publishRoomUpdate(ctx, room)
+
 	return room, nil
 }
 
@@ -206,6 +315,12 @@ func DeleteRoom(ctx context.Context, req *model.DeleteRoomRequest) *model.ErrorR
 		return errRes
 	}
 
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionDeleteRoom)
+	if errRes != nil {
+		errRes.Message = "Failed to delete room."
+		return errRes
+	}
+
 	if room.NotificationTopicID != "" {
 		nRes := <-notification.Provider(ctx).DeleteTopic(room.NotificationTopicID)
 		if nRes.Error != nil {
@@ -243,10 +358,16 @@ func RetrieveRoomMessages(ctx context.Context, req *model.RetrieveRoomMessagesRe
 		return nil, errRes
 	}
 
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to get messages."
+		return nil, errRes
+	}
+
 	var roleIDs []int32
 	if req.RoleIDs == nil {
-		if len(user.Roles) > 0 {
-			roleIDs = user.Roles
+		if userRoleIDs := requestRoleIDs(ctx, user); len(userRoleIDs) > 0 {
+			roleIDs = userRoleIDs
 		}
 	} else {
 		if len(req.RoleIDs) > 0 {
@@ -256,6 +377,15 @@ func RetrieveRoomMessages(ctx context.Context, req *model.RetrieveRoomMessagesRe
 
 	req.SetDefaultPagingParamsIfParamsNotSet()
 
+	// Messages hidden by a suspension or a deactivation are still visible to an admin or an app client
+	_, restricted := requestUserID(ctx)
+
+	// In group rooms, messages of the users blocked by the viewer are hidden from the viewer
+	blockerUserID := ""
+	if restricted && room.Type != scpb.RoomType_OneOnOneRoom {
+		blockerUserID = userID
+	}
+
 	messages, err := datastore.Provider(ctx).SelectMessages(
 		req.Limit,
 		req.Offset,
@@ -264,6 +394,8 @@ func RetrieveRoomMessages(ctx context.Context, req *model.RetrieveRoomMessagesRe
 		datastore.SelectMessagesOptionOrders(req.Orders),
 		datastore.SelectMessagesOptionFilterByRoomID(req.RoomID),
 		datastore.SelectMessagesOptionFilterByRoleIDs(roleIDs),
+		datastore.SelectMessagesOptionExcludeHidden(restricted),
+		datastore.SelectMessagesOptionExcludeBlockedBy(blockerUserID),
 	)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to get messages.", http.StatusInternalServerError, model.WithError(err))
@@ -279,6 +411,8 @@ func RetrieveRoomMessages(ctx context.Context, req *model.RetrieveRoomMessagesRe
 	count, err := datastore.Provider(ctx).SelectCountMessages(
 		datastore.SelectMessagesOptionFilterByRoomID(req.RoomID),
 		datastore.SelectMessagesOptionFilterByRoleIDs(req.RoleIDs),
+		datastore.SelectMessagesOptionExcludeHidden(restricted),
+		datastore.SelectMessagesOptionExcludeBlockedBy(blockerUserID),
 	)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to get messages.", http.StatusInternalServerError, model.WithError(err))
@@ -296,3 +430,23 @@ func updateLastAccessRoomID(ctx context.Context, roomID string) {
 	user.LastAccessRoomID = roomID
 	datastore.Provider(ctx).UpdateUser(user)
 }
+
+func inviteOnlyRoomErrorResponse(message string) *model.ErrorResponse {
+	invalidParams := []*scpb.InvalidParam{
+		&scpb.InvalidParam{
+			Name:   "roomId",
+			Reason: "This room is invite-only. Invite users instead of adding them.",
+		},
+	}
+	return model.NewErrorResponse(message, http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+}
+
+func archivedRoomErrorResponse(message string) *model.ErrorResponse {
+	invalidParams := []*scpb.InvalidParam{
+		&scpb.InvalidParam{
+			Name:   "roomId",
+			Reason: "This room is archived. Unarchive it first.",
+		},
+	}
+	return model.NewErrorResponse(message, http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// CreateRoomFolder creates a personal folder of the room list of a user
+func CreateRoomFolder(ctx context.Context, req *model.CreateRoomFolderRequest) (*model.RoomFolder, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateRoomFolder", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	_, errRes = confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create room folder."
+		return nil, errRes
+	}
+
+	roomFolder := req.GenerateRoomFolder()
+	err := datastore.Provider(ctx).InsertRoomFolder(roomFolder)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create room folder.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return roomFolder, nil
+}
+
+// RetrieveRoomFolders retrieves the room folders of a user
+func RetrieveRoomFolders(ctx context.Context, req *model.RetrieveRoomFoldersRequest) (*model.RoomFoldersResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveRoomFolders", "service")
+	defer tracer.Finish(span)
+
+	roomFolders, err := datastore.Provider(ctx).SelectRoomFolders(req.UserID)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve room folders.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.RoomFoldersResponse{}
+	res.RoomFolders = roomFolders
+	return res, nil
+}
+
+// UpdateRoomFolder renames or moves a room folder
+func UpdateRoomFolder(ctx context.Context, req *model.UpdateRoomFolderRequest) (*model.RoomFolder, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "UpdateRoomFolder", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	roomFolder, errRes := confirmRoomFolderExist(ctx, req.UserID, req.FolderID)
+	if errRes != nil {
+		errRes.Message = "Failed to update room folder."
+		return nil, errRes
+	}
+
+	roomFolder.UpdateRoomFolder(req)
+
+	err := datastore.Provider(ctx).UpdateRoomFolder(roomFolder)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to update room folder.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return roomFolder, nil
+}
+
+// DeleteRoomFolder deletes a room folder. The rooms in it are not deleted
+func DeleteRoomFolder(ctx context.Context, req *model.DeleteRoomFolderRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "DeleteRoomFolder", "service")
+	defer tracer.Finish(span)
+
+	roomFolder, errRes := confirmRoomFolderExist(ctx, req.UserID, req.FolderID)
+	if errRes != nil {
+		errRes.Message = "Failed to delete room folder."
+		return errRes
+	}
+
+	err := datastore.Provider(ctx).DeleteRoomFolder(roomFolder)
+	if err != nil {
+		return model.NewErrorResponse("Failed to delete room folder.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return nil
+}
+
+// UpdateRoomUserPreference updates favorite, folder and manual position of a room in the room list of a user
+func UpdateRoomUserPreference(ctx context.Context, req *model.UpdateRoomUserPreferenceRequest) (*model.RoomUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "UpdateRoomUserPreference", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	ru, errRes := confirmRoomUserExist(ctx, req.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to update room preference."
+		return nil, errRes
+	}
+
+	if req.FolderID != nil && *req.FolderID != "" {
+		_, errRes = confirmRoomFolderExist(ctx, req.UserID, *req.FolderID)
+		if errRes != nil && errRes.Status == http.StatusNotFound {
+			invalidParams := []*scpb.InvalidParam{
+				&scpb.InvalidParam{
+					Name:   "folderId",
+					Reason: "folderId is not exist.",
+				},
+			}
+			return nil, model.NewErrorResponse("Failed to update room preference.", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+		}
+		if errRes != nil {
+			errRes.Message = "Failed to update room preference."
+			return nil, errRes
+		}
+	}
+
+	ru.UpdatePreference(req)
+
+	err := datastore.Provider(ctx).UpdateRoomUser(ru)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to update room preference.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return ru, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+// TransferRoomOwnership hands the room over to another member
+func TransferRoomOwnership(ctx context.Context, req *model.TransferRoomOwnershipRequest) (*model.Room, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "TransferRoomOwnership", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to transfer room ownership."
+		return nil, errRes
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionTransferOwnership)
+	if errRes != nil {
+		errRes.Message = "Failed to transfer room ownership."
+		return nil, errRes
+	}
+
+	if req.UserID == room.UserID {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "That user is already the room owner.",
+			},
+		}
+		return nil, model.NewErrorResponse("Failed to transfer room ownership.", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	}
+
+	_, errRes = confirmRoomUserExist(ctx, req.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to transfer room ownership."
+		return nil, errRes
+	}
+
+	errRes = transferRoomOwnership(ctx, room, req.UserID, model.RoomOwnershipTransferReasonTransfer)
+	if errRes != nil {
+		errRes.Message = "Failed to transfer room ownership."
+		return nil, errRes
+	}
+
+	return room, nil
+}
+
+// succeedRoomOwnership passes the room of a leaving or deleted owner to the successor.
+// The room must be selected with its users so that deleted users are not elected
+func succeedRoomOwnership(ctx context.Context, room *model.Room, reason model.RoomOwnershipTransferReason) *model.ErrorResponse {
+	roomUsers, err := datastore.Provider(ctx).SelectRoomUsers(
+		datastore.SelectRoomUsersOptionWithRoomID(room.RoomID),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	activeUserIDs := make(map[string]bool, len(room.Users))
+	for _, user := range room.Users {
+		activeUserIDs[user.UserID] = true
+	}
+
+	candidates := make([]*model.RoomUser, 0, len(roomUsers))
+	for _, ru := range roomUsers {
+		if activeUserIDs[ru.UserID] {
+			candidates = append(candidates, ru)
+		}
+	}
+
+	successor := model.ElectRoomOwnerSuccessor(candidates, room.UserID)
+	if successor == nil {
+		return nil
+	}
+
+	return transferRoomOwnership(ctx, room, successor.UserID, reason)
+}
+
+// succeedRoomOwnershipOfUser passes every room owned by the deleted user to its successor
+func succeedRoomOwnershipOfUser(ctx context.Context, userID string) *model.ErrorResponse {
+	roomUsers, err := datastore.Provider(ctx).SelectRoomUsers(
+		datastore.SelectRoomUsersOptionWithUserIDs([]string{userID}),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	for _, ru := range roomUsers {
+		room, err := datastore.Provider(ctx).SelectRoom(ru.RoomID, datastore.SelectRoomOptionWithUsers(true))
+		if err != nil {
+			return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+		}
+		if room == nil || room.UserID != userID {
+			continue
+		}
+
+		errRes := succeedRoomOwnership(ctx, room, model.RoomOwnershipTransferReasonOwnerDeleted)
+		if errRes != nil {
+			return errRes
+		}
+	}
+
+	return nil
+}
+
+func transferRoomOwnership(ctx context.Context, room *model.Room, userID string, reason model.RoomOwnershipTransferReason) *model.ErrorResponse {
+	previousOwnerUserID := room.UserID
+	room.TransferOwnership(userID)
+
+	err := datastore.Provider(ctx).UpdateRoomOwner(room, previousOwnerUserID)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	payload := &model.PayloadOwnershipTransfer{
+		Reason:              reason,
+		PreviousOwnerUserID: previousOwnerUserID,
+		OwnerUserID:         userID,
+	}
+	go sendSystemMessage(ctx, room.RoomID, userID, payload)
+	go publishRoomUpdate(ctx, room)
+
+	return nil
+}
@@ -6,9 +6,12 @@ import (
 	"time"
 
 	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
 	"github.com/swagchat/chat-api/datastore"
 	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/utils"
 	"github.com/betchi/tracer"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
 )
 
 // AddRoomUsers creates room users
@@ -22,14 +25,47 @@ func AddRoomUsers(ctx context.Context, req *model.AddRoomUsersRequest) *model.Er
 		return errRes
 	}
 
+	if room.IsArchived() {
+		return archivedRoomErrorResponse("Failed to create room users.")
+	}
+
+	if room.JoinPolicy == model.RoomJoinPolicyInviteOnly {
+		return inviteOnlyRoomErrorResponse("Failed to create room users.")
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionAddMembers)
+	if errRes != nil {
+		errRes.Message = "Failed to create room users."
+		return errRes
+	}
+
+	return addRoomUsers(ctx, req, room)
+}
+
+func addRoomUsers(ctx context.Context, req *model.AddRoomUsersRequest, room *model.Room, opts ...datastore.InsertRoomUsersOption) *model.ErrorResponse {
 	req.Room = room
 
-	errRes = confirmUserIDsExist(ctx, req.UserIDs, "userIds")
+	errRes := confirmUserIDsExist(ctx, req.UserIDs, "userIds")
+	if errRes != nil {
+		errRes.Message = "Failed to create room users."
+		return errRes
+	}
+
+	errRes = confirmUsersNotBanned(ctx, room.RoomID, req.UserIDs, "userIds")
 	if errRes != nil {
 		errRes.Message = "Failed to create room users."
 		return errRes
 	}
 
+	// Every path that adds members goes through here, so that the request user can not add users who block them
+	if userID, restricted := requestUserID(ctx); restricted {
+		errRes = confirmNotBlockedBy(ctx, userID, req.UserIDs)
+		if errRes != nil {
+			errRes.Message = "Failed to create room users. " + errRes.Message
+			return errRes
+		}
+	}
+
 	errRes = req.Validate()
 	if errRes != nil {
 		return errRes
@@ -51,7 +87,10 @@ func AddRoomUsers(ctx context.Context, req *model.AddRoomUsersRequest) *model.Er
 	}
 
 	roomUsers := req.GenerateRoomUsers()
-	err := datastore.Provider(ctx).InsertRoomUsers(roomUsers)
+	err := datastore.Provider(ctx).InsertRoomUsers(roomUsers, opts...)
+	if errors.Cause(err) == datastore.ErrInviteLinkExhausted {
+		return model.NewErrorResponse("Failed to create room users. The invite link has reached its maximum number of uses.", http.StatusGone)
+	}
 	if err != nil {
 		return model.NewErrorResponse("Failed to create room users.", http.StatusInternalServerError, model.WithError(err))
 	}
@@ -59,6 +98,9 @@ func AddRoomUsers(ctx context.Context, req *model.AddRoomUsersRequest) *model.Er
 	go subscribeByRoomUsers(ctx, roomUsers)
 	go publishUserJoin(ctx, req.RoomID)
 
+	actorUserID, _ := requestUserID(ctx)
+	go sendRoomChangeMessage(ctx, req.RoomID, model.NewMembershipChange(true, actorUserID, req.UserIDs))
+
 	return nil
 }
 
@@ -144,6 +186,64 @@ func UpdateRoomUser(ctx context.Context, req *model.UpdateRoomUserRequest) *mode
 	return nil
 }
 
+// UpdateRoomUserRole updates the member role of a room user
+func UpdateRoomUserRole(ctx context.Context, req *model.UpdateRoomUserRoleRequest) (*model.RoomUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "UpdateRoomUserRole", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	room, errRes := confirmRoomExist(ctx, req.RoomID)
+	if errRes != nil {
+		errRes.Message = "Failed to update room user role."
+		return nil, errRes
+	}
+
+	ru, errRes := confirmRoomUserExist(ctx, req.RoomID, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to update room user role."
+		return nil, errRes
+	}
+
+	if ru.UserID == room.UserID {
+		invalidParams := []*scpb.InvalidParam{
+			&scpb.InvalidParam{
+				Name:   "userId",
+				Reason: "The role of the room owner can not be changed.",
+			},
+		}
+		return nil, model.NewErrorResponse("Failed to update room user role.", http.StatusBadRequest, model.WithInvalidParams(invalidParams))
+	}
+
+	errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionChangeMemberRoles)
+	if errRes != nil {
+		errRes.Message = "Failed to update room user role."
+		return nil, errRes
+	}
+
+	if userID, restricted := requestUserID(ctx); restricted {
+		role, errRes := roomMemberRole(ctx, room, userID)
+		if errRes != nil {
+			errRes.Message = "Failed to update room user role."
+			return nil, errRes
+		}
+		if ru.MemberRole >= role || *req.MemberRole >= role {
+			return nil, model.NewErrorResponse("Failed to update room user role. You do not have permission", http.StatusUnauthorized)
+		}
+	}
+
+	ru.MemberRole = *req.MemberRole
+	err := datastore.Provider(ctx).UpdateRoomUser(ru)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to update room user role.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return ru, nil
+}
+
 // DeleteRoomUsers deletes room users
 func DeleteRoomUsers(ctx context.Context, req *model.DeleteRoomUsersRequest) *model.ErrorResponse {
 	span := tracer.StartSpan(ctx, "DeleteRoomUsers", "service")
@@ -157,6 +257,20 @@ func DeleteRoomUsers(ctx context.Context, req *model.DeleteRoomUsersRequest) *mo
 
 	req.Room = room
 
+	if userID, restricted := requestUserID(ctx); restricted && !isOnlyUserID(req.UserIDs, userID) {
+		errRes = RoomPermissionAuthz(ctx, room, model.RoomPermissionRemoveMembers)
+		if errRes != nil {
+			errRes.Message = "Failed to delete room users."
+			return errRes
+		}
+
+		errRes = roomMemberOutrankAuthz(ctx, room, req.UserIDs)
+		if errRes != nil {
+			errRes.Message = "Failed to delete room users."
+			return errRes
+		}
+	}
+
 	err := datastore.Provider(ctx).DeleteRoomUsers(
 		datastore.DeleteRoomUsersOptionFilterByRoomIDs([]string{req.RoomID}),
 		datastore.DeleteRoomUsersOptionFilterByUserIDs(req.UserIDs),
@@ -165,6 +279,17 @@ func DeleteRoomUsers(ctx context.Context, req *model.DeleteRoomUsersRequest) *mo
 		return model.NewErrorResponse("Failed to delete room users.", http.StatusInternalServerError, model.WithError(err))
 	}
 
+	actorUserID, _ := requestUserID(ctx)
+	go sendRoomChangeMessage(ctx, req.RoomID, model.NewMembershipChange(false, actorUserID, req.UserIDs))
+
+	if utils.SearchStringValueInSlice(req.UserIDs, room.UserID) {
+		errRes = succeedRoomOwnership(ctx, room, model.RoomOwnershipTransferReasonOwnerLeft)
+		if errRes != nil {
+			errRes.Message = "Failed to delete room users."
+			return errRes
+		}
+	}
+
 	go func() {
 		rus, err := datastore.Provider(ctx).SelectRoomUsers(
 			datastore.SelectRoomUsersOptionWithRoomID(req.RoomID),
@@ -179,3 +304,17 @@ func DeleteRoomUsers(ctx context.Context, req *model.DeleteRoomUsersRequest) *mo
 
 	return nil
 }
+
+func isOnlyUserID(userIDs []string, userID string) bool {
+	if len(userIDs) == 0 {
+		return false
+	}
+
+	for _, id := range userIDs {
+		if id != userID {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	scimDeactivatedReason = "Deactivated by the identity provider."
+	scimReactivatedReason = "Reactivated by the identity provider."
+)
+
+// CreateScimToken issues a SCIM token of the requesting app client
+func CreateScimToken(ctx context.Context) (*model.ScimToken, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateScimToken", "service")
+	defer tracer.Finish(span)
+
+	clientID, _ := ctx.Value(config.CtxClientID).(string)
+	scimToken := model.NewScimToken(clientID)
+
+	err := datastore.Provider(ctx).InsertScimToken(scimToken)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create scim token.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return scimToken, nil
+}
+
+// DeleteScimToken revokes a SCIM token of the requesting app client
+func DeleteScimToken(ctx context.Context, req *model.DeleteScimTokenRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "DeleteScimToken", "service")
+	defer tracer.Finish(span)
+
+	if clientID, ok := ctx.Value(config.CtxClientID).(string); ok {
+		req.ClientID = clientID
+	}
+
+	scimToken, errRes := confirmScimTokenExist(ctx, req.ClientID, req.TokenID)
+	if errRes != nil {
+		errRes.Message = "Failed to delete scim token."
+		return errRes
+	}
+
+	scimToken.Deleted = time.Now().Unix()
+	err := datastore.Provider(ctx).UpdateScimToken(scimToken)
+	if err != nil {
+		return model.NewErrorResponse("Failed to delete scim token.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return nil
+}
+
+// CreateScimUser provisions a user
+func CreateScimUser(ctx context.Context, req *model.ScimUser) (*model.ScimUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateScimUser", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	errRes = confirmScimUserNameNotExist(ctx, req.UserName, "")
+	if errRes != nil {
+		errRes.Message = "Failed to provision user."
+		return nil, errRes
+	}
+
+	user, errRes := CreateUser(ctx, req.GenerateCreateUserRequest())
+	if errRes != nil {
+		errRes.Message = "Failed to provision user."
+		return nil, errRes
+	}
+
+	if req.Status() == model.UserStatusDeactivated {
+		errRes = updateScimUserStatus(ctx, user.UserID, model.UserStatusDeactivated, scimDeactivatedReason)
+		if errRes != nil {
+			return nil, errRes
+		}
+	}
+
+	return retrieveScimUser(ctx, user.UserID)
+}
+
+// RetrieveScimUsers retrieves the provisioned users
+func RetrieveScimUsers(ctx context.Context, req *model.RetrieveScimResourcesRequest) (*model.ScimListResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveScimUsers", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	if req.Filter != nil && req.Filter.IsID() {
+		resources := make([]*model.ScimUser, 0, 1)
+		user, errRes := confirmScimUserExist(ctx, req.Filter.Value)
+		if errRes == nil {
+			resources = append(resources, model.NewScimUser(user))
+		} else if errRes.Status != http.StatusNotFound {
+			errRes.Message = "Failed to retrieve users."
+			return nil, errRes
+		}
+		return model.NewScimListResponse(resources, len(resources), int64(len(resources)), req.StartIndex), nil
+	}
+
+	metaDataFilters, errRes := req.UserMetaDataFilters()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	users, err := datastore.Provider(ctx).SelectUsers(
+		req.Limit(),
+		req.Offset(),
+		datastore.SelectUsersOptionFilterByMetaData(metaDataFilters),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve users.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	count, err := datastore.Provider(ctx).SelectCountUsers(
+		datastore.SelectUsersOptionFilterByMetaData(metaDataFilters),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve users.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	resources := make([]*model.ScimUser, len(users))
+	for i, user := range users {
+		resources[i] = model.NewScimUser(user)
+	}
+
+	return model.NewScimListResponse(resources, len(resources), count, req.StartIndex), nil
+}
+
+// RetrieveScimUser retrieves a provisioned user
+func RetrieveScimUser(ctx context.Context, req *model.RetrieveScimResourceRequest) (*model.ScimUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveScimUser", "service")
+	defer tracer.Finish(span)
+
+	return retrieveScimUser(ctx, req.ID)
+}
+
+// ReplaceScimUser replaces the attributes of a provisioned user
+func ReplaceScimUser(ctx context.Context, req *model.ScimUser) (*model.ScimUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "ReplaceScimUser", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	user, errRes := confirmScimUserExist(ctx, req.ID)
+	if errRes != nil {
+		errRes.Message = "Failed to replace user."
+		return nil, errRes
+	}
+
+	return replaceScimUser(ctx, user, req)
+}
+
+// PatchScimUser partially updates a provisioned user
+func PatchScimUser(ctx context.Context, req *model.ScimPatchRequest) (*model.ScimUser, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "PatchScimUser", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	user, errRes := confirmScimUserExist(ctx, req.ID)
+	if errRes != nil {
+		errRes.Message = "Failed to patch user."
+		return nil, errRes
+	}
+
+	scimUser := model.NewScimUser(user)
+	errRes = scimUser.ApplyPatch(req)
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	errRes = scimUser.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	return replaceScimUser(ctx, user, scimUser)
+}
+
+// DeleteScimUser deprovisions a user
+func DeleteScimUser(ctx context.Context, req *model.DeleteScimResourceRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "DeleteScimUser", "service")
+	defer tracer.Finish(span)
+
+	_, errRes := confirmScimUserExist(ctx, req.ID)
+	if errRes != nil {
+		errRes.Message = "Failed to delete user."
+		return errRes
+	}
+
+	dur := &model.DeleteUserRequest{}
+	dur.UserID = req.ID
+	return DeleteUser(ctx, dur)
+}
+
+func retrieveScimUser(ctx context.Context, userID string) (*model.ScimUser, *model.ErrorResponse) {
+	user, errRes := confirmScimUserExist(ctx, userID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve user."
+		return nil, errRes
+	}
+
+	return model.NewScimUser(user), nil
+}
+
+func replaceScimUser(ctx context.Context, user *model.User, scimUser *model.ScimUser) (*model.ScimUser, *model.ErrorResponse) {
+	errRes := confirmScimUserNameNotExist(ctx, scimUser.UserName, user.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to replace user."
+		return nil, errRes
+	}
+
+	previousStatus := user.EffectiveStatus()
+
+	_, errRes = UpdateUser(ctx, scimUser.GenerateUpdateUserRequest(user))
+	if errRes != nil {
+		errRes.Message = "Failed to replace user."
+		return nil, errRes
+	}
+
+	// Suspensions are kept because they are not managed by the identity provider
+	if scimUser.Status() == model.UserStatusDeactivated && previousStatus != model.UserStatusDeactivated {
+		errRes = updateScimUserStatus(ctx, user.UserID, model.UserStatusDeactivated, scimDeactivatedReason)
+	} else if scimUser.Status() == model.UserStatusActive && previousStatus == model.UserStatusDeactivated {
+		errRes = updateScimUserStatus(ctx, user.UserID, model.UserStatusActive, scimReactivatedReason)
+	}
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	return retrieveScimUser(ctx, user.UserID)
+}
+
+func updateScimUserStatus(ctx context.Context, userID string, status model.UserStatus, reason string) *model.ErrorResponse {
+	req := &model.UpdateUserStatusRequest{
+		UserID: userID,
+		Status: status,
+		Reason: reason,
+	}
+	_, errRes := UpdateUserStatus(ctx, req)
+	return errRes
+}
+
+// confirmScimUserNameNotExist confirms that no other user is provisioned with the userName
+func confirmScimUserNameNotExist(ctx context.Context, userName, userID string) *model.ErrorResponse {
+	req := &model.RetrieveScimResourcesRequest{}
+	req.Filter = &model.ScimFilter{
+		Attribute: "userName",
+		Value:     userName,
+	}
+	metaDataFilters, errRes := req.UserMetaDataFilters()
+	if errRes != nil {
+		return errRes
+	}
+
+	users, err := datastore.Provider(ctx).SelectUsers(
+		2,
+		0,
+		datastore.SelectUsersOptionFilterByMetaData(metaDataFilters),
+	)
+	if err != nil {
+		return model.NewErrorResponse("", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	for _, user := range users {
+		if user.UserID != userID {
+			return model.NewErrorResponse("", http.StatusConflict)
+		}
+	}
+
+	return nil
+}
+
+// CreateScimGroup provisions a group as a room without an owner
+func CreateScimGroup(ctx context.Context, req *model.ScimGroup) (*model.ScimGroup, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateScimGroup", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	userIDs := req.MemberUserIDs()
+	if len(userIDs) > 0 {
+		errRes = confirmUserIDsExist(ctx, userIDs, "members")
+		if errRes != nil {
+			errRes.Message = "Failed to provision group."
+			return nil, errRes
+		}
+	}
+
+	room := req.GenerateRoom()
+	err := datastore.Provider(ctx).InsertRoom(room)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to provision group.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go webhookRoom(ctx, room)
+
+	if len(userIDs) > 0 {
+		errRes = addScimGroupMembers(ctx, room, userIDs)
+		if errRes != nil {
+			errRes.Message = "Failed to provision group."
+			return nil, errRes
+		}
+	}
+
+	return retrieveScimGroup(ctx, room.RoomID)
+}
+
+// RetrieveScimGroups retrieves the provisioned groups
+func RetrieveScimGroups(ctx context.Context, req *model.RetrieveScimResourcesRequest) (*model.ScimListResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveScimGroups", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	if req.Filter != nil && req.Filter.IsID() {
+		resources := make([]*model.ScimGroup, 0, 1)
+		room, errRes := confirmScimGroupExist(ctx, req.Filter.Value)
+		if errRes == nil {
+			resources = append(resources, model.NewScimGroup(room))
+		} else if errRes.Status != http.StatusNotFound {
+			errRes.Message = "Failed to retrieve groups."
+			return nil, errRes
+		}
+		return model.NewScimListResponse(resources, len(resources), int64(len(resources)), req.StartIndex), nil
+	}
+
+	metaDataFilters, errRes := req.GroupMetaDataFilters()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	rooms, err := datastore.Provider(ctx).SelectRooms(
+		req.Limit(),
+		req.Offset(),
+		datastore.SelectRoomsOptionFilterByMetaData(metaDataFilters),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve groups.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	count, err := datastore.Provider(ctx).SelectCountRooms(
+		datastore.SelectRoomsOptionFilterByMetaData(metaDataFilters),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve groups.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	resources := make([]*model.ScimGroup, len(rooms))
+	for i, room := range rooms {
+		roomWithUsers, errRes := confirmScimGroupExist(ctx, room.RoomID)
+		if errRes != nil {
+			errRes.Message = "Failed to retrieve groups."
+			return nil, errRes
+		}
+		resources[i] = model.NewScimGroup(roomWithUsers)
+	}
+
+	return model.NewScimListResponse(resources, len(resources), count, req.StartIndex), nil
+}
+
+// RetrieveScimGroup retrieves a provisioned group
+func RetrieveScimGroup(ctx context.Context, req *model.RetrieveScimResourceRequest) (*model.ScimGroup, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveScimGroup", "service")
+	defer tracer.Finish(span)
+
+	return retrieveScimGroup(ctx, req.ID)
+}
+
+// ReplaceScimGroup replaces the attributes and the members of a provisioned group
+func ReplaceScimGroup(ctx context.Context, req *model.ScimGroup) (*model.ScimGroup, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "ReplaceScimGroup", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	room, errRes := confirmScimGroupExist(ctx, req.ID)
+	if errRes != nil {
+		errRes.Message = "Failed to replace group."
+		return nil, errRes
+	}
+
+	return replaceScimGroup(ctx, room, req)
+}
+
+// PatchScimGroup partially updates a provisioned group
+func PatchScimGroup(ctx context.Context, req *model.ScimPatchRequest) (*model.ScimGroup, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "PatchScimGroup", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	room, errRes := confirmScimGroupExist(ctx, req.ID)
+	if errRes != nil {
+		errRes.Message = "Failed to patch group."
+		return nil, errRes
+	}
+
+	scimGroup := model.NewScimGroup(room)
+	errRes = scimGroup.ApplyPatch(req)
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	errRes = scimGroup.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	return replaceScimGroup(ctx, room, scimGroup)
+}
+
+// DeleteScimGroup deprovisions a group
+func DeleteScimGroup(ctx context.Context, req *model.DeleteScimResourceRequest) *model.ErrorResponse {
+	span := tracer.StartSpan(ctx, "DeleteScimGroup", "service")
+	defer tracer.Finish(span)
+
+	_, errRes := confirmScimGroupExist(ctx, req.ID)
+	if errRes != nil {
+		errRes.Message = "Failed to delete group."
+		return errRes
+	}
+
+	drr := &model.DeleteRoomRequest{}
+	drr.RoomID = req.ID
+	return DeleteRoom(ctx, drr)
+}
+
+func retrieveScimGroup(ctx context.Context, roomID string) (*model.ScimGroup, *model.ErrorResponse) {
+	room, errRes := confirmScimGroupExist(ctx, roomID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve group."
+		return nil, errRes
+	}
+
+	return model.NewScimGroup(room), nil
+}
+
+func replaceScimGroup(ctx context.Context, room *model.Room, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.ErrorResponse) {
+	userIDs := scimGroup.MemberUserIDs()
+	if len(userIDs) > 0 {
+		errRes := confirmUserIDsExist(ctx, userIDs, "members")
+		if errRes != nil {
+			errRes.Message = "Failed to replace group."
+			return nil, errRes
+		}
+	}
+
+	scimGroup.UpdateRoom(room)
+	err := datastore.Provider(ctx).UpdateRoom(room)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to replace group.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	members := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		members[userID] = true
+	}
+
+	currentMembers := make(map[string]bool, len(room.Users))
+	removedUserIDs := make([]string, 0)
+	for _, user := range room.Users {
+		currentMembers[user.UserID] = true
+		if !members[user.UserID] {
+			removedUserIDs = append(removedUserIDs, user.UserID)
+		}
+	}
+
+	addedUserIDs := make([]string, 0)
+	for _, userID := range userIDs {
+		if !currentMembers[userID] {
+			addedUserIDs = append(addedUserIDs, userID)
+		}
+	}
+
+	if len(addedUserIDs) > 0 {
+		errRes := addScimGroupMembers(ctx, room, addedUserIDs)
+		if errRes != nil {
+			errRes.Message = "Failed to replace group."
+			return nil, errRes
+		}
+	}
+
+	if len(removedUserIDs) > 0 {
+		drur := &model.DeleteRoomUsersRequest{}
+		drur.RoomID = room.RoomID
+		drur.UserIDs = removedUserIDs
+		errRes := DeleteRoomUsers(ctx, drur)
+		if errRes != nil {
+			errRes.Message = "Failed to replace group."
+			return nil, errRes
+		}
+	}
+
+	return retrieveScimGroup(ctx, room.RoomID)
+}
+
+func addScimGroupMembers(ctx context.Context, room *model.Room, userIDs []string) *model.ErrorResponse {
+	arur := &model.AddRoomUsersRequest{}
+	arur.RoomID = room.RoomID
+	arur.UserIDs = userIDs
+	arur.Display = true
+	return addRoomUsers(ctx, arur, room)
+}
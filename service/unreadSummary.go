@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+)
+
+// RetrieveUnreadSummary retrieves the unread counts of the user across the rooms
+func RetrieveUnreadSummary(ctx context.Context, req *model.RetrieveUnreadSummaryRequest) (*model.UnreadSummary, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveUnreadSummary", "service")
+	defer tracer.Finish(span)
+
+	_, errRes := confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to get unread summary."
+		return nil, errRes
+	}
+
+	unreadSummary, err := selectUnreadSummary(ctx, req.UserID)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to get unread summary.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return unreadSummary, nil
+}
+
+func selectUnreadSummary(ctx context.Context, userID string) (*model.UnreadSummary, error) {
+	roomUnreadCounts, err := datastore.Provider(ctx).SelectRoomUnreadCounts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewUnreadSummary(userID, roomUnreadCounts), nil
+}
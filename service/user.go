@@ -55,16 +55,29 @@ func RetrieveUsers(ctx context.Context, req *model.RetrieveUsersRequest) (*model
 	span := tracer.StartSpan(ctx, "RetrieveUsers", "service")
 	defer tracer.Finish(span)
 
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
 	users, err := datastore.Provider(ctx).SelectUsers(
 		req.Limit,
 		req.Offset,
 		datastore.SelectUsersOptionWithOrders(req.Orders),
+		datastore.SelectUsersOptionFilterByMetaData(req.MetaDataFilters),
 	)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to retrieve users.", http.StatusInternalServerError, model.WithError(err))
 	}
 
-	count, err := datastore.Provider(ctx).SelectCountUsers()
+	count, err := datastore.Provider(ctx).SelectCountUsers(
+		datastore.SelectUsersOptionFilterByMetaData(req.MetaDataFilters),
+	)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve users.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	err = applyUsersProfileVisibility(ctx, users)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to retrieve users.", http.StatusInternalServerError, model.WithError(err))
 	}
@@ -97,6 +110,11 @@ func RetrieveUser(ctx context.Context, req *model.RetrieveUserRequest) (*model.U
 		return nil, model.NewErrorResponse("", http.StatusNotFound)
 	}
 
+	err = applyUsersProfileVisibility(ctx, []*model.User{user})
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve user.", http.StatusInternalServerError, model.WithError(err))
+	}
+
 	// unreadCountRooms := make([]*model.RoomForUser, 0)
 	// notUnreadCountRooms := make([]*model.RoomForUser, 0)
 	// for _, roomForUser := range user.Rooms {
@@ -181,6 +199,12 @@ func DeleteUser(ctx context.Context, req *model.DeleteUserRequest) *model.ErrorR
 		return model.NewErrorResponse("Failed to delete user.", http.StatusInternalServerError, model.WithError(err))
 	}
 
+	errRes = succeedRoomOwnershipOfUser(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to delete user."
+		return errRes
+	}
+
 	go unsubscribeByUserID(ctx, req.UserID)
 
 	return nil
@@ -205,20 +229,41 @@ func RetrieveUserRooms(ctx context.Context, req *model.RetrieveUserRoomsRequest)
 	span := tracer.StartSpan(ctx, "RetrieveUserRooms", "service")
 	defer tracer.Finish(span)
 
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
 	miniRooms, err := datastore.Provider(ctx).SelectMiniRooms(
 		req.Limit,
 		req.Offset,
 		req.UserID,
 		datastore.SelectMiniRoomsOptionWithOrders(req.Orders),
 		datastore.SelectMiniRoomsOptionFilter(req.Filter),
+		datastore.SelectMiniRoomsOptionFilterByArchived(req.Archived),
+		datastore.SelectMiniRoomsOptionFilterByFavorite(req.Favorite),
+		datastore.SelectMiniRoomsOptionFilterByFolderID(req.FolderID),
+		datastore.SelectMiniRoomsOptionWithSort(req.Sort),
+		datastore.SelectMiniRoomsOptionFilterByMetaData(req.MetaDataFilters),
 	)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to retrieve user rooms.", http.StatusInternalServerError, model.WithError(err))
 	}
 
+	for _, miniRoom := range miniRooms {
+		err = applyMiniUsersProfileVisibility(ctx, miniRoom.Users)
+		if err != nil {
+			return nil, model.NewErrorResponse("Failed to retrieve user rooms.", http.StatusInternalServerError, model.WithError(err))
+		}
+	}
+
 	allCount, err := datastore.Provider(ctx).SelectCountMiniRooms(
 		req.UserID,
 		datastore.SelectMiniRoomsOptionFilter(req.Filter),
+		datastore.SelectMiniRoomsOptionFilterByArchived(req.Archived),
+		datastore.SelectMiniRoomsOptionFilterByFavorite(req.Favorite),
+		datastore.SelectMiniRoomsOptionFilterByFolderID(req.FolderID),
+		datastore.SelectMiniRoomsOptionFilterByMetaData(req.MetaDataFilters),
 	)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to retrieve user rooms.", http.StatusInternalServerError, model.WithError(err))
@@ -245,11 +290,17 @@ func RetrieveContacts(ctx context.Context, req *model.RetrieveContactsRequest) (
 		req.Limit,
 		req.Offset,
 		datastore.SelectContactsOptionWithOrders(req.Orders),
+		datastore.SelectContactsOptionWithMode(contactMode(ctx)),
 	)
 	if err != nil {
 		return nil, model.NewErrorResponse("Failed to get contacts.", http.StatusInternalServerError, model.WithError(err))
 	}
 
+	err = applyUsersProfileVisibility(ctx, contacts)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to get contacts.", http.StatusInternalServerError, model.WithError(err))
+	}
+
 	res := &model.UsersResponse{}
 	res.Users = contacts
 	res.AllCount = int64(0)
@@ -260,6 +311,53 @@ func RetrieveContacts(ctx context.Context, req *model.RetrieveContactsRequest) (
 	return res, nil
 }
 
+// SearchUsers searches the user directory on behalf of the user
+func SearchUsers(ctx context.Context, req *model.SearchUsersRequest) (*model.UsersResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "SearchUsers", "service")
+	defer tracer.Finish(span)
+
+	req.SetDefaultParams()
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	errRes = confirmUserSearchRateLimit(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to search users. " + errRes.Message
+		return nil, errRes
+	}
+
+	opts := []datastore.SelectUsersOption{
+		datastore.SelectUsersOptionFilterByName(req.Query, req.Match),
+		datastore.SelectUsersOptionFilterByMetaData(req.MetaDataFilters),
+		datastore.SelectUsersOptionVisibleTo(req.UserID),
+	}
+
+	users, err := datastore.Provider(ctx).SelectUsers(req.Limit, req.Offset, opts...)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to search users.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	count, err := datastore.Provider(ctx).SelectCountUsers(opts...)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to search users.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	err = applyUsersProfileVisibility(ctx, users)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to search users.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.UsersResponse{}
+	res.Users = users
+	res.AllCount = count
+	res.Limit = req.Limit
+	res.Offset = req.Offset
+
+	return res, nil
+}
+
 // RetrieveProfile retrieves a profile
 func RetrieveProfile(ctx context.Context, req *model.RetrieveProfileRequest) (*model.User, *model.ErrorResponse) {
 	span := tracer.StartSpan(ctx, "RetrieveProfile", "service")
@@ -273,6 +371,11 @@ func RetrieveProfile(ctx context.Context, req *model.RetrieveProfileRequest) (*m
 
 	user.DoPostProcessing()
 
+	err := applyUsersProfileVisibility(ctx, []*model.User{user})
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve profile.", http.StatusInternalServerError, model.WithError(err))
+	}
+
 	return user, nil
 }
 
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/pkg/errors"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+	"github.com/swagchat/chat-api/notification"
+	"github.com/swagchat/chat-api/producer"
+	"github.com/swagchat/chat-api/storage"
+	scpb "github.com/swagchat/protobuf/protoc-gen-go"
+)
+
+const userExportMessagesLimit = 1000
+
+// CreateUserExport creates a user export. The archive is built asynchronously
+func CreateUserExport(ctx context.Context, req *model.CreateUserExportRequest) (*model.UserExport, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "CreateUserExport", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	_, errRes = confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to create user export."
+		return nil, errRes
+	}
+
+	userExport := req.GenerateUserExport()
+	err := datastore.Provider(ctx).InsertUserExport(userExport)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to create user export.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	go buildUserExport(ctx, userExport)
+
+	return userExport, nil
+}
+
+// RetrieveUserExport retrieves a user export
+func RetrieveUserExport(ctx context.Context, req *model.RetrieveUserExportRequest) (*model.UserExport, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveUserExport", "service")
+	defer tracer.Finish(span)
+
+	userExport, errRes := confirmUserExportExist(ctx, req.UserID, req.ExportID)
+	if errRes != nil {
+		errRes.Message = "Failed to retrieve user export."
+		return nil, errRes
+	}
+
+	return userExport, nil
+}
+
+// DownloadUserExport downloads the archive of a user export
+func DownloadUserExport(ctx context.Context, req *model.RetrieveUserExportRequest) ([]byte, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "DownloadUserExport", "service")
+	defer tracer.Finish(span)
+
+	userExport, errRes := confirmUserExportExist(ctx, req.UserID, req.ExportID)
+	if errRes != nil {
+		errRes.Message = "Failed to download user export."
+		return nil, errRes
+	}
+
+	if userExport.Status != model.UserExportStatusCompleted {
+		return nil, model.NewErrorResponse("Failed to download user export. The export is not ready yet.", http.StatusConflict)
+	}
+
+	if userExport.IsExpired() {
+		return nil, model.NewErrorResponse("Failed to download user export. The download link has expired.", http.StatusGone)
+	}
+
+	assetInfo := &storage.AssetInfo{
+		Filename: userExport.Filename,
+	}
+	bytes, err := storage.Provider(ctx).Get(assetInfo)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to download user export.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	return bytes, nil
+}
+
+func buildUserExport(ctx context.Context, userExport *model.UserExport) {
+	span := tracer.StartSpan(ctx, "buildUserExport", "service")
+	defer tracer.Finish(span)
+
+	archive, err := collectUserExportArchive(ctx, userExport.UserID)
+	if err == nil {
+		buffer := new(bytes.Buffer)
+		err = json.NewEncoder(buffer).Encode(archive)
+		if err == nil {
+			assetInfo := &storage.AssetInfo{
+				Filename: model.UserExportFilename(userExport.ExportID),
+				Data:     buffer,
+			}
+			var filename string
+			filename, err = storage.Provider(ctx).Post(assetInfo)
+			if err == nil {
+				userExport.Complete(filename)
+			}
+		}
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		tracer.SetError(span, err)
+		userExport.Fail()
+	}
+
+	err = datastore.Provider(ctx).UpdateUserExport(userExport)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	publishUserExport(ctx, userExport)
+	pushUserExport(ctx, userExport)
+}
+
+func collectUserExportArchive(ctx context.Context, userID string) (*model.UserExportArchive, error) {
+	dp := datastore.Provider(ctx)
+
+	user, err := dp.SelectUser(
+		userID,
+		datastore.SelectUserOptionWithBlocks(true),
+		datastore.SelectUserOptionWithDevices(true),
+		datastore.SelectUserOptionWithRoles(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// The user may be deleted after the export is requested
+	if user == nil {
+		return nil, errors.Errorf("An error occurred while collecting user export. User of userId[%s] is not found", userID)
+	}
+	user.DoPostProcessing()
+
+	roomUsers, err := dp.SelectRoomUsers(
+		datastore.SelectRoomUsersOptionWithUserIDs([]string{userID}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*model.Message, 0)
+	for offset := int32(0); ; offset += userExportMessagesLimit {
+		ms, err := dp.SelectMessages(
+			userExportMessagesLimit,
+			offset,
+			datastore.SelectMessagesOptionFilterByUserID(userID),
+		)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, ms...)
+		if len(ms) < userExportMessagesLimit {
+			break
+		}
+	}
+
+	assets, err := dp.SelectAssets(datastore.SelectAssetsOptionFilterByUserID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	l, _ := time.LoadLocation("Etc/GMT")
+	archive := &model.UserExportArchive{
+		Exported:  time.Now().In(l).Format(time.RFC3339),
+		User:      user,
+		RoomUsers: roomUsers,
+		Messages:  messages,
+		Assets:    assets,
+	}
+
+	return archive, nil
+}
+
+func publishUserExport(ctx context.Context, userExport *model.UserExport) {
+	buffer := new(bytes.Buffer)
+	json.NewEncoder(buffer).Encode(userExport)
+	event := &scpb.EventData{
+		Type:    scpb.EventType_MessageEvent,
+		Data:    buffer.Bytes(),
+		UserIDs: []string{userExport.UserID},
+	}
+	err := producer.Provider(ctx).PublishMessage(event)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+func pushUserExport(ctx context.Context, userExport *model.UserExport) {
+	mi := &notification.MessageInfo{
+		Text: userExport.NotificationText(),
+	}
+	pushToUser(ctx, userExport.UserID, "", mi)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+)
+
+const (
+	TestServiceBuildUserExportOfDeletedUser = "[service] build user export of deleted user test"
+)
+
+func TestUserExport(t *testing.T) {
+	t.Run(TestServiceBuildUserExportOfDeletedUser, func(t *testing.T) {
+		nowTimestamp := time.Now().Unix()
+		newUser := &model.User{}
+		newUser.UserID = "user-export-service-user-id-0001"
+		newUser.MetaData = []byte(`{"key":"value"}`)
+		newUser.LastAccessedTimestamp = nowTimestamp
+		newUser.CreatedTimestamp = nowTimestamp
+		newUser.ModifiedTimestamp = nowTimestamp
+		err := datastore.Provider(ctx).InsertUser(newUser)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestServiceBuildUserExportOfDeletedUser, err.Error())
+		}
+
+		req := &model.CreateUserExportRequest{}
+		req.UserID = "user-export-service-user-id-0001"
+		userExport := req.GenerateUserExport()
+		err = datastore.Provider(ctx).InsertUserExport(userExport)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestServiceBuildUserExportOfDeletedUser, err.Error())
+		}
+
+		// The user is deleted between the request and the build
+		deleteUser := &model.User{}
+		deleteUser.UserID = "user-export-service-user-id-0001"
+		deleteUser.DeletedTimestamp = 1
+		err = datastore.Provider(ctx).UpdateUser(deleteUser)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestServiceBuildUserExportOfDeletedUser, err.Error())
+		}
+
+		buildUserExport(ctx, userExport)
+
+		builtUserExport, err := datastore.Provider(ctx).SelectUserExport(userExport.ExportID)
+		if err != nil {
+			t.Fatalf("Failed to %s. Expected err to be nil, but it was not nil [%s]", TestServiceBuildUserExportOfDeletedUser, err.Error())
+		}
+		if builtUserExport == nil {
+			t.Fatalf("Failed to %s. Expected userExport to be not nil, but it was nil", TestServiceBuildUserExportOfDeletedUser)
+		}
+		if builtUserExport.Status != model.UserExportStatusFailed {
+			t.Fatalf("Failed to %s. Expected status to be %d, but it was %d", TestServiceBuildUserExportOfDeletedUser, model.UserExportStatusFailed, builtUserExport.Status)
+		}
+	})
+}
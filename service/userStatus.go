@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/betchi/tracer"
+	logger "github.com/betchi/zapper"
+	"github.com/swagchat/chat-api/config"
+	"github.com/swagchat/chat-api/datastore"
+	"github.com/swagchat/chat-api/model"
+)
+
+// UpdateUserStatus suspends, deactivates or reactivates a user.
+// Every change is recorded in the audit trail and sent to the webhooks
+func UpdateUserStatus(ctx context.Context, req *model.UpdateUserStatusRequest) (*model.User, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "UpdateUserStatus", "service")
+	defer tracer.Finish(span)
+
+	errRes := req.Validate()
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	user, errRes := confirmUserExist(ctx, req.UserID)
+	if errRes != nil {
+		errRes.Message = "Failed to update user status."
+		return nil, errRes
+	}
+
+	if clientID, ok := ctx.Value(config.CtxClientID).(string); ok {
+		req.ClientID = clientID
+	}
+
+	previousStatus := user.EffectiveStatus()
+	user.UpdateStatus(req)
+	userStatusLog := req.GenerateUserStatusLog(user, previousStatus)
+
+	err := datastore.Provider(ctx).UpdateUserStatus(user, userStatusLog)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to update user status.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	if user.Status != model.UserStatusActive {
+		go unsubscribeByUserID(ctx, user.UserID)
+	} else if previousStatus != model.UserStatusActive {
+		go resubscribeByUserID(ctx, user.UserID)
+	}
+
+	go webhookUserStatus(ctx, userStatusLog)
+
+	return user, nil
+}
+
+// RetrieveUserStatusLogs retrieves the audit trail of the status changes of a user
+func RetrieveUserStatusLogs(ctx context.Context, req *model.RetrieveUserStatusLogsRequest) (*model.UserStatusLogsResponse, *model.ErrorResponse) {
+	span := tracer.StartSpan(ctx, "RetrieveUserStatusLogs", "service")
+	defer tracer.Finish(span)
+
+	userStatusLogs, err := datastore.Provider(ctx).SelectUserStatusLogs(req.UserID, req.Limit, req.Offset)
+	if err != nil {
+		return nil, model.NewErrorResponse("Failed to retrieve user status logs.", http.StatusInternalServerError, model.WithError(err))
+	}
+
+	res := &model.UserStatusLogsResponse{}
+	res.UserStatusLogs = userStatusLogs
+	res.Limit = req.Limit
+	res.Offset = req.Offset
+	return res, nil
+}
+
+// reactivateExpiredSuspension stores the user as active when the suspension has expired, and subscribes the devices again.
+// A suspension expires without a request, so this is done when the expired suspension is seen first
+func reactivateExpiredSuspension(ctx context.Context, user *model.User) {
+	if !user.IsSuspensionExpired() {
+		return
+	}
+
+	req := &model.UpdateUserStatusRequest{
+		UserID: user.UserID,
+		Status: model.UserStatusActive,
+		Reason: "The suspension expired.",
+	}
+	user.UpdateStatus(req)
+	userStatusLog := req.GenerateUserStatusLog(user, model.UserStatusSuspended)
+
+	err := datastore.Provider(ctx).UpdateUserStatus(user, userStatusLog)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	go resubscribeByUserID(ctx, user.UserID)
+	go webhookUserStatus(ctx, userStatusLog)
+}
+
+// resubscribeByUserID subscribes the devices of a reactivated user to the notification topics of the rooms again
+func resubscribeByUserID(ctx context.Context, userID string) {
+	devices, err := datastore.Provider(ctx).SelectDevices(datastore.SelectDevicesOptionFilterByUserID(userID))
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	for _, device := range devices {
+		if device.NotificationDeviceID == "" {
+			continue
+		}
+		subscribeByDevice(ctx, device, nil)
+	}
+}
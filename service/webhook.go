@@ -189,3 +189,49 @@ func webhookMessage(ctx context.Context, message *model.Message, user *model.Use
 		}
 	}
 }
+
+func webhookUserStatus(ctx context.Context, userStatusLog *model.UserStatusLog) {
+	span := tracer.StartSpan(ctx, "webhookUserStatus", "service")
+	defer tracer.Finish(span)
+
+	webhooks, err := datastore.Provider(ctx).SelectWebhooks(
+		model.WebhookEventTypeUser,
+		datastore.SelectWebhooksOptionWithRoomID(datastore.RoomIDAll),
+	)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	for _, webhook := range webhooks {
+		switch webhook.Protocol {
+		case model.WebhookProtocolHTTP:
+			logger.Info(fmt.Sprintf("[HTTP][WebhookUserStatus]Start Webhook. Endpoint=[%s]", webhook.Endpoint))
+			buf := new(bytes.Buffer)
+			json.NewEncoder(buf).Encode(userStatusLog)
+
+			resp, err := http.Post(
+				webhook.Endpoint,
+				"application/json",
+				buf,
+			)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[HTTP][WebhookUserStatus]Post failure. Endpoint=[%s]. %v.", webhook.Endpoint, err))
+				continue
+			}
+			_, err = ioutil.ReadAll(resp.Body)
+			if err != nil {
+				logger.Error(fmt.Sprintf("[HTTP][WebhookUserStatus]Response body read failure. Endpoint=[%s]. %v.", webhook.Endpoint, err))
+				continue
+			}
+			if resp.StatusCode != http.StatusOK {
+				logger.Error(fmt.Sprintf("[HTTP][WebhookUserStatus]Status code is not 200. Endpoint=[%s] StatusCode[%d].", webhook.Endpoint, resp.StatusCode))
+				continue
+			}
+			logger.Info(fmt.Sprintf("[HTTP][WebhookUserStatus]Finish Webhook. Endpoint=[%s]", webhook.Endpoint))
+		case model.WebhookProtocolGRPC:
+			// The webhook service of the protocol buffers does not have an event of user status
+			logger.Error(fmt.Sprintf("[GRPC][WebhookUserStatus]Not supported. Endpoint=[%s]", webhook.Endpoint))
+		}
+	}
+}